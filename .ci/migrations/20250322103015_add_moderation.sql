@@ -0,0 +1,19 @@
+-- Modify "auction_items" table
+ALTER TABLE "auction_items" ADD COLUMN "cancelled_at" timestamptz NULL;
+-- Modify "users" table
+ALTER TABLE "users" ADD COLUMN "role" text NOT NULL DEFAULT 'user', ADD COLUMN "suspended_at" timestamptz NULL;
+-- Create "audit_logs" table
+CREATE TABLE "audit_logs" (
+  "id" uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "actor_id" uuid NOT NULL,
+  "action" text NOT NULL,
+  "target_id" uuid NOT NULL,
+  "reason" text NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_audit_logs_actor" FOREIGN KEY ("actor_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "idx_audit_logs_deleted_at" to table: "audit_logs"
+CREATE INDEX "idx_audit_logs_deleted_at" ON "audit_logs" ("deleted_at");
@@ -0,0 +1,26 @@
+-- Create "shill_findings" table
+CREATE TABLE "shill_findings" (
+  "id" uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "rule" text NOT NULL,
+  "auction_item_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "related_user_ids" text[] NULL DEFAULT '{}',
+  "detail" text NOT NULL,
+  "status" text NOT NULL DEFAULT 'pending',
+  "reviewer_id" uuid NULL,
+  "reviewed_at" timestamptz NULL,
+  "review_note" text NOT NULL DEFAULT '',
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_shill_findings_auction_item" FOREIGN KEY ("auction_item_id") REFERENCES "auction_items" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_shill_findings_reviewer" FOREIGN KEY ("reviewer_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_shill_findings_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "idx_shill_findings_deleted_at" to table: "shill_findings"
+CREATE INDEX "idx_shill_findings_deleted_at" ON "shill_findings" ("deleted_at");
+-- Create index "idx_shill_findings_rule_item_user" to table: "shill_findings"
+CREATE UNIQUE INDEX "idx_shill_findings_rule_item_user" ON "shill_findings" ("rule", "auction_item_id", "user_id");
+-- Create index "idx_shill_findings_status" to table: "shill_findings"
+CREATE INDEX "idx_shill_findings_status" ON "shill_findings" ("status");
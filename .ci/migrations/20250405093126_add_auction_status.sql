@@ -0,0 +1,13 @@
+-- Modify "auction_items" table
+ALTER TABLE "auction_items" ADD COLUMN "status" text NOT NULL DEFAULT 'draft';
+-- Backfill "status" of existing auction items
+UPDATE "auction_items" SET "status" = CASE
+  WHEN "cancelled_at" IS NOT NULL THEN 'cancelled'
+  WHEN "start_time" > now() THEN 'scheduled'
+  WHEN "end_time" > now() THEN 'live'
+  ELSE 'ended'
+END;
+-- Modify "auction_items" table
+ALTER TABLE "auction_items" DROP COLUMN "cancelled_at";
+-- Create index "idx_auction_items_status" to table: "auction_items"
+CREATE INDEX "idx_auction_items_status" ON "auction_items" ("status");
@@ -0,0 +1,25 @@
+-- Modify "auction_items" table
+ALTER TABLE "auction_items" ADD COLUMN "reserve_price" integer NOT NULL DEFAULT 0, ADD COLUMN "auto_relist_rounds" integer NOT NULL DEFAULT 0, ADD COLUMN "relisted_from_id" uuid NULL, ADD CONSTRAINT "fk_auction_items_relisted_from" FOREIGN KEY ("relisted_from_id") REFERENCES "auction_items" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION;
+-- Create index "idx_auction_items_relisted_from_id" to table: "auction_items"
+CREATE UNIQUE INDEX "idx_auction_items_relisted_from_id" ON "auction_items" ("relisted_from_id");
+-- Create "listing_templates" table
+CREATE TABLE "listing_templates" (
+  "id" uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "user_id" uuid NOT NULL,
+  "name" character varying(255) NOT NULL,
+  "title" character varying(255) NOT NULL,
+  "description" text NOT NULL,
+  "starting_price" integer NOT NULL,
+  "reserve_price" integer NOT NULL DEFAULT 0,
+  "carousels" text[] NULL DEFAULT '{}',
+  "auto_relist_rounds" integer NOT NULL DEFAULT 0,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_listing_templates_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "idx_listing_template_user_id_name" to table: "listing_templates"
+CREATE UNIQUE INDEX "idx_listing_template_user_id_name" ON "listing_templates" ("user_id", "name") WHERE (deleted_at IS NULL);
+-- Create index "idx_listing_templates_deleted_at" to table: "listing_templates"
+CREATE INDEX "idx_listing_templates_deleted_at" ON "listing_templates" ("deleted_at");
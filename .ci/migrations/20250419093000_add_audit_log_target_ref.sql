@@ -0,0 +1,2 @@
+-- Modify "audit_logs" table
+ALTER TABLE "audit_logs" ADD COLUMN "target_ref" text NOT NULL DEFAULT '';
@@ -0,0 +1,20 @@
+-- Create "outbox_events" table
+CREATE TABLE "outbox_events" (
+  "id" uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "aggregate_id" uuid NOT NULL,
+  "type" text NOT NULL,
+  "payload" jsonb NOT NULL,
+  "sent_at" timestamptz NULL,
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text NOT NULL DEFAULT '',
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_outbox_events_aggregate_id" to table: "outbox_events"
+CREATE INDEX "idx_outbox_events_aggregate_id" ON "outbox_events" ("aggregate_id");
+-- Create index "idx_outbox_events_deleted_at" to table: "outbox_events"
+CREATE INDEX "idx_outbox_events_deleted_at" ON "outbox_events" ("deleted_at");
+-- Create index "idx_outbox_events_sent_at" to table: "outbox_events"
+CREATE INDEX "idx_outbox_events_sent_at" ON "outbox_events" ("sent_at");
@@ -0,0 +1,12 @@
+-- Create "notification_payloads" table
+CREATE TABLE "notification_payloads" (
+  "id" uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "channel" text NOT NULL,
+  "payload" text NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_notification_payloads_deleted_at" to table: "notification_payloads"
+CREATE INDEX "idx_notification_payloads_deleted_at" ON "notification_payloads" ("deleted_at");
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	redisAdapter "q4/adapters/redis"
+)
+
+// DeadLetterQueue 在記憶體中保存處理失敗的消息，實作 redisAdapter.IDeadLetterQueue
+// 由 GroupConsumer 的 Message.Fail 寫入，重送時寫回原本的 Stream，只交付給處理失敗的 consumer group
+type DeadLetterQueue[T any] struct {
+	mu      sync.Mutex
+	stream  *Stream[T]
+	entries []redisAdapter.DeadLetterEntry[T]
+	ids     idGenerator
+	logger  *slog.Logger
+}
+
+// NewDeadLetterQueue 建立 stream 的 dead-letter 管理工具
+func NewDeadLetterQueue[T any](stream *Stream[T], logger *slog.Logger) (*DeadLetterQueue[T], error) {
+	if stream == nil {
+		return nil, errors.New("stream cannot be nil")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &DeadLetterQueue[T]{
+		stream: stream,
+		logger: logger.With(slog.String("caller", "DeadLetterQueue")),
+	}, nil
+}
+
+// add 記錄 consumer group 處理失敗的消息
+func (q *DeadLetterQueue[T]) add(data T, group string, failErr error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.entries = append(q.entries, redisAdapter.DeadLetterEntry[T]{
+		ID:    q.ids.next(time.Now()),
+		Data:  data,
+		Error: failErr.Error(),
+		Group: group,
+	})
+}
+
+// List 由新到舊列出 dead-letter 訊息，lastID 為上一頁最後一筆訊息的ID，空字串表示從最新的訊息開始
+func (q *DeadLetterQueue[T]) List(_ context.Context, lastID string, count int64) ([]redisAdapter.DeadLetterEntry[T], error) {
+	const op = "DeadLetterQueue.List"
+	if lastID != "" && !validID(lastID) {
+		return nil, fmt.Errorf("[%s] %w: %s", op, redisAdapter.ErrInvalidStreamID, lastID)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var entries []redisAdapter.DeadLetterEntry[T]
+	for i := len(q.entries) - 1; i >= 0 && int64(len(entries)) < count; i-- {
+		if lastID != "" && compareIDs(q.entries[i].ID, lastID) >= 0 {
+			continue
+		}
+		entries = append(entries, q.entries[i])
+	}
+	return entries, nil
+}
+
+// Get 取得指定的 dead-letter 訊息，不存在的訊息不會包含在結果中
+func (q *DeadLetterQueue[T]) Get(_ context.Context, ids ...string) ([]redisAdapter.DeadLetterEntry[T], error) {
+	const op = "DeadLetterQueue.Get"
+	if err := validateIDs(ids); err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var entries []redisAdapter.DeadLetterEntry[T]
+	for _, id := range ids {
+		if i := q.index(id); i >= 0 {
+			entries = append(entries, q.entries[i])
+		}
+	}
+	return entries, nil
+}
+
+// Replay 將 dead-letter 訊息以新的ID寫回原本的 Stream 並從 dead-letter 刪除，返回成功重送的訊息ID
+// 重送的訊息只會交付給處理失敗的 consumer group
+func (q *DeadLetterQueue[T]) Replay(_ context.Context, ids ...string) ([]string, error) {
+	const op = "DeadLetterQueue.Replay"
+	if err := validateIDs(ids); err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var replayed []string
+	for _, id := range ids {
+		i := q.index(id)
+		if i < 0 {
+			continue
+		}
+		if _, err := q.stream.replay(q.entries[i].Data, q.entries[i].Group); err != nil {
+			return replayed, fmt.Errorf("[%s] failed to add message to stream: %w", op, err)
+		}
+		q.entries = slices.Delete(q.entries, i, i+1)
+		replayed = append(replayed, id)
+	}
+	q.logger.Info("dead letters replayed", slog.Any("ids", replayed))
+	return replayed, nil
+}
+
+// Discard 刪除 dead-letter 訊息，返回成功刪除的訊息ID
+func (q *DeadLetterQueue[T]) Discard(_ context.Context, ids ...string) ([]string, error) {
+	const op = "DeadLetterQueue.Discard"
+	if err := validateIDs(ids); err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var discarded []string
+	for _, id := range ids {
+		if i := q.index(id); i >= 0 {
+			q.entries = slices.Delete(q.entries, i, i+1)
+			discarded = append(discarded, id)
+		}
+	}
+	q.logger.Info("dead letters discarded", slog.Any("ids", discarded))
+	return discarded, nil
+}
+
+// index 取得訊息在 entries 中的位置，不存在時返回-1，呼叫前需要持有鎖
+func (q *DeadLetterQueue[T]) index(id string) int {
+	return slices.IndexFunc(q.entries, func(entry redisAdapter.DeadLetterEntry[T]) bool {
+		return entry.ID == id
+	})
+}
+
+// validateIDs 檢查訊息ID的格式，和 redisAdapter.DeadLetterQueue 返回相同的錯誤
+func validateIDs(ids []string) error {
+	if len(ids) == 0 {
+		return errors.New("ids cannot be empty")
+	}
+	for _, id := range ids {
+		if !validID(id) {
+			return fmt.Errorf("%w: %s", redisAdapter.ErrInvalidStreamID, id)
+		}
+	}
+	return nil
+}
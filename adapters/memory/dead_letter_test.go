@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	redisAdapter "q4/adapters/redis"
+)
+
+func TestDeadLetterQueue(t *testing.T) {
+	stream, err := NewStream[string]()
+	require.NoError(t, err)
+	_, err = NewDeadLetterQueue[string](nil, nil)
+	assert.Error(t, err)
+	queue, err := NewDeadLetterQueue(stream, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for _, data := range []string{"1", "2", "3"} {
+		queue.add(data, "group", errors.New("failed "+data))
+	}
+	// 由新到舊列出
+	entries, err := queue.List(ctx, "", 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "3", entries[0].Data)
+	assert.Equal(t, "2", entries[1].Data)
+	entries, err = queue.List(ctx, entries[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "1", entries[0].Data)
+	first := entries[0].ID
+
+	_, err = queue.List(ctx, "invalid", 2)
+	assert.ErrorIs(t, err, redisAdapter.ErrInvalidStreamID)
+	_, err = queue.Get(ctx)
+	assert.Error(t, err)
+
+	entries, err = queue.Get(ctx, first, "0-0")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "failed 1", entries[0].Error)
+	assert.Equal(t, "group", entries[0].Group)
+
+	// 重送後寫回原本的 stream 並從 dead-letter 刪除，只有處理失敗的 consumer group 會讀取
+	stream.createGroup("group")
+	stream.createGroup("other")
+	replayed, err := queue.Replay(ctx, first, "0-0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{first}, replayed)
+	replay, err := stream.read(ctx, "group", 10)
+	require.NoError(t, err)
+	require.Len(t, replay, 1)
+	assert.Equal(t, "1", replay[0].Data)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = stream.read(timeoutCtx, "other", 10)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	//  - 重送的消息不會補送給 SSE
+	ranged, err := stream.Range("0-0")
+	require.NoError(t, err)
+	assert.Empty(t, ranged)
+
+	all, err := queue.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	discarded, err := queue.Discard(ctx, all[0].ID, first)
+	require.NoError(t, err)
+	assert.Equal(t, []string{all[0].ID}, discarded)
+	all, err = queue.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "2", all[0].Data)
+}
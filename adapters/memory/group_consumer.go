@@ -0,0 +1,343 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	redisAdapter "q4/adapters/redis"
+)
+
+type groupConsumerOptions[T any] struct {
+	logger         *slog.Logger
+	bufferSize     int
+	batchSize      int
+	strictOrdering bool
+	deadLetter     *DeadLetterQueue[T]
+	// 消息重試的策略，參考 redisAdapter.Message.Retry
+	maxAttempts int64
+	backoffBase time.Duration
+	backoffMax  time.Duration
+}
+
+type GroupConsumerOption[T any] func(*groupConsumerOptions[T])
+
+// WithGroupConsumerLogger 設置日誌記錄器
+func WithGroupConsumerLogger[T any](logger *slog.Logger) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.logger = logger
+	}
+}
+
+// WithGroupConsumerBufferSize 設置下游channel的緩衝大小
+func WithGroupConsumerBufferSize[T any](size int) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.bufferSize = size
+	}
+}
+
+// WithGroupConsumerBatchSize 設置每次從 Stream 讀取的消息數量上限，預設為1
+func WithGroupConsumerBatchSize[T any](size int) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.batchSize = size
+	}
+}
+
+// WithGroupConsumerStrictOrdering 設置是否使用嚴格順序模式，Message.Retry 會在原地等待退避時間
+func WithGroupConsumerStrictOrdering[T any](strict bool) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.strictOrdering = strict
+	}
+}
+
+// WithGroupConsumerDeadLetterQueue 設置 Message.Fail 寫入的 dead-letter queue，沒有設置時只記錄在日誌中
+func WithGroupConsumerDeadLetterQueue[T any](queue *DeadLetterQueue[T]) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.deadLetter = queue
+	}
+}
+
+// WithGroupConsumerMaxAttempts 設置消息最多投遞幾次，Message.Retry 達到上限後移動到dead-letter，預設為1表示不重試
+func WithGroupConsumerMaxAttempts[T any](n int64) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.maxAttempts = n
+	}
+}
+
+// WithGroupConsumerBackoff 設置 Message.Retry 的退避時間，從 base 開始每次加倍，最多為 max，預設為1秒到1分鐘
+func WithGroupConsumerBackoff[T any](base, max time.Duration) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.backoffBase = base
+		o.backoffMax = max
+	}
+}
+
+// GroupConsumer 以 consumer group 讀取記憶體中的 Stream，實作 redisAdapter.IGroupConsumer 和 redisAdapter.IBatchGroupConsumer
+//   - 交付的 Message 和 Redis 的版本使用相同的 Done、Fail 和 Retry
+//   - 非嚴格順序模式下 Message.Retry 只會重新投遞到同一個 GroupConsumer，不會寫回 Stream
+//
+// NOTE: 只有單一程序使用，消息被讀取後就不會再交付給同一個 group，不需要 pending 和認領的機制
+type GroupConsumer[T any] struct {
+	stream      *Stream[T]
+	group       string
+	downStream  chan *redisAdapter.Message[T]
+	batch       bool // 批次模式，以batchStream整批交付消息
+	batchStream chan []*redisAdapter.Message[T]
+	// 非嚴格順序模式下到期重試的消息
+	retries    chan *redisAdapter.Message[T]
+	ctx        context.Context
+	cancelFunc context.CancelFunc
+	wg         sync.WaitGroup
+	lifecycle  sync.Mutex // 保護 Start 和 Close
+	closed     bool
+	logger     *slog.Logger
+	options    groupConsumerOptions[T]
+}
+
+// NewGroupConsumer 建立逐筆交付消息的 GroupConsumer
+func NewGroupConsumer[T any](stream *Stream[T], group string, opts ...GroupConsumerOption[T]) (redisAdapter.IGroupConsumer[T], error) {
+	return newGroupConsumer(stream, group, opts...)
+}
+
+// NewBatchGroupConsumer 建立以批次交付消息的 GroupConsumer，每批最多 batchSize 筆消息(預設為100)
+func NewBatchGroupConsumer[T any](stream *Stream[T], group string, opts ...GroupConsumerOption[T]) (redisAdapter.IBatchGroupConsumer[T], error) {
+	gc, err := newGroupConsumer(stream, group, append([]GroupConsumerOption[T]{WithGroupConsumerBatchSize[T](100)}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	gc.batch = true
+	return gc, nil
+}
+
+func newGroupConsumer[T any](stream *Stream[T], group string, opts ...GroupConsumerOption[T]) (*GroupConsumer[T], error) {
+	if stream == nil {
+		return nil, errors.New("stream cannot be nil")
+	}
+	if group == "" {
+		return nil, errors.New("group cannot be empty")
+	}
+
+	// 默認選項
+	options := groupConsumerOptions[T]{
+		logger:      slog.Default(),
+		bufferSize:  1,
+		batchSize:   1,
+		maxAttempts: 1,
+		backoffBase: time.Second,
+		backoffMax:  time.Minute,
+	}
+
+	// 應用自定義選項
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.batchSize <= 0 {
+		return nil, errors.New("batch size must be positive")
+	}
+	if options.maxAttempts <= 0 || options.backoffBase < 0 || options.backoffMax < options.backoffBase {
+		return nil, errors.New("invalid retry options")
+	}
+
+	return &GroupConsumer[T]{
+		stream:  stream,
+		group:   group,
+		closed:  true,
+		logger:  options.logger.With(slog.String("caller", "MemoryGroupConsumer"), slog.String("group", group)),
+		options: options,
+	}, nil
+}
+
+func (s *GroupConsumer[T]) Start() error {
+	s.lifecycle.Lock()
+	defer s.lifecycle.Unlock()
+	if !s.closed {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if s.batch {
+		s.batchStream = make(chan []*redisAdapter.Message[T], s.options.bufferSize)
+	} else {
+		s.downStream = make(chan *redisAdapter.Message[T], s.options.bufferSize)
+	}
+	s.retries = make(chan *redisAdapter.Message[T])
+	s.ctx = ctx
+	s.cancelFunc = cancel
+	s.closed = false
+	s.stream.createGroup(s.group)
+	s.logger.Info("starting group consumer")
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.logger.Info("group consumer goroutine stopped")
+		defer func() {
+			if s.batch {
+				close(s.batchStream)
+			} else {
+				close(s.downStream)
+			}
+		}()
+
+		// 讀取 Stream 的 goroutine，和重試的消息一起交給下游
+		entries := make(chan []StreamEntry[T])
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer close(entries)
+			for {
+				batch, err := s.stream.read(ctx, s.group, s.options.batchSize)
+				if err != nil {
+					if !errors.Is(err, context.Canceled) {
+						s.logger.Error("failed to read stream", slog.Any("error", err))
+					}
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case entries <- batch:
+				}
+			}
+		}()
+
+		for {
+			var messages []*redisAdapter.Message[T]
+			select {
+			case <-ctx.Done():
+				return
+			case message := <-s.retries:
+				messages = append(messages, message)
+			case batch, ok := <-entries:
+				if !ok {
+					return
+				}
+				for _, entry := range batch {
+					messages = append(messages, redisAdapter.NewMessage(entry.ID, entry.Data, 1, s))
+				}
+			}
+			if err := s.moveToDownStream(ctx, messages); err != nil {
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Subscribe 訂閱Stream，返回Message通道
+func (s *GroupConsumer[T]) Subscribe() <-chan *redisAdapter.Message[T] {
+	return s.downStream
+}
+
+// SubscribeBatch 訂閱Stream，返回批次的Message通道，只有以 NewBatchGroupConsumer 建立時可以使用
+func (s *GroupConsumer[T]) SubscribeBatch() <-chan []*redisAdapter.Message[T] {
+	return s.batchStream
+}
+
+func (s *GroupConsumer[T]) Close() error {
+	s.lifecycle.Lock()
+	defer s.lifecycle.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.logger.Info("closing group consumer")
+	s.closed = true
+	s.cancelFunc()
+
+	s.wg.Wait()
+	s.logger.Info("group consumer closed gracefully")
+	return nil
+}
+
+// moveToDownStream 處理發送消息到下游channel，批次模式下整批交付，否則逐筆交付
+func (s *GroupConsumer[T]) moveToDownStream(ctx context.Context, messages []*redisAdapter.Message[T]) error {
+	if s.batch {
+		select {
+		case <-ctx.Done():
+			return context.Canceled
+		case s.batchStream <- messages:
+			return nil
+		}
+	}
+	for _, message := range messages {
+		select {
+		case <-ctx.Done():
+			return context.Canceled
+		case s.downStream <- message:
+		}
+	}
+	return nil
+}
+
+// Done 實作 redisAdapter.MessageHandler，消息在讀取時就已經從 group 中移除，不需要確認
+func (s *GroupConsumer[T]) Done(context.Context, *redisAdapter.Message[T]) error {
+	return nil
+}
+
+// Fail 實作 redisAdapter.MessageHandler，將消息移動到 dead-letter queue
+func (s *GroupConsumer[T]) Fail(_ context.Context, m *redisAdapter.Message[T], failErr error) error {
+	if s.options.deadLetter == nil {
+		s.logger.Error("message failed without dead letter queue, dropped", slog.String("id", m.ID()), slog.Any("error", failErr))
+		return nil
+	}
+	s.options.deadLetter.add(m.Data, s.group, failErr)
+	return nil
+}
+
+// Retry 實作 redisAdapter.MessageHandler，行為和 redisAdapter.Message.Retry 相同
+//   - 非嚴格順序模式: 在退避時間後以新的投遞次數重新交付，返回 false
+//   - 嚴格順序模式: 在原地等待退避時間後返回 true
+func (s *GroupConsumer[T]) Retry(ctx context.Context, m *redisAdapter.Message[T], failErr error) (bool, error) {
+	attempt := m.Attempt()
+	if attempt >= s.options.maxAttempts {
+		return false, s.Fail(ctx, m, failErr)
+	}
+	delay := s.backoff(attempt)
+
+	if s.options.strictOrdering {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-timer.C:
+			return true, nil
+		}
+	}
+
+	retry := redisAdapter.NewMessage(m.ID(), m.Data, attempt+1, s)
+	consumerCtx := s.ctx
+	if consumerCtx == nil || consumerCtx.Err() != nil {
+		s.logger.Warn("group consumer closed before retry, message dropped", slog.String("id", retry.ID()))
+		return false, nil
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-consumerCtx.Done():
+			s.logger.Warn("group consumer closed before retry, message dropped", slog.String("id", retry.ID()))
+			return
+		case <-timer.C:
+		}
+		select {
+		case <-consumerCtx.Done():
+			s.logger.Warn("group consumer closed before retry, message dropped", slog.String("id", retry.ID()))
+		case s.retries <- retry:
+		}
+	}()
+	return false, nil
+}
+
+// backoff 取得第 attempt 次投遞失敗後的等待時間，以指數成長並且不超過 backoffMax
+func (s *GroupConsumer[T]) backoff(attempt int64) time.Duration {
+	delay := s.options.backoffBase
+	for i := int64(1); i < attempt && delay < s.options.backoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, s.options.backoffMax)
+}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	redisAdapter "q4/adapters/redis"
+)
+
+func TestGroupConsumer_Retry(t *testing.T) {
+	stream, err := NewStream[string]()
+	require.NoError(t, err)
+	queue, err := NewDeadLetterQueue(stream, nil)
+	require.NoError(t, err)
+	consumer, err := NewGroupConsumer(stream, "group",
+		WithGroupConsumerMaxAttempts[string](2),
+		WithGroupConsumerBackoff[string](time.Millisecond, time.Millisecond),
+		WithGroupConsumerDeadLetterQueue(queue),
+	)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Start())
+	defer consumer.Close()
+	ctx := context.Background()
+	receive := func() *redisAdapter.Message[string] {
+		select {
+		case msg := <-consumer.Subscribe():
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for message")
+			return nil
+		}
+	}
+
+	id, err := stream.Add("data")
+	require.NoError(t, err)
+	msg := receive()
+	assert.Equal(t, id, msg.ID())
+	assert.Equal(t, int64(1), msg.Attempt())
+
+	// 非嚴格順序模式下在退避時間後重新交付
+	retry, err := msg.Retry(ctx, errors.New("failed"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+	msg = receive()
+	assert.Equal(t, id, msg.ID())
+	assert.Equal(t, int64(2), msg.Attempt())
+
+	// 達到最大投遞次數後移動到 dead-letter
+	retry, err = msg.Retry(ctx, errors.New("failed again"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+	entries, err := queue.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "data", entries[0].Data)
+	assert.Equal(t, "failed again", entries[0].Error)
+}
+
+func TestBatchGroupConsumer_StrictOrdering(t *testing.T) {
+	stream, err := NewStream[string]()
+	require.NoError(t, err)
+	consumer, err := NewBatchGroupConsumer(stream, "group",
+		WithGroupConsumerStrictOrdering[string](true),
+		WithGroupConsumerBatchSize[string](2),
+		WithGroupConsumerMaxAttempts[string](3),
+		WithGroupConsumerBackoff[string](time.Millisecond, time.Millisecond),
+	)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Start())
+	defer consumer.Close()
+	ctx := context.Background()
+
+	for _, data := range []string{"1", "2", "3"} {
+		_, err := stream.Add(data)
+		require.NoError(t, err)
+	}
+	var batch []*redisAdapter.Message[string]
+	select {
+	case batch = <-consumer.SubscribeBatch():
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for batch")
+	}
+	require.Len(t, batch, 2)
+	assert.Equal(t, "1", batch[0].Data)
+
+	// 嚴格順序模式下在原地等待後返回 true
+	retry, err := batch[0].Retry(ctx, errors.New("failed"))
+	require.NoError(t, err)
+	assert.True(t, retry)
+	assert.Equal(t, int64(2), batch[0].Attempt())
+	require.NoError(t, redisAdapter.DoneMessages(ctx, batch...))
+
+	// 等待中的 context 取消時返回錯誤
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = batch[1].Retry(cancelled, errors.New("failed"))
+	assert.NoError(t, err, "done message cannot be retried")
+
+	select {
+	case batch = <-consumer.SubscribeBatch():
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for batch")
+	}
+	require.Len(t, batch, 1)
+	assert.Equal(t, "3", batch[0].Data)
+	_, err = batch[0].Retry(cancelled, errors.New("failed"))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewGroupConsumer(t *testing.T) {
+	stream, err := NewStream[string]()
+	require.NoError(t, err)
+	_, err = NewGroupConsumer[string](nil, "group")
+	assert.Error(t, err)
+	_, err = NewGroupConsumer(stream, "")
+	assert.Error(t, err)
+	_, err = NewGroupConsumer(stream, "group", WithGroupConsumerBatchSize[string](0))
+	assert.Error(t, err)
+	_, err = NewGroupConsumer(stream, "group", WithGroupConsumerMaxAttempts[string](0))
+	assert.Error(t, err)
+	_, err = NewGroupConsumer(stream, "group", WithGroupConsumerBackoff[string](time.Minute, time.Second))
+	assert.Error(t, err)
+}
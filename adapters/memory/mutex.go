@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	redisAdapter "q4/adapters/redis"
+)
+
+var (
+	// 程序內所有的鎖，同一個 key 的 Mutex 共用同一把鎖
+	locksMu sync.Mutex
+	locks   = map[string]chan struct{}{}
+)
+
+// Mutex 程序內的互斥鎖，實作 redisAdapter.IAutoRenewMutex，用於不使用 Redis 的單一程序模式
+// 鎖不會過期，不需要續期，Lock 返回的 context 會在 Unlock 時取消
+type Mutex struct {
+	key    string
+	sem    chan struct{}
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	locked bool
+}
+
+// NewMutex 建立 key 對應的互斥鎖，同一個 key 的 Mutex 之間互斥
+func NewMutex(key string) redisAdapter.IAutoRenewMutex {
+	locksMu.Lock()
+	defer locksMu.Unlock()
+	sem, ok := locks[key]
+	if !ok {
+		sem = make(chan struct{}, 1)
+		locks[key] = sem
+	}
+	return &Mutex{key: key, sem: sem}
+}
+
+// Lock 等待取得鎖，支持通過context取消
+func (m *Mutex) Lock(ctx context.Context) (context.Context, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case m.sem <- struct{}{}:
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lockCtx, cancel := context.WithCancel(ctx)
+	m.cancel = cancel
+	m.locked = true
+	return lockCtx, nil
+}
+
+// Unlock 釋放鎖，沒有持有鎖時返回 false
+func (m *Mutex) Unlock() (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.locked {
+		return false, nil
+	}
+	m.locked = false
+	m.cancel()
+	<-m.sem
+	return true, nil
+}
+
+// Valid 檢查是否持有鎖
+func (m *Mutex) Valid() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.locked
+}
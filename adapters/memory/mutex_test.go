@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutex(t *testing.T) {
+	ctx := context.Background()
+	m1 := NewMutex("test-mutex")
+	m2 := NewMutex("test-mutex")
+	other := NewMutex("test-mutex-other")
+
+	lockCtx, err := m1.Lock(ctx)
+	require.NoError(t, err)
+	assert.True(t, m1.Valid())
+	assert.False(t, m2.Valid())
+
+	// 不同 key 的鎖互不影響
+	_, err = other.Lock(ctx)
+	require.NoError(t, err)
+	ok, err := other.Unlock()
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// 同一個 key 的鎖需要等待釋放
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = m2.Lock(timeoutCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ok, err = m1.Unlock()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.ErrorIs(t, lockCtx.Err(), context.Canceled)
+	ok, err = m1.Unlock()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = m2.Lock(ctx)
+	require.NoError(t, err)
+	assert.True(t, m2.Valid())
+	_, err = m2.Unlock()
+	assert.NoError(t, err)
+}
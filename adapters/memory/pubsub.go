@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"sync"
+)
+
+type pubSubOptions struct {
+	bufferSize int
+}
+
+type PubSubOption func(*pubSubOptions)
+
+// WithPubSubBufferSize 設置下游channel的緩衝大小，預設為100
+func WithPubSubBufferSize(size int) PubSubOption {
+	return func(o *pubSubOptions) {
+		o.bufferSize = size
+	}
+}
+
+// PubSub 在程序內傳遞消息，實作 sse.Subscriber 和 sse.Publisher，用於不使用 Redis 的單一程序模式
+// NOTE: 下游的緩衝已滿時 Publish 會等待到下游讀取或 PubSub 關閉
+type PubSub[T any] struct {
+	downStream chan T
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewPubSub 建立 PubSub，建立後就可以發送消息
+func NewPubSub[T any](opts ...PubSubOption) *PubSub[T] {
+	// 默認選項
+	options := pubSubOptions{
+		bufferSize: 100,
+	}
+
+	// 應用自定義選項
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &PubSub[T]{
+		downStream: make(chan T, max(options.bufferSize, 0)),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start 實作和其他消息來源相同的介面，PubSub 不需要啟動
+func (p *PubSub[T]) Start() {}
+
+// Subscribe 訂閱消息
+// NOTE: 關閉時不會關閉返回的channel，避免發送中的 Publish 寫入已關閉的channel
+func (p *PubSub[T]) Subscribe() <-chan T {
+	return p.downStream
+}
+
+// Publish 發送消息，PubSub 已經關閉時返回 ErrClosed
+func (p *PubSub[T]) Publish(data T) error {
+	select {
+	case <-p.done:
+		return ErrClosed
+	default:
+	}
+	select {
+	case <-p.done:
+		return ErrClosed
+	case p.downStream <- data:
+		return nil
+	}
+}
+
+// Close 停止發送消息，等待中的 Publish 會返回 ErrClosed
+func (p *PubSub[T]) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+}
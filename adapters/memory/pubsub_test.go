@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubSub(t *testing.T) {
+	pubSub := NewPubSub[string](WithPubSubBufferSize(1))
+	pubSub.Start()
+
+	require.NoError(t, pubSub.Publish("1"))
+	assert.Equal(t, "1", <-pubSub.Subscribe())
+
+	// 緩衝已滿時等待到關閉
+	require.NoError(t, pubSub.Publish("2"))
+	done := make(chan error)
+	go func() {
+		done <- pubSub.Publish("3")
+	}()
+	time.Sleep(10 * time.Millisecond)
+	pubSub.Close()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, ErrClosed)
+	case <-time.After(time.Second):
+		t.Fatal("publish should return after close")
+	}
+	assert.ErrorIs(t, pubSub.Publish("4"), ErrClosed)
+	pubSub.Close()
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	redisAdapter "q4/adapters/redis"
+)
+
+// RateLimiter 在記憶體中以滑動時間窗限流，實作 redisAdapter.IRateLimiter，用於不使用 Redis 的單一程序模式
+// 限流的規則和 redisAdapter.RateLimiter 相同，只是計數不會在多個實例間共用
+type RateLimiter struct {
+	mu     sync.Mutex
+	limit  int64
+	window time.Duration
+	// 每個鍵在時間窗內的請求時間，由舊到新排列
+	requests  map[string][]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewRateLimiter 建立一個在記憶體中計數的滑動時間窗限流器
+//   - limit: 時間窗內允許的請求數
+//   - window: 時間窗長度
+func NewRateLimiter(limit int64, window time.Duration) (redisAdapter.IRateLimiter, error) {
+	if limit <= 0 || window < time.Millisecond {
+		return nil, errors.New("limit and window must be positive")
+	}
+	return &RateLimiter{
+		limit:    limit,
+		window:   window,
+		requests: map[string][]time.Time{},
+		now:      time.Now,
+	}, nil
+}
+
+// Allow 檢查指定的鍵是否還能請求，允許時會同時記錄本次請求
+func (l *RateLimiter) Allow(_ context.Context, key string) (redisAdapter.RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	// 定期清除整個時間窗都沒有請求的鍵，避免記憶體無限增長
+	if now.Sub(l.lastSweep) >= l.window {
+		for k, times := range l.requests {
+			if len(l.prune(times, now)) == 0 {
+				delete(l.requests, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	times := l.prune(l.requests[key], now)
+	count := int64(len(times))
+	if count < l.limit {
+		l.requests[key] = append(times, now)
+		return redisAdapter.RateLimitResult{
+			Allowed:   true,
+			Remaining: l.limit - count - 1,
+			Token:     strconv.FormatInt(now.UnixNano(), 10),
+		}, nil
+	}
+	l.requests[key] = times
+	// 最早的請求離開時間窗後才能再次請求
+	return redisAdapter.RateLimitResult{
+		RetryAfter: max(times[0].Add(l.window).Sub(now), time.Millisecond),
+	}, nil
+}
+
+// Refund 退回 Allow 記錄的請求，token 為請求的時間，同一時間的請求只會退回一筆
+func (l *RateLimiter) Refund(_ context.Context, key, token string) error {
+	at, err := strconv.ParseInt(token, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid token: %s", token)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	times := l.requests[key]
+	for i, t := range times {
+		if t.UnixNano() == at {
+			l.requests[key] = append(times[:i:i], times[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// prune 移除時間窗以外的請求紀錄
+func (l *RateLimiter) prune(times []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-l.window)
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	return times[i:]
+}
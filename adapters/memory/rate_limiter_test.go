@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	_, err := NewRateLimiter(0, time.Second)
+	assert.Error(t, err)
+	_, err = NewRateLimiter(1, 0)
+	assert.Error(t, err)
+
+	limiter, err := NewRateLimiter(2, time.Second)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	limiter.(*RateLimiter).now = func() time.Time { return now }
+	ctx := context.Background()
+
+	result, err := limiter.Allow(ctx, "user")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, int64(1), result.Remaining)
+	now = now.Add(400 * time.Millisecond)
+	result, err = limiter.Allow(ctx, "user")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, int64(0), result.Remaining)
+
+	// 達到上限時以最早的請求計算等待時間
+	now = now.Add(100 * time.Millisecond)
+	result, err = limiter.Allow(ctx, "user")
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+	// 不同的鍵各自計數
+	result, err = limiter.Allow(ctx, "other")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// 最早的請求離開時間窗後可以再次請求
+	now = now.Add(500 * time.Millisecond)
+	result, err = limiter.Allow(ctx, "user")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// 退回的請求不再佔用次數
+	require.NoError(t, limiter.Refund(ctx, "user", result.Token))
+	result, err = limiter.Allow(ctx, "user")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	result, err = limiter.Allow(ctx, "user")
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Error(t, limiter.Refund(ctx, "user", "invalid"))
+}
@@ -0,0 +1,274 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrClosed 表示 Stream 或 PubSub 已經關閉
+var ErrClosed = errors.New("closed")
+
+// StreamEntry 代表 Stream 中的一筆消息
+type StreamEntry[T any] struct {
+	// 和 Redis stream 相同格式的ID(<ms>-<seq>)，依照寫入的順序遞增
+	ID   string
+	Data T
+	// 是否為重送的 dead-letter 消息，Range 不會返回重送的消息，參考 redisAdapter.ReplayGroupField
+	Replayed bool
+	// 重送的消息要交付的 consumer group，其他 consumer group 讀取時略過，空字串表示交付給所有的 consumer group
+	Group string
+}
+
+type streamOptions struct {
+	maxLen int
+}
+
+type StreamOption func(*streamOptions)
+
+// WithStreamMaxLen 設置已經被所有 consumer group 讀取的消息最多保留的筆數，預設為10000
+// 還沒有被讀取的消息不會被刪除
+func WithStreamMaxLen(n int) StreamOption {
+	return func(o *streamOptions) {
+		o.maxLen = n
+	}
+}
+
+// Stream 在記憶體中模擬 Redis stream，用於不使用 Redis 的單一程序模式
+//   - 每個 consumer group 各自記錄讀取的位置，同一個 group 的每筆消息只會交付一次
+//   - 實作 redisAdapter.IProducer，寫入時同步完成，不需要 Flush
+//
+// NOTE: 消息只保存在記憶體中，程序結束時還沒有被處理的消息會遺失
+type Stream[T any] struct {
+	mu      sync.Mutex
+	entries []StreamEntry[T]
+	// entries[0] 的序號，刪除舊消息後遞增
+	offset int
+	// 每個 consumer group 下一筆要讀取的序號
+	groups map[string]int
+	ids    idGenerator
+	// 寫入新消息或關閉時關閉並替換，用於喚醒等待中的讀取
+	notify  chan struct{}
+	closed  bool
+	options streamOptions
+}
+
+// NewStream 建立 Stream
+func NewStream[T any](opts ...StreamOption) (*Stream[T], error) {
+	// 默認選項
+	options := streamOptions{
+		maxLen: 10000,
+	}
+
+	// 應用自定義選項
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.maxLen < 0 {
+		return nil, errors.New("max len cannot be negative")
+	}
+
+	return &Stream[T]{
+		groups:  map[string]int{},
+		notify:  make(chan struct{}),
+		options: options,
+	}, nil
+}
+
+// Add 寫入消息並返回消息的ID
+func (s *Stream[T]) Add(data T) (string, error) {
+	return s.add(StreamEntry[T]{Data: data})
+}
+
+// replay 寫入重送給 group 的 dead-letter 消息並返回消息的ID
+func (s *Stream[T]) replay(data T, group string) (string, error) {
+	return s.add(StreamEntry[T]{Data: data, Replayed: true, Group: group})
+}
+
+func (s *Stream[T]) add(entry StreamEntry[T]) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return "", ErrClosed
+	}
+	entry.ID = s.ids.next(time.Now())
+	s.entries = append(s.entries, entry)
+	s.trim()
+	close(s.notify)
+	s.notify = make(chan struct{})
+	return entry.ID, nil
+}
+
+// idGenerator 以和 Redis 相同的規則產生遞增的ID
+type idGenerator struct {
+	lastMs  int64
+	lastSeq int64
+}
+
+// next 產生下一個ID，同一毫秒內或時間倒退時沿用上一個ID的時間並遞增序號
+func (g *idGenerator) next(now time.Time) string {
+	ms := now.UnixMilli()
+	if ms <= g.lastMs {
+		g.lastSeq++
+	} else {
+		g.lastMs = ms
+		g.lastSeq = 0
+	}
+	return fmt.Sprintf("%d-%d", g.lastMs, g.lastSeq)
+}
+
+// compareIDs 比較兩個ID的先後，a 在 b 之前時返回負數，相同時返回0
+// NOTE: ID 需要先以 validID 檢查格式
+func compareIDs(a, b string) int {
+	aMs, aSeq := parseID(a)
+	bMs, bSeq := parseID(b)
+	if c := cmp.Compare(aMs, bMs); c != 0 {
+		return c
+	}
+	return cmp.Compare(aSeq, bSeq)
+}
+
+func parseID(id string) (uint64, uint64) {
+	ms, seq, _ := strings.Cut(id, "-")
+	msValue, _ := strconv.ParseUint(ms, 10, 64)
+	seqValue, _ := strconv.ParseUint(seq, 10, 64)
+	return msValue, seqValue
+}
+
+// validID 檢查ID是否為 <ms>-<seq> 的格式
+func validID(id string) bool {
+	ms, seq, found := strings.Cut(id, "-")
+	if !found {
+		return false
+	}
+	if _, err := strconv.ParseUint(ms, 10, 64); err != nil {
+		return false
+	}
+	_, err := strconv.ParseUint(seq, 10, 64)
+	return err == nil
+}
+
+// Len 取得目前保留的消息數量
+func (s *Stream[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// Range 依序返回目前保留的消息中ID在 id 之後的消息，不影響 consumer group 的讀取位置，重送的消息不會返回
+func (s *Stream[T]) Range(id string) ([]StreamEntry[T], error) {
+	if !validID(id) {
+		return nil, fmt.Errorf("invalid stream id %q", id)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	start, _ := slices.BinarySearchFunc(s.entries, id, func(entry StreamEntry[T], id string) int {
+		return compareIDs(entry.ID, id)
+	})
+	if start < len(s.entries) && s.entries[start].ID == id {
+		start++
+	}
+	return slices.DeleteFunc(slices.Clone(s.entries[start:]), func(entry StreamEntry[T]) bool {
+		return entry.Replayed
+	}), nil
+}
+
+// createGroup 建立 consumer group，新的 group 從目前保留的第一筆消息開始讀取，已經存在時不做任何處理
+func (s *Stream[T]) createGroup(group string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.groups[group]; !ok {
+		s.groups[group] = s.offset
+	}
+}
+
+// read 讀取 group 還沒有讀取的消息，最多 count 筆，沒有新消息時等待到寫入新消息或 ctx 結束
+// 重送給其他 consumer group 的消息會被略過
+func (s *Stream[T]) read(ctx context.Context, group string, count int) ([]StreamEntry[T], error) {
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return nil, ErrClosed
+		}
+		cursor := max(s.groups[group], s.offset)
+		if n := min(s.offset+len(s.entries)-cursor, count); n > 0 {
+			start := cursor - s.offset
+			entries := make([]StreamEntry[T], n)
+			copy(entries, s.entries[start:start+n])
+			s.groups[group] = cursor + n
+			s.trim()
+			s.mu.Unlock()
+			entries = slices.DeleteFunc(entries, func(entry StreamEntry[T]) bool {
+				return entry.Replayed && entry.Group != "" && entry.Group != group
+			})
+			if len(entries) == 0 {
+				continue
+			}
+			return entries, nil
+		}
+		notify := s.notify
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// trim 刪除已經被所有 consumer group 讀取且超過保留筆數的舊消息，呼叫前需要持有鎖
+func (s *Stream[T]) trim() {
+	consumed := s.offset + len(s.entries)
+	for _, cursor := range s.groups {
+		consumed = min(consumed, cursor)
+	}
+	drop := min(consumed-s.offset, len(s.entries)-s.options.maxLen)
+	if drop <= 0 {
+		return
+	}
+	// 清除引用讓被刪除的消息可以被回收，底層陣列會在之後 append 擴充時重新配置
+	clear(s.entries[:drop])
+	s.entries = s.entries[drop:]
+	s.offset += drop
+}
+
+// Start 實作 redisAdapter.IProducer，Stream 不需要啟動
+func (s *Stream[T]) Start() {}
+
+// Publish 寫入消息
+func (s *Stream[T]) Publish(data T) error {
+	_, err := s.Add(data)
+	return err
+}
+
+// PublishSync 寫入消息並返回消息的ID
+func (s *Stream[T]) PublishSync(ctx context.Context, data T) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return s.Add(data)
+}
+
+// Flush 實作 redisAdapter.IProducer，消息在寫入時就已經完成
+func (s *Stream[T]) Flush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Close 停止寫入並喚醒所有等待中的讀取
+func (s *Stream[T]) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.notify)
+}
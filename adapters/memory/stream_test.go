@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDGenerator(t *testing.T) {
+	var ids idGenerator
+	now := time.UnixMilli(1000)
+	assert.Equal(t, "1000-0", ids.next(now))
+	assert.Equal(t, "1000-1", ids.next(now))
+	// 時間倒退時沿用上一個ID的時間
+	assert.Equal(t, "1000-2", ids.next(time.UnixMilli(999)))
+	assert.Equal(t, "1001-0", ids.next(time.UnixMilli(1001)))
+
+	assert.Negative(t, compareIDs("1000-2", "1001-0"))
+	assert.Negative(t, compareIDs("999-9", "1000-0"))
+	assert.Zero(t, compareIDs("1-1", "1-1"))
+	for _, id := range []string{"", "1", "a-0", "1-", "-1"} {
+		assert.False(t, validID(id), id)
+	}
+}
+
+func TestStream_ReadGroup(t *testing.T) {
+	_, err := NewStream[string](WithStreamMaxLen(-1))
+	assert.Error(t, err)
+	stream, err := NewStream[string](WithStreamMaxLen(1))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	stream.createGroup("a")
+	stream.createGroup("b")
+	for _, data := range []string{"1", "2", "3"} {
+		_, err := stream.Add(data)
+		require.NoError(t, err)
+	}
+	entries, err := stream.read(ctx, "a", 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "1", entries[0].Data)
+	assert.Equal(t, "2", entries[1].Data)
+	// 還沒有被所有 group 讀取的消息不會被刪除
+	assert.Equal(t, 3, stream.Len())
+
+	entries, err = stream.read(ctx, "b", 10)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+	// 所有 group 都讀取過的消息只保留 maxLen 筆
+	assert.Equal(t, 1, stream.Len())
+	entries, err = stream.read(ctx, "a", 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "3", entries[0].Data)
+	assert.Equal(t, 1, stream.Len())
+
+	// 沒有新消息時等待到寫入新消息
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		stream.Add("4")
+	}()
+	entries, err = stream.read(ctx, "a", 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "4", entries[0].Data)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = stream.read(timeoutCtx, "a", 10)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	stream.Close()
+	_, err = stream.read(ctx, "b", 10)
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.ErrorIs(t, stream.Publish("5"), ErrClosed)
+}
+
+func TestStream_Range(t *testing.T) {
+	stream, err := NewStream[string]()
+	require.NoError(t, err)
+	var ids []string
+	for _, data := range []string{"1", "2", "3"} {
+		id, err := stream.Add(data)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	entries, err := stream.Range(ids[0])
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, ids[1], entries[0].ID)
+	assert.Equal(t, "3", entries[1].Data)
+
+	// 不存在的ID從之後的消息開始
+	entries, err = stream.Range("0-0")
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+	entries, err = stream.Range(ids[2])
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = stream.Range("invalid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,327 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// NOTIFY 的內容最多為 8000 bytes，預設保留一些空間
+const defaultMaxPayload = 7900
+
+// 通知內容的第一個字元表示內容的類型
+const (
+	// 直接以 NOTIFY 傳送的內容
+	inlinePrefix = 'd'
+	// 寫入資料表的內容，後面接著紀錄的ID
+	spillPrefix = 'r'
+)
+
+type notifierOptions struct {
+	logger               *slog.Logger
+	bufferSize           int
+	maxPayload           int
+	spillTable           string
+	spillTTL             time.Duration
+	publishTimeout       time.Duration
+	minReconnectInterval time.Duration
+	maxReconnectInterval time.Duration
+	pingInterval         time.Duration
+}
+
+type NotifierOption func(*notifierOptions)
+
+// WithNotifierLogger 設置日誌記錄器
+func WithNotifierLogger(logger *slog.Logger) NotifierOption {
+	return func(o *notifierOptions) {
+		o.logger = logger
+	}
+}
+
+// WithNotifierBufferSize 設置下游channel的緩衝大小
+func WithNotifierBufferSize(size int) NotifierOption {
+	return func(o *notifierOptions) {
+		o.bufferSize = size
+	}
+}
+
+// WithNotifierMaxPayload 設置直接以 NOTIFY 傳送的最大長度，超過時寫入資料表，預設為 7900 bytes
+func WithNotifierMaxPayload(size int) NotifierOption {
+	return func(o *notifierOptions) {
+		o.maxPayload = size
+	}
+}
+
+// WithNotifierSpillTable 設置保存過長內容的資料表，可以包含 schema，預設為 notification_payloads
+// 資料表需要有 id、created_at、updated_at、channel 和 payload 欄位，參考 models.NotificationPayload
+func WithNotifierSpillTable(table string) NotifierOption {
+	return func(o *notifierOptions) {
+		o.spillTable = table
+	}
+}
+
+// WithNotifierSpillTTL 設置資料表中的內容保留的時間，預設為1小時
+func WithNotifierSpillTTL(ttl time.Duration) NotifierOption {
+	return func(o *notifierOptions) {
+		o.spillTTL = ttl
+	}
+}
+
+// WithNotifierPublishTimeout 設置發送通知的超時時間，預設為5秒
+func WithNotifierPublishTimeout(d time.Duration) NotifierOption {
+	return func(o *notifierOptions) {
+		o.publishTimeout = d
+	}
+}
+
+// WithNotifierReconnectInterval 設置連線中斷後重新連線的最小和最大間隔，每次失敗時加倍，預設為1秒和1分鐘
+func WithNotifierReconnectInterval(min, max time.Duration) NotifierOption {
+	return func(o *notifierOptions) {
+		o.minReconnectInterval = min
+		o.maxReconnectInterval = max
+	}
+}
+
+// Notifier 以 PostgreSQL 的 LISTEN/NOTIFY 發送和接收消息，實作 sse.Subscriber 和 sse.Publisher
+//   - 消息以 JSON 編碼，超過 NOTIFY 長度限制的消息會寫入資料表，只以 NOTIFY 傳送紀錄的ID
+//   - 連線中斷時會自動重新連線並重新 LISTEN
+//
+// NOTE: NOTIFY 不會保存消息，接收端斷線期間發送的消息會遺失
+type Notifier[T any] struct {
+	db         *sql.DB
+	dsn        string
+	channel    string
+	table      string
+	listener   *pq.Listener
+	downStream chan T
+	cancelFunc context.CancelFunc
+	wg         sync.WaitGroup
+	closed     bool
+	logger     *slog.Logger
+	options    notifierOptions
+}
+
+// NewNotifier 建立以 channel 傳送消息的 Notifier，db 用於發送通知和讀寫資料表，dsn 用於建立 LISTEN 專用的連線
+func NewNotifier[T any](db *sql.DB, dsn, channel string, opts ...NotifierOption) (*Notifier[T], error) {
+	if db == nil {
+		return nil, errors.New("db cannot be nil")
+	}
+	if dsn == "" {
+		return nil, errors.New("dsn cannot be empty")
+	}
+	if channel == "" {
+		return nil, errors.New("channel cannot be empty")
+	}
+
+	// 默認選項
+	options := notifierOptions{
+		logger:               slog.Default(),
+		bufferSize:           100,
+		maxPayload:           defaultMaxPayload,
+		spillTable:           "notification_payloads",
+		spillTTL:             time.Hour,
+		publishTimeout:       5 * time.Second,
+		minReconnectInterval: time.Second,
+		maxReconnectInterval: time.Minute,
+		pingInterval:         90 * time.Second,
+	}
+
+	// 應用自定義選項
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.maxPayload <= 0 || options.maxPayload > 7999 {
+		return nil, errors.New("max payload must be between 1 and 7999")
+	}
+	if options.spillTable == "" || options.spillTTL <= 0 || options.publishTimeout <= 0 {
+		return nil, errors.New("invalid spill options")
+	}
+	if options.minReconnectInterval <= 0 || options.maxReconnectInterval < options.minReconnectInterval {
+		return nil, errors.New("invalid reconnect interval")
+	}
+
+	return &Notifier[T]{
+		db:      db,
+		dsn:     dsn,
+		channel: channel,
+		table:   quoteTable(options.spillTable),
+		closed:  true,
+		logger:  options.logger.With(slog.String("caller", "Notifier"), slog.String("channel", channel)),
+		options: options,
+	}, nil
+}
+
+func (n *Notifier[T]) Start() {
+	if !n.closed {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	n.downStream = make(chan T, n.options.bufferSize)
+	n.closed = false
+	n.cancelFunc = cancel
+	n.listener = pq.NewListener(n.dsn, n.options.minReconnectInterval, n.options.maxReconnectInterval, n.handleEvent)
+	// 連線還沒建立時會在連線後 LISTEN，只有伺服器拒絕時才會返回錯誤
+	if err := n.listener.Listen(n.channel); err != nil {
+		n.logger.Error("listen error", slog.Any("error", err))
+	}
+	n.logger.Info("starting notifier")
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		defer n.logger.Info("notifier goroutine stopped")
+		defer close(n.downStream)
+
+		ping := time.NewTicker(n.options.pingInterval)
+		defer ping.Stop()
+		cleanup := time.NewTicker(n.options.spillTTL / 2)
+		defer cleanup.Stop()
+		notifications := n.listener.NotificationChannel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ping.C:
+				// 長時間沒有通知時確認連線是否正常，連線中斷時 Listener 會自動重新連線
+				go n.listener.Ping()
+			case <-cleanup.C:
+				if err := n.cleanupSpilled(ctx); err != nil && !errors.Is(err, context.Canceled) {
+					n.logger.Error("cleanup spilled payloads error", slog.Any("error", err))
+				}
+			case notification := <-notifications:
+				// 重新連線後會收到 nil，表示斷線期間的通知可能已經遺失
+				if notification == nil {
+					continue
+				}
+				data, err := n.decode(ctx, notification.Extra)
+				if err != nil {
+					n.logger.Error("failed to parse notification", slog.Any("error", err))
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case n.downStream <- data:
+				}
+			}
+		}
+	}()
+}
+
+// handleEvent 記錄 Listener 的連線狀態
+func (n *Notifier[T]) handleEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventConnected:
+		n.logger.Info("listener connected")
+	case pq.ListenerEventDisconnected:
+		n.logger.Warn("listener disconnected", slog.Any("error", err))
+	case pq.ListenerEventReconnected:
+		n.logger.Warn("listener reconnected, notifications during disconnection may be lost")
+	case pq.ListenerEventConnectionAttemptFailed:
+		n.logger.Error("listener connection attempt failed", slog.Any("error", err))
+	}
+}
+
+// Subscribe 訂閱通知
+func (n *Notifier[T]) Subscribe() <-chan T {
+	return n.downStream
+}
+
+// Publish 發送通知，超過長度限制的消息會在同一個交易中寫入資料表和發送通知
+// NOTE: 不需要先呼叫 Start，只接收通知的實例才需要啟動
+func (n *Notifier[T]) Publish(data T) error {
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("json marshal error: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), n.options.publishTimeout)
+	defer cancel()
+
+	if len(bytes)+1 <= n.options.maxPayload {
+		if _, err := n.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", n.channel, string(inlinePrefix)+string(bytes)); err != nil {
+			return fmt.Errorf("notify error: %w", err)
+		}
+		return nil
+	}
+
+	// NOTIFY 在交易提交後才會送出，接收端收到通知時一定可以讀取到內容
+	tx, err := n.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+	var id string
+	query := "INSERT INTO " + n.table + " (channel, payload, created_at, updated_at) VALUES ($1, $2, now(), now()) RETURNING id"
+	if err := tx.QueryRowContext(ctx, query, n.channel, string(bytes)).Scan(&id); err != nil {
+		return fmt.Errorf("spill payload error: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "SELECT pg_notify($1, $2)", n.channel, string(spillPrefix)+id); err != nil {
+		return fmt.Errorf("notify error: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit error: %w", err)
+	}
+	return nil
+}
+
+// decode 解析通知的內容，寫入資料表的內容會依照ID讀取
+func (n *Notifier[T]) decode(ctx context.Context, extra string) (T, error) {
+	var result T
+	if extra == "" {
+		return result, errors.New("empty notification")
+	}
+	payload := extra[1:]
+	switch extra[0] {
+	case inlinePrefix:
+	case spillPrefix:
+		query := "SELECT payload FROM " + n.table + " WHERE id = $1"
+		if err := n.db.QueryRowContext(ctx, query, payload).Scan(&payload); err != nil {
+			return result, fmt.Errorf("load spilled payload %s error: %w", extra[1:], err)
+		}
+	default:
+		return result, fmt.Errorf("unknown notification type %q", extra[0])
+	}
+	if err := json.Unmarshal([]byte(payload), &result); err != nil {
+		return result, fmt.Errorf("json unmarshal error: %w", err)
+	}
+	return result, nil
+}
+
+// cleanupSpilled 刪除超過保留時間的內容
+func (n *Notifier[T]) cleanupSpilled(ctx context.Context) error {
+	query := "DELETE FROM " + n.table + " WHERE channel = $1 AND created_at < $2"
+	_, err := n.db.ExecContext(ctx, query, n.channel, time.Now().Add(-n.options.spillTTL))
+	return err
+}
+
+// Close 停止接收通知並關閉 LISTEN 的連線
+func (n *Notifier[T]) Close() {
+	if n.closed {
+		return
+	}
+	n.logger.Info("closing notifier")
+	n.closed = true
+	n.cancelFunc()
+	n.wg.Wait()
+	if err := n.listener.Close(); err != nil {
+		n.logger.Warn("close listener error", slog.Any("error", err))
+	}
+	n.logger.Info("notifier closed")
+}
+
+// quoteTable 將可能包含 schema 的資料表名稱的每個部分加上引號
+func quoteTable(table string) string {
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = pq.QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
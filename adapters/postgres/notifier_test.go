@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMessage struct {
+	ID   string `json:"id"`
+	Data string `json:"data"`
+}
+
+func TestNewNotifier(t *testing.T) {
+	db, err := sql.Open("postgres", "postgres://localhost/test")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = NewNotifier[testMessage](nil, "postgres://localhost/test", "test")
+	assert.Error(t, err)
+	_, err = NewNotifier[testMessage](db, "", "test")
+	assert.Error(t, err)
+	_, err = NewNotifier[testMessage](db, "postgres://localhost/test", "")
+	assert.Error(t, err)
+	for _, opt := range []NotifierOption{
+		WithNotifierMaxPayload(0),
+		WithNotifierMaxPayload(8000),
+		WithNotifierSpillTable(""),
+		WithNotifierSpillTTL(0),
+		WithNotifierPublishTimeout(0),
+		WithNotifierReconnectInterval(time.Minute, time.Second),
+	} {
+		_, err = NewNotifier[testMessage](db, "postgres://localhost/test", "test", opt)
+		assert.Error(t, err)
+	}
+
+	notifier, err := NewNotifier[testMessage](db, "postgres://localhost/test", "test", WithNotifierSpillTable("q4.notification_payloads"))
+	require.NoError(t, err)
+	assert.Equal(t, `"q4"."notification_payloads"`, notifier.table)
+}
+
+func TestNotifier_DecodeInline(t *testing.T) {
+	db, err := sql.Open("postgres", "postgres://localhost/test")
+	require.NoError(t, err)
+	defer db.Close()
+	notifier, err := NewNotifier[testMessage](db, "postgres://localhost/test", "test")
+	require.NoError(t, err)
+
+	data, err := notifier.decode(context.Background(), `d{"id":"1","data":"test"}`)
+	require.NoError(t, err)
+	assert.Equal(t, testMessage{ID: "1", Data: "test"}, data)
+
+	for _, extra := range []string{"", "x{}", "d{"} {
+		_, err = notifier.decode(context.Background(), extra)
+		assert.Error(t, err, extra)
+	}
+}
+
+// 需要連線到 Q4_TEST_DSN 指定的資料庫(需要先套用 migration)，沒有設定時略過
+func TestNotifier_PublishSubscribe(t *testing.T) {
+	dsn := os.Getenv("Q4_TEST_DSN")
+	if dsn == "" {
+		t.Skip("Q4_TEST_DSN is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	defer db.Close()
+
+	notifier, err := NewNotifier[testMessage](db, dsn, "q4_notifier_test", WithNotifierMaxPayload(100))
+	require.NoError(t, err)
+	notifier.Start()
+	defer notifier.Close()
+	receive := func() testMessage {
+		select {
+		case msg := <-notifier.Subscribe():
+			return msg
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for notification")
+			return testMessage{}
+		}
+	}
+	// 等待 LISTEN 完成
+	require.Eventually(t, func() bool {
+		require.NoError(t, notifier.Publish(testMessage{ID: "ping"}))
+		select {
+		case <-notifier.Subscribe():
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, notifier.Publish(testMessage{ID: "1", Data: "short"}))
+	assert.Equal(t, testMessage{ID: "1", Data: "short"}, receive())
+
+	// 超過長度限制的消息寫入資料表
+	large := testMessage{ID: "2", Data: strings.Repeat("x", 1000)}
+	require.NoError(t, notifier.Publish(large))
+	assert.Equal(t, large, receive())
+	var count int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM notification_payloads WHERE channel = $1", "q4_notifier_test").Scan(&count))
+	assert.Positive(t, count)
+	_, err = db.Exec("DELETE FROM notification_payloads WHERE channel = $1", "q4_notifier_test")
+	assert.NoError(t, err)
+}
@@ -59,7 +59,10 @@ func WithAutoRenewMutexSkipLockError(skip bool) AutoRenewMutexOption {
 }
 
 // NewAutoRenewMutex 創建一個帶自動續期功能的互斥鎖
-func NewAutoRenewMutex(client *redis.Client, key string, opts ...AutoRenewMutexOption) IAutoRenewMutex {
+// NOTE: Redis 發生主從切換時，續期會因為連線中斷或新的主節點沒有同步到鎖而失敗，
+// 此時 Lock 返回的 context 會立即被取消，持有者需要停止工作並重新取得鎖。
+// 由於主從之間是異步複製，鎖遺失後到下一次續期之前，其他實例可能已經取得同一把鎖。
+func NewAutoRenewMutex(client redis.UniversalClient, key string, opts ...AutoRenewMutexOption) IAutoRenewMutex {
 	// 默認選項
 	options := autoRenewMutexOptions{
 		expiry:        8 * time.Second,
@@ -104,6 +107,10 @@ func (m *AutoRenewMutex) Lock(ctx context.Context) (context.Context, error) {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		case <-timer.C:
+			// select 在 ctx 已經取消且計時器同時到期時會隨機選擇，所以取鎖前再檢查一次
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			err := m.Mutex.LockContext(ctx)
 			if err == nil {
 				lockCtx, cancel := context.WithCancel(ctx)
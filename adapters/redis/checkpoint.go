@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CheckpointStore 保存 Consumer 的讀取位置，重新啟動後可以從上次的位置繼續讀取
+type CheckpointStore interface {
+	// Load 讀取 key 保存的 stream ID，沒有保存過時返回空字串
+	Load(ctx context.Context, key string) (string, error)
+	// Save 保存 key 的 stream ID
+	Save(ctx context.Context, key, id string) error
+}
+
+// RedisCheckpointStore 將讀取位置保存在 Redis 的 hash 中，每個 key 對應一個欄位
+type RedisCheckpointStore struct {
+	client redis.UniversalClient
+	hash   string
+}
+
+// NewRedisCheckpointStore 建立以 hash 保存讀取位置的 RedisCheckpointStore
+func NewRedisCheckpointStore(client redis.UniversalClient, hash string) (*RedisCheckpointStore, error) {
+	if isNilClient(client) {
+		return nil, errors.New("redis client cannot be nil")
+	}
+	if hash == "" {
+		return nil, errors.New("hash cannot be empty")
+	}
+	return &RedisCheckpointStore{client: client, hash: hash}, nil
+}
+
+func (s *RedisCheckpointStore) Load(ctx context.Context, key string) (string, error) {
+	id, err := s.client.HGet(ctx, s.hash, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return id, err
+}
+
+func (s *RedisCheckpointStore) Save(ctx context.Context, key, id string) error {
+	return s.client.HSet(ctx, s.hash, key, id).Err()
+}
+
+// MemoryCheckpointStore 將讀取位置保存在記憶體中，只適用於單一程序內重新啟動 Consumer 的情況
+type MemoryCheckpointStore struct {
+	mu  sync.RWMutex
+	ids map[string]string
+}
+
+// NewMemoryCheckpointStore 建立 MemoryCheckpointStore
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{ids: map[string]string{}}
+}
+
+func (s *MemoryCheckpointStore) Load(_ context.Context, key string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ids[key], nil
+}
+
+func (s *MemoryCheckpointStore) Save(_ context.Context, key, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids[key] = id
+	return nil
+}
+
+// validStreamID 檢查 ID 是否可以作為 XREAD 的起始位置，可以是 "$"、"<ms>" 或 "<ms>-<seq>"
+func validStreamID(id string) bool {
+	if id == "$" {
+		return true
+	}
+	ms, seq, found := strings.Cut(id, "-")
+	if _, err := strconv.ParseUint(ms, 10, 64); err != nil {
+		return false
+	}
+	if found {
+		if _, err := strconv.ParseUint(seq, 10, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// streamIDBefore 返回時間 t 之前的最後一個 stream ID，XREAD 從這個位置讀取時會包含 t 當下寫入的消息
+func streamIDBefore(t time.Time) string {
+	ms := t.UnixMilli()
+	if ms <= 0 {
+		return "0-0"
+	}
+	return fmt.Sprintf("%d-%d", ms-1, uint64(math.MaxUint64))
+}
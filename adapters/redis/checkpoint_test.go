@@ -0,0 +1,187 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamIDBefore(t *testing.T) {
+	assert.Equal(t, "999-18446744073709551615", streamIDBefore(time.UnixMilli(1000)))
+	assert.Equal(t, "0-0", streamIDBefore(time.UnixMilli(0)))
+	assert.True(t, validStreamID(streamIDBefore(time.Now())))
+	for _, id := range []string{"$", "0", "1-0", "1700000000000-5"} {
+		assert.True(t, validStreamID(id), id)
+	}
+	for _, id := range []string{"", ">", "a-0", "1-", "-1", "1-0-0"} {
+		assert.False(t, validStreamID(id), id)
+	}
+}
+
+func TestNewConsumer_StartOptions(t *testing.T) {
+	client := redis.NewClient(&redis.Options{})
+	defer client.Close()
+	for _, opt := range []ConsumerOption[TestMessage]{
+		WithConsumerStartID[TestMessage]("invalid"),
+		WithConsumerCheckpoint[TestMessage](NewMemoryCheckpointStore(), "", time.Second),
+		WithConsumerCheckpoint[TestMessage](NewMemoryCheckpointStore(), "test", -time.Second),
+	} {
+		_, err := NewConsumer(client, "test-stream", opt)
+		assert.Error(t, err)
+	}
+}
+
+func TestRedisCheckpointStore(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	_, err := NewRedisCheckpointStore(client, "")
+	assert.Error(t, err)
+	store, err := NewRedisCheckpointStore(client, "checkpoint")
+	require.NoError(t, err)
+
+	id, err := store.Load(ctx, "test-stream")
+	require.NoError(t, err)
+	assert.Empty(t, id)
+	require.NoError(t, store.Save(ctx, "test-stream", "1-0"))
+	id, err = store.Load(ctx, "test-stream")
+	require.NoError(t, err)
+	assert.Equal(t, "1-0", id)
+	assert.Equal(t, "1-0", mr.HGet("checkpoint", "test-stream"))
+}
+
+func TestConsumer_Checkpoint(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	const stream = "test-stream"
+	add := func(id string) {
+		values, err := DefaultParseToMessage(TestMessage{ID: id})
+		require.NoError(t, err)
+		require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: stream, ID: id, Values: values}).Err())
+	}
+	receive := func(consumer IConsumer[TestMessage]) string {
+		select {
+		case msg := <-consumer.Subscribe():
+			return msg.ID
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for message")
+			return ""
+		}
+	}
+	store := NewMemoryCheckpointStore()
+	newConsumer := func(opts ...ConsumerOption[TestMessage]) IConsumer[TestMessage] {
+		opts = append(opts,
+			WithConsumerBlockTimeout[TestMessage](10*time.Millisecond),
+			WithConsumerCheckpoint[TestMessage](store, stream, time.Hour),
+		)
+		consumer, err := NewConsumer(client, stream, opts...)
+		require.NoError(t, err)
+		consumer.Start()
+		return consumer
+	}
+
+	add("1-0")
+	add("2-0")
+	// 沒有保存的位置時依照起始位置讀取
+	consumer := newConsumer(WithConsumerStartID[TestMessage]("1-0"))
+	assert.Equal(t, "2-0", receive(consumer))
+	add("3-0")
+	assert.Equal(t, "3-0", receive(consumer))
+	// 關閉時保存最後交付的位置
+	consumer.Close()
+	id, err := store.Load(ctx, stream)
+	require.NoError(t, err)
+	assert.Equal(t, "3-0", id)
+
+	// 重新啟動後從保存的位置繼續讀取，停機期間寫入的消息不會遺漏
+	add("4-0")
+	consumer = newConsumer(WithConsumerStartID[TestMessage]("$"))
+	defer consumer.Close()
+	assert.Equal(t, "4-0", receive(consumer))
+}
+
+func TestConsumer_StartTime(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	const stream = "test-stream"
+	for i := 1; i <= 3; i++ {
+		values, err := DefaultParseToMessage(TestMessage{ID: fmt.Sprint(i)})
+		require.NoError(t, err)
+		require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: stream, ID: fmt.Sprintf("%d000-0", i), Values: values}).Err())
+	}
+	consumer, err := NewConsumer(client, stream,
+		WithConsumerStartTime[TestMessage](time.UnixMilli(2000)),
+		WithConsumerBlockTimeout[TestMessage](10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	consumer.Start()
+	defer consumer.Close()
+	for _, expected := range []string{"2", "3"} {
+		select {
+		case msg := <-consumer.Subscribe():
+			assert.Equal(t, expected, msg.ID)
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for message")
+		}
+	}
+}
+
+func TestConsumer_Replay(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	const stream = "test-stream"
+	var ids []string
+	for i := 0; i < replayBatchSize*2+5; i++ {
+		values, err := DefaultParseToMessage(TestMessage{ID: fmt.Sprint(i)})
+		require.NoError(t, err)
+		if i == 3 {
+			values = map[string]any{"data": "invalid"}
+		}
+		id, err := client.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: values}).Result()
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	consumer, err := NewConsumer[TestMessage](client, stream)
+	require.NoError(t, err)
+
+	// 分批讀取整個範圍，略過無法解析的消息
+	var replayed []string
+	require.NoError(t, consumer.Replay(ctx, "("+ids[0], "+", func(id string, data TestMessage) error {
+		replayed = append(replayed, data.ID)
+		return nil
+	}))
+	assert.Len(t, replayed, len(ids)-2)
+	assert.Equal(t, "1", replayed[0])
+	assert.Equal(t, "4", replayed[2])
+	assert.Equal(t, fmt.Sprint(len(ids)-1), replayed[len(replayed)-1])
+
+	// fn 返回錯誤時停止
+	stop := fmt.Errorf("stop")
+	count := 0
+	err = consumer.Replay(ctx, "-", ids[10], func(id string, data TestMessage) error {
+		count++
+		if count == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,297 @@
+package redis
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// envelope 的欄位，舊版只有 data 欄位的消息會被視為以 msgpack 編碼的第1版
+const (
+	EnvelopeTypeField    = "type"
+	EnvelopeVersionField = "version"
+	EnvelopeCodecField   = "codec"
+	EnvelopeDataField    = "data"
+)
+
+// 內建的編碼方式名稱
+const (
+	CodecMsgpack  = "msgpack"
+	CodecJSON     = "json"
+	CodecProtobuf = "protobuf"
+)
+
+var (
+	ErrCodecNotFound      = errors.New("codec not found")
+	ErrUnsupportedVersion = errors.New("unsupported schema version")
+)
+
+// Codec 定義了消息內容的編碼方式
+type Codec interface {
+	Name() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// MsgpackCodec 以 msgpack 編碼，和 DefaultParseToMessage 相同
+type MsgpackCodec struct{}
+
+func (MsgpackCodec) Name() string                       { return CodecMsgpack }
+func (MsgpackCodec) Marshal(v any) ([]byte, error)      { return msgpack.Marshal(v) }
+func (MsgpackCodec) Unmarshal(data []byte, v any) error { return msgpack.Unmarshal(data, v) }
+
+// JSONCodec 以 JSON 編碼
+type JSONCodec struct{}
+
+func (JSONCodec) Name() string                       { return CodecJSON }
+func (JSONCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (JSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// ProtobufCodec 以 protobuf 編碼，資料本身或它的指標需要實作 proto.Message
+// NOTE: protobuf 無法解碼成 map，所以不支援 Schema 的 upcaster
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) Name() string { return CodecProtobuf }
+
+func (ProtobufCodec) Marshal(v any) ([]byte, error) {
+	if m, ok := v.(proto.Message); ok {
+		return proto.Marshal(m)
+	}
+	// 以複本的指標檢查是否實作 proto.Message
+	ptr := reflect.New(reflect.TypeOf(v))
+	ptr.Elem().Set(reflect.ValueOf(v))
+	if m, ok := ptr.Interface().(proto.Message); ok {
+		return proto.Marshal(m)
+	}
+	return nil, fmt.Errorf("%T does not implement proto.Message", v)
+}
+
+func (ProtobufCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T does not implement proto.Message", v)
+	}
+	return proto.Unmarshal(data, m)
+}
+
+// CodecRegistry 以名稱管理可以使用的 Codec
+type CodecRegistry struct {
+	mu     sync.RWMutex
+	codecs map[string]Codec
+}
+
+// NewCodecRegistry 建立 CodecRegistry，並註冊 codecs
+func NewCodecRegistry(codecs ...Codec) *CodecRegistry {
+	r := &CodecRegistry{codecs: map[string]Codec{}}
+	for _, codec := range codecs {
+		r.Register(codec)
+	}
+	return r
+}
+
+// Register 註冊 Codec，名稱相同時會取代原本的 Codec
+func (r *CodecRegistry) Register(codec Codec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codecs[codec.Name()] = codec
+}
+
+// Get 依照名稱取得 Codec
+func (r *CodecRegistry) Get(name string) (Codec, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	codec, ok := r.codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCodecNotFound, name)
+	}
+	return codec, nil
+}
+
+// DefaultCodecRegistry 預設的 CodecRegistry，包含 msgpack、JSON 和 protobuf
+var DefaultCodecRegistry = NewCodecRegistry(MsgpackCodec{}, JSONCodec{}, ProtobufCodec{})
+
+// Upcaster 將舊版本的消息內容轉換成下一個版本，payload 是以舊版本的 Codec 解碼後的結果
+type Upcaster func(payload map[string]any) (map[string]any, error)
+
+// Schema 描述一種消息的類型、版本和編碼方式，編碼後的消息會以 envelope 的欄位寫入 stream
+//   - 編碼時一律使用目前的版本和 Codec
+//   - 解碼時依照消息記錄的 Codec 解碼，舊版本的消息會依序經過每個版本的 Upcaster 轉換成目前的版本
+//
+// 修改結構的欄位時遞增版本並註冊上一個版本的 Upcaster，就不會影響還在 stream 中的舊消息
+type Schema[T any] struct {
+	typ       string
+	version   int
+	codec     Codec
+	registry  *CodecRegistry
+	upcasters map[int]Upcaster
+}
+
+type schemaOptions struct {
+	codec     string
+	registry  *CodecRegistry
+	upcasters map[int]Upcaster
+}
+
+type SchemaOption func(*schemaOptions)
+
+// WithSchemaCodec 設置編碼時使用的 Codec 名稱，預設為 msgpack
+func WithSchemaCodec(name string) SchemaOption {
+	return func(o *schemaOptions) {
+		o.codec = name
+	}
+}
+
+// WithSchemaRegistry 設置取得 Codec 的 CodecRegistry，預設為 DefaultCodecRegistry
+func WithSchemaRegistry(registry *CodecRegistry) SchemaOption {
+	return func(o *schemaOptions) {
+		o.registry = registry
+	}
+}
+
+// WithSchemaUpcaster 設置將第 from 版的消息轉換成第 from+1 版的 Upcaster
+func WithSchemaUpcaster(from int, fn Upcaster) SchemaOption {
+	return func(o *schemaOptions) {
+		o.upcasters[from] = fn
+	}
+}
+
+// NewSchema 建立消息類型 typ 第 version 版的 Schema，版本從1開始
+func NewSchema[T any](typ string, version int, opts ...SchemaOption) (*Schema[T], error) {
+	var zero T
+	if reflect.TypeOf(zero) == nil || reflect.TypeOf(zero).Kind() == reflect.Ptr {
+		return nil, ErrPointerType
+	}
+	if typ == "" {
+		return nil, errors.New("schema type cannot be empty")
+	}
+	if version <= 0 {
+		return nil, errors.New("schema version must be positive")
+	}
+
+	// 默認選項
+	options := schemaOptions{
+		codec:     CodecMsgpack,
+		registry:  DefaultCodecRegistry,
+		upcasters: map[int]Upcaster{},
+	}
+
+	// 應用自定義選項
+	for _, opt := range opts {
+		opt(&options)
+	}
+	codec, err := options.registry.Get(options.codec)
+	if err != nil {
+		return nil, err
+	}
+	for from := range options.upcasters {
+		if from <= 0 || from >= version {
+			return nil, fmt.Errorf("invalid upcaster version %d", from)
+		}
+	}
+
+	return &Schema[T]{
+		typ:       typ,
+		version:   version,
+		codec:     codec,
+		registry:  options.registry,
+		upcasters: options.upcasters,
+	}, nil
+}
+
+// Encode 將資料編碼成 envelope 的欄位，可以直接作為 Producer 的 ParseFunc
+func (s *Schema[T]) Encode(data T) (map[string]any, error) {
+	bytes, err := s.codec.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s marshal error: %w", s.codec.Name(), err)
+	}
+	return map[string]any{
+		EnvelopeTypeField:    s.typ,
+		EnvelopeVersionField: strconv.Itoa(s.version),
+		EnvelopeCodecField:   s.codec.Name(),
+		EnvelopeDataField:    base64.StdEncoding.EncodeToString(bytes),
+	}, nil
+}
+
+// Decode 將 envelope 的欄位解碼成資料，可以直接作為 Consumer 和 GroupConsumer 的 ParseFunc
+func (s *Schema[T]) Decode(message map[string]any) (T, error) {
+	var result T
+	if len(message) == 0 {
+		return result, nil
+	}
+
+	// 舊版的消息只有 data 欄位
+	typ, version, codecName := s.typ, 1, CodecMsgpack
+	if value, ok := message[EnvelopeTypeField]; ok {
+		typ = fmt.Sprint(value)
+	}
+	if value, ok := message[EnvelopeVersionField]; ok {
+		v, err := strconv.Atoi(fmt.Sprint(value))
+		if err != nil {
+			return result, fmt.Errorf("invalid version field: %w", err)
+		}
+		version = v
+	}
+	if value, ok := message[EnvelopeCodecField]; ok {
+		codecName = fmt.Sprint(value)
+	}
+	if typ != s.typ {
+		return result, fmt.Errorf("unexpected message type %s, expected %s", typ, s.typ)
+	}
+	if version <= 0 || version > s.version {
+		return result, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	dataStr, ok := message[EnvelopeDataField].(string)
+	if !ok {
+		return result, fmt.Errorf("data field not found or invalid type")
+	}
+	bytes, err := base64.StdEncoding.DecodeString(dataStr)
+	if err != nil {
+		return result, fmt.Errorf("base64 decode error: %w", err)
+	}
+	codec, err := s.registry.Get(codecName)
+	if err != nil {
+		return result, err
+	}
+
+	if version < s.version {
+		bytes, err = s.upcast(codec, version, bytes)
+		if err != nil {
+			return result, err
+		}
+	}
+	if err := codec.Unmarshal(bytes, &result); err != nil {
+		return result, fmt.Errorf("%s unmarshal error: %w", codec.Name(), err)
+	}
+	return result, nil
+}
+
+// upcast 依序將第 version 版的消息內容轉換成目前的版本，返回以相同 Codec 重新編碼的結果
+func (s *Schema[T]) upcast(codec Codec, version int, bytes []byte) ([]byte, error) {
+	var payload map[string]any
+	if err := codec.Unmarshal(bytes, &payload); err != nil {
+		return nil, fmt.Errorf("%s unmarshal error: %w", codec.Name(), err)
+	}
+	for v := version; v < s.version; v++ {
+		upcaster, ok := s.upcasters[v]
+		if !ok {
+			return nil, fmt.Errorf("%w: no upcaster from version %d", ErrUnsupportedVersion, v)
+		}
+		var err error
+		if payload, err = upcaster(payload); err != nil {
+			return nil, fmt.Errorf("upcast from version %d error: %w", v, err)
+		}
+	}
+	bytes, err := codec.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s marshal error: %w", codec.Name(), err)
+	}
+	return bytes, nil
+}
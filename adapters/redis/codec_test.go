@@ -0,0 +1,156 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type testMessageV1 struct {
+	ID   string
+	Name string
+}
+
+type testMessageV3 struct {
+	ID        string
+	FirstName string
+	LastName  string
+}
+
+func TestSchema_EncodeDecode(t *testing.T) {
+	for _, codec := range []string{CodecMsgpack, CodecJSON} {
+		t.Run(codec, func(t *testing.T) {
+			schema, err := NewSchema[TestMessage]("test", 2, WithSchemaCodec(codec))
+			require.NoError(t, err)
+
+			values, err := schema.Encode(TestMessage{ID: "1", Data: "test data"})
+			require.NoError(t, err)
+			assert.Equal(t, "test", values[EnvelopeTypeField])
+			assert.Equal(t, "2", values[EnvelopeVersionField])
+			assert.Equal(t, codec, values[EnvelopeCodecField])
+
+			data, err := schema.Decode(values)
+			require.NoError(t, err)
+			assert.Equal(t, TestMessage{ID: "1", Data: "test data"}, data)
+		})
+	}
+}
+
+func TestSchema_DecodeLegacyMessage(t *testing.T) {
+	schema, err := NewSchema[TestMessage]("test", 1)
+	require.NoError(t, err)
+
+	// 沒有 envelope 欄位的消息視為以 msgpack 編碼的第1版
+	values, err := DefaultParseToMessage(TestMessage{ID: "1", Data: "legacy"})
+	require.NoError(t, err)
+	data, err := schema.Decode(values)
+	require.NoError(t, err)
+	assert.Equal(t, TestMessage{ID: "1", Data: "legacy"}, data)
+}
+
+func TestSchema_Upcast(t *testing.T) {
+	v1, err := NewSchema[testMessageV1]("test", 1, WithSchemaCodec(CodecJSON))
+	require.NoError(t, err)
+	old, err := v1.Encode(testMessageV1{ID: "1", Name: "Ada Lovelace"})
+	require.NoError(t, err)
+	legacy, err := DefaultParseToMessage(testMessageV1{ID: "2", Name: "Alan Turing"})
+	require.NoError(t, err)
+
+	v3, err := NewSchema[testMessageV3]("test", 3,
+		// 第1版到第2版: Name 改名為 FullName
+		WithSchemaUpcaster(1, func(payload map[string]any) (map[string]any, error) {
+			payload["FullName"] = payload["Name"]
+			delete(payload, "Name")
+			return payload, nil
+		}),
+		// 第2版到第3版: FullName 拆成 FirstName 和 LastName
+		WithSchemaUpcaster(2, func(payload map[string]any) (map[string]any, error) {
+			var first, last string
+			if name, ok := payload["FullName"].(string); ok {
+				for i := range name {
+					if name[i] == ' ' {
+						first, last = name[:i], name[i+1:]
+						break
+					}
+				}
+			}
+			payload["FirstName"], payload["LastName"] = first, last
+			delete(payload, "FullName")
+			return payload, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	data, err := v3.Decode(old)
+	require.NoError(t, err)
+	assert.Equal(t, testMessageV3{ID: "1", FirstName: "Ada", LastName: "Lovelace"}, data)
+
+	data, err = v3.Decode(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, testMessageV3{ID: "2", FirstName: "Alan", LastName: "Turing"}, data)
+
+	// 沒有註冊 upcaster 的版本無法解碼
+	v2, err := NewSchema[testMessageV3]("test", 2)
+	require.NoError(t, err)
+	_, err = v2.Decode(old)
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestSchema_DecodeErrors(t *testing.T) {
+	schema, err := NewSchema[TestMessage]("test", 1)
+	require.NoError(t, err)
+	values, err := schema.Encode(TestMessage{ID: "1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(map[string]any)
+	}{
+		{name: "future version", modify: func(m map[string]any) { m[EnvelopeVersionField] = "2" }},
+		{name: "invalid version", modify: func(m map[string]any) { m[EnvelopeVersionField] = "v1" }},
+		{name: "other type", modify: func(m map[string]any) { m[EnvelopeTypeField] = "other" }},
+		{name: "unknown codec", modify: func(m map[string]any) { m[EnvelopeCodecField] = "avro" }},
+		{name: "missing data", modify: func(m map[string]any) { delete(m, EnvelopeDataField) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := make(map[string]any, len(values))
+			for k, v := range values {
+				message[k] = v
+			}
+			tt.modify(message)
+			_, err := schema.Decode(message)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewSchema(t *testing.T) {
+	_, err := NewSchema[*TestMessage]("test", 1)
+	assert.ErrorIs(t, err, ErrPointerType)
+	_, err = NewSchema[TestMessage]("", 1)
+	assert.Error(t, err)
+	_, err = NewSchema[TestMessage]("test", 0)
+	assert.Error(t, err)
+	_, err = NewSchema[TestMessage]("test", 1, WithSchemaCodec("avro"))
+	assert.ErrorIs(t, err, ErrCodecNotFound)
+	_, err = NewSchema[TestMessage]("test", 2, WithSchemaUpcaster(2, nil))
+	assert.Error(t, err)
+}
+
+func TestProtobufCodec(t *testing.T) {
+	codec := ProtobufCodec{}
+	bytes, err := codec.Marshal(wrapperspb.String("test data"))
+	require.NoError(t, err)
+
+	var result wrapperspb.StringValue
+	require.NoError(t, codec.Unmarshal(bytes, &result))
+	assert.True(t, proto.Equal(wrapperspb.String("test data"), &result))
+
+	_, err = codec.Marshal(TestMessage{})
+	assert.Error(t, err)
+	assert.Error(t, codec.Unmarshal(bytes, &TestMessage{}))
+}
@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -11,11 +12,20 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// replayBatchSize Replay 每次從 stream 讀取的消息數量
+const replayBatchSize = 100
+
 type consumerOptions[T any] struct {
 	logger       *slog.Logger
 	bufferSize   int
 	blockTimeout time.Duration
-	parseFunc    func(map[string]any) (T, error)
+	retryDelay   time.Duration
+	parseFunc    func(id string, values map[string]any) (T, error)
+
+	startID            string
+	checkpoint         CheckpointStore
+	checkpointKey      string
+	checkpointInterval time.Duration
 }
 
 type ConsumerOption[T any] func(*consumerOptions[T])
@@ -41,18 +51,71 @@ func WithConsumerBlockTimeout[T any](d time.Duration) ConsumerOption[T] {
 	}
 }
 
+// WithConsumerRetryDelay 設置讀取失敗後重試前的等待時間，預設為0表示立即重試
+// Redis 發生主從切換時，連線會在新的主節點可用之前持續失敗，設置等待時間可以避免大量重試
+func WithConsumerRetryDelay[T any](d time.Duration) ConsumerOption[T] {
+	return func(o *consumerOptions[T]) {
+		o.retryDelay = d
+	}
+}
+
 // WithConsumerParseFunc 設置自定義解析函數
 func WithConsumerParseFunc[T any](fn func(map[string]any) (T, error)) ConsumerOption[T] {
+	return func(o *consumerOptions[T]) {
+		o.parseFunc = func(_ string, values map[string]any) (T, error) {
+			return fn(values)
+		}
+	}
+}
+
+// WithConsumerParseMessageFunc 設置自定義解析函數，解析時可以取得消息的ID
+func WithConsumerParseMessageFunc[T any](fn func(id string, values map[string]any) (T, error)) ConsumerOption[T] {
 	return func(o *consumerOptions[T]) {
 		o.parseFunc = fn
 	}
 }
 
+// WithConsumerSchema 以 Schema 解碼消息，舊版本的消息會經過 upcaster 轉換成目前的版本
+func WithConsumerSchema[T any](schema *Schema[T]) ConsumerOption[T] {
+	return WithConsumerParseFunc(schema.Decode)
+}
+
+// WithConsumerStartID 設置開始讀取的位置，只會讀取ID大於 id 的消息，預設為 "$" 表示只讀取啟動後寫入的消息
+// 使用 "0" 可以從 stream 的開頭讀取
+func WithConsumerStartID[T any](id string) ConsumerOption[T] {
+	return func(o *consumerOptions[T]) {
+		o.startID = id
+	}
+}
+
+// WithConsumerStartTime 設置開始讀取的時間，會讀取 t 當下和之後寫入的消息
+func WithConsumerStartTime[T any](t time.Time) ConsumerOption[T] {
+	return func(o *consumerOptions[T]) {
+		o.startID = streamIDBefore(t)
+	}
+}
+
+// WithConsumerCheckpoint 設置保存讀取位置的 CheckpointStore
+//   - 啟動時讀取 key 保存的位置，有保存過時會取代 WithConsumerStartID 的設定
+//   - 讀取消息後每隔 interval 保存一次最後交付到下游的消息ID，關閉時也會保存，interval 為0時每一則消息都會保存
+//
+// NOTE: 保存的是交付到下游的位置，下游還沒處理完就重新啟動時，這些消息不會再次讀取
+func WithConsumerCheckpoint[T any](store CheckpointStore, key string, interval time.Duration) ConsumerOption[T] {
+	return func(o *consumerOptions[T]) {
+		o.checkpoint = store
+		o.checkpointKey = key
+		o.checkpointInterval = interval
+	}
+}
+
 type Consumer[T any] struct {
-	client     *redis.Client
+	client     redis.UniversalClient
 	stream     string
 	lastID     string
 	downStream chan T
+	// 最後保存的讀取位置和保存的時間
+	savedID    string
+	savedAt    time.Time
 	cancelFunc context.CancelFunc
 	wg         sync.WaitGroup
 	closed     bool
@@ -60,8 +123,8 @@ type Consumer[T any] struct {
 	options    consumerOptions[T]
 }
 
-func NewConsumer[T any](client *redis.Client, stream string, opts ...ConsumerOption[T]) (IConsumer[T], error) {
-	if client == nil {
+func NewConsumer[T any](client redis.UniversalClient, stream string, opts ...ConsumerOption[T]) (IConsumer[T], error) {
+	if isNilClient(client) {
 		return nil, errors.New("redis client cannot be nil")
 	}
 	if stream == "" {
@@ -73,18 +136,27 @@ func NewConsumer[T any](client *redis.Client, stream string, opts ...ConsumerOpt
 		logger:       slog.Default(),
 		bufferSize:   100,
 		blockTimeout: time.Second,
-		parseFunc:    DefaultParseFromMessage[T],
+		parseFunc: func(_ string, values map[string]any) (T, error) {
+			return DefaultParseFromMessage[T](values)
+		},
+		startID: "$",
 	}
 
 	// 應用自定義選項
 	for _, opt := range opts {
 		opt(&options)
 	}
+	if !validStreamID(options.startID) {
+		return nil, fmt.Errorf("invalid start id %q", options.startID)
+	}
+	if options.checkpoint != nil && (options.checkpointKey == "" || options.checkpointInterval < 0) {
+		return nil, errors.New("invalid checkpoint options")
+	}
 
 	consumer := &Consumer[T]{
 		client:  client,
 		stream:  stream,
-		lastID:  "$",
+		lastID:  options.startID,
 		closed:  true,
 		logger:  options.logger.With(slog.String("caller", "Consumer"), slog.String("stream", stream)),
 		options: options,
@@ -110,6 +182,13 @@ func (s *Consumer[T]) Start() {
 		defer s.logger.Info("consumer goroutine stopped")
 		defer close(s.downStream)
 
+		if s.options.checkpoint != nil {
+			if err := s.restoreCheckpoint(ctx); err != nil {
+				return
+			}
+			defer s.saveCheckpoint(true)
+		}
+
 		for {
 			select {
 			case <-ctx.Done():
@@ -118,18 +197,31 @@ func (s *Consumer[T]) Start() {
 				message, err := s.fetchNextMessage(ctx)
 				if err != nil {
 					if errors.Is(err, redis.Nil) {
+						s.saveCheckpoint(false)
 						continue
 					}
+					// 讀取失敗時保留最後讀取的ID，連線恢復後從相同的位置繼續讀取
 					s.logger.Error("fetch message error", slog.Any("error", err))
+					if err := waitRetry(ctx, s.options.retryDelay); err != nil {
+						return
+					}
+					continue
+				}
+
+				// 重送的 dead-letter 訊息不是新的消息，不交付給下游
+				if _, replayed := ReplayTarget(message.Values); replayed {
+					s.lastID = message.ID
+					s.saveCheckpoint(false)
 					continue
 				}
 
 				// 解析消息
-				data, err := s.options.parseFunc(message.Values)
+				data, err := s.options.parseFunc(message.ID, message.Values)
 				if err != nil {
 					s.logger.Error("failed to parse message",
 						slog.String("messageId", message.ID),
 						slog.Any("error", err))
+					s.lastID = message.ID
 					continue
 				}
 
@@ -138,9 +230,11 @@ func (s *Consumer[T]) Start() {
 				case <-ctx.Done():
 					return
 				case s.downStream <- data:
+					s.lastID = message.ID
 					s.logger.Debug("message sent to downstream",
 						slog.String("messageId", message.ID))
 				}
+				s.saveCheckpoint(false)
 			}
 		}
 	}()
@@ -159,7 +253,6 @@ func (s *Consumer[T]) fetchNextMessage(ctx context.Context) (redis.XMessage, err
 
 	if len(streams) > 0 && len(streams[0].Messages) > 0 {
 		message := streams[0].Messages[0]
-		s.lastID = message.ID
 		s.logger.Debug("received message", slog.String("messageId", message.ID))
 		return message, nil
 	}
@@ -167,6 +260,78 @@ func (s *Consumer[T]) fetchNextMessage(ctx context.Context) (redis.XMessage, err
 	return redis.XMessage{}, redis.Nil
 }
 
+// restoreCheckpoint 讀取保存的讀取位置，讀取失敗時依照 retryDelay 重試，直到成功或 context 取消
+func (s *Consumer[T]) restoreCheckpoint(ctx context.Context) error {
+	for {
+		id, err := s.options.checkpoint.Load(ctx, s.options.checkpointKey)
+		if err == nil {
+			if id != "" {
+				s.lastID = id
+				s.logger.Info("restore checkpoint", slog.String("messageId", id))
+			}
+			s.savedID, s.savedAt = s.lastID, time.Now()
+			return nil
+		}
+		s.logger.Error("load checkpoint error", slog.Any("error", err))
+		if err := waitRetry(ctx, s.options.retryDelay); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// saveCheckpoint 讀取位置有變化且距離上次保存超過 checkpointInterval 時保存，force 為 true 時不檢查間隔
+// 保存失敗只會記錄在日誌中，下一次保存時會再嘗試
+func (s *Consumer[T]) saveCheckpoint(force bool) {
+	if s.options.checkpoint == nil || s.lastID == s.savedID {
+		return
+	}
+	if !force && time.Since(s.savedAt) < s.options.checkpointInterval {
+		return
+	}
+	// 關閉時 context 已經被取消，使用獨立的 context 保存
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.options.checkpoint.Save(ctx, s.options.checkpointKey, s.lastID); err != nil {
+		s.logger.Error("save checkpoint error", slog.Any("error", err))
+		return
+	}
+	s.savedID, s.savedAt = s.lastID, time.Now()
+}
+
+// Replay 依照順序將 stream 中 start 到 end 之間(包含兩端)的消息交給 fn，不影響 Subscribe 的讀取位置
+//   - start 和 end 可以使用 "-" 和 "+" 表示開頭和結尾，以 "(" 開頭表示不包含該ID
+//   - 無法解析的消息和重送的 dead-letter 訊息會被略過，fn 返回錯誤時停止並返回該錯誤
+func (s *Consumer[T]) Replay(ctx context.Context, start, end string, fn func(id string, data T) error) error {
+	for {
+		messages, err := s.client.XRangeN(ctx, s.stream, start, end, replayBatchSize).Result()
+		if err != nil {
+			return fmt.Errorf("fail to read stream range, err=%w", err)
+		}
+		for _, message := range messages {
+			if _, replayed := ReplayTarget(message.Values); replayed {
+				continue
+			}
+			data, err := s.options.parseFunc(message.ID, message.Values)
+			if err != nil {
+				s.logger.Error("failed to parse message",
+					slog.String("messageId", message.ID),
+					slog.Any("error", err))
+				continue
+			}
+			if err := fn(message.ID, data); err != nil {
+				return err
+			}
+		}
+		if len(messages) < replayBatchSize {
+			return nil
+		}
+		start = "(" + messages[len(messages)-1].ID
+	}
+}
+
 // Subscribe 訂閱數據流
 func (s *Consumer[T]) Subscribe() <-chan T {
 	return s.downStream
@@ -310,3 +310,45 @@ func TestConsumer_MessageConsumption(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestConsumer_ParseMessageFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	client, mock, cleanup := setupTest(t)
+	defer cleanup()
+
+	mock.ExpectXRead(&redis.XReadArgs{
+		Streams: []string{"test-stream", "$"},
+		Count:   1,
+		Block:   time.Second,
+	}).SetVal([]redis.XStream{
+		{
+			Stream: "test-stream",
+			Messages: []redis.XMessage{
+				{
+					ID:     "1234-0",
+					Values: map[string]any{"data": "test data"},
+				},
+			},
+		},
+	})
+
+	consumer, err := NewConsumer(
+		client,
+		"test-stream",
+		WithConsumerBlockTimeout[TestMessage](time.Second),
+		WithConsumerParseMessageFunc(func(id string, values map[string]any) (TestMessage, error) {
+			return TestMessage{ID: id, Data: values["data"].(string)}, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	consumer.Start()
+	defer consumer.Close()
+
+	select {
+	case msg := <-consumer.Subscribe():
+		assert.Equal(t, TestMessage{ID: "1234-0", Data: "test data"}, msg)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+}
@@ -0,0 +1,297 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DeadLetterErrorField 是 dead-letter 訊息中記錄處理失敗原因的欄位，由 Message.Fail 寫入
+const DeadLetterErrorField = "error"
+
+// DeadLetterGroupField 是 dead-letter 訊息中記錄處理失敗的 consumer group 的欄位，重送時只會交付給這個 consumer group
+const DeadLetterGroupField = "group"
+
+// ReplayGroupField 是重送的訊息中記錄要交付的 consumer group 的欄位
+//   - GroupConsumer 會確認並略過交付給其他 consumer group 的訊息
+//   - Consumer 不會交付重送的訊息，重送的訊息不是新的消息，不應該再推播給訂閱者
+//
+// 欄位的值為空字串時表示交付給所有的 consumer group，用於沒有記錄 consumer group 的舊 dead-letter 訊息
+const ReplayGroupField = "replay-group"
+
+// ReplayTarget 取得重送的訊息要交付的 consumer group，不是重送的訊息時 ok 為 false
+func ReplayTarget(values map[string]any) (group string, ok bool) {
+	value, ok := values[ReplayGroupField]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
+// replayedForOtherGroup 是否為重送給其他 consumer group 的訊息
+func replayedForOtherGroup(values map[string]any, group string) bool {
+	target, ok := ReplayTarget(values)
+	return ok && target != "" && target != group
+}
+
+// ErrInvalidStreamID 表示提供的 stream 訊息ID格式不正確
+var ErrInvalidStreamID = errors.New("invalid stream id")
+
+var streamIDPattern = regexp.MustCompile(`^\d+-\d+$`)
+
+// DeadLetterStream 取得 stream 對應的 dead-letter stream 鍵
+// NOTE: 只在原本的鍵後面加上後綴，原本鍵中的 hash tag 會被保留，兩者在 Redis Cluster 中會落在同一個 slot
+func DeadLetterStream(stream string) string {
+	return stream + ":dead-letter"
+}
+
+// replayDeadLetterScript 將 dead-letter 訊息移回原本的 stream
+//
+//	KEYS[1] - dead-letter stream
+//	KEYS[2] - 原本的 stream
+//	ARGV    - 要重送的訊息ID
+//
+// 返回值: 成功重送的訊息ID
+//
+// 流程:
+//   - 1. 讀取 dead-letter 訊息，不存在時略過
+//   - 2. 移除失敗原因、投遞次數和 consumer group 的欄位，重送的訊息會重新計算投遞次數
+//   - 3. 以 ReplayGroupField 記錄處理失敗的 consumer group，以新的ID寫回原本的 stream，只有該 consumer group 會處理
+//   - 4. 從 dead-letter stream 刪除訊息
+var replayDeadLetterScript = redis.NewScript(`
+local replayed = {}
+for _, id in ipairs(ARGV) do
+    local entries = redis.call('XRANGE', KEYS[1], id, id)
+    if #entries > 0 then
+        local fields = entries[1][2]
+        local values = {}
+        local group = ''
+        for i = 1, #fields, 2 do
+            if fields[i] == 'group' then
+                group = fields[i + 1]
+            elseif fields[i] ~= 'error' and fields[i] ~= 'attempt' and fields[i] ~= 'replay-group' then
+                table.insert(values, fields[i])
+                table.insert(values, fields[i + 1])
+            end
+        end
+        if #values > 0 then
+            table.insert(values, 'replay-group')
+            table.insert(values, group)
+            redis.call('XADD', KEYS[2], '*', unpack(values))
+            redis.call('XDEL', KEYS[1], id)
+            table.insert(replayed, id)
+        end
+    end
+end
+return replayed
+`)
+
+// discardDeadLetterScript 刪除 dead-letter 訊息
+//
+//	KEYS[1] - dead-letter stream
+//	ARGV    - 要刪除的訊息ID
+//
+// 返回值: 成功刪除的訊息ID，已經不存在的訊息不會包含在內
+var discardDeadLetterScript = redis.NewScript(`
+local discarded = {}
+for _, id in ipairs(ARGV) do
+    if redis.call('XDEL', KEYS[1], id) == 1 then
+        table.insert(discarded, id)
+    end
+end
+return discarded
+`)
+
+// DeadLetterEntry 代表 dead-letter stream 中的一筆訊息
+type DeadLetterEntry[T any] struct {
+	// dead-letter stream 中的訊息ID
+	ID string
+	// 解析後的資料，ParseError 不為空時為零值
+	Data T
+	// 解析資料失敗的原因
+	ParseError error
+	// 處理失敗的原因，解析失敗而被移入的訊息沒有這個欄位
+	Error string
+	// 處理失敗的 consumer group，舊版本移入的訊息沒有這個欄位
+	Group string
+	// 原始的訊息內容
+	Values map[string]any
+}
+
+type deadLetterQueueOptions[T any] struct {
+	logger    *slog.Logger
+	parseFunc func(map[string]any) (T, error)
+}
+
+type DeadLetterQueueOption[T any] func(*deadLetterQueueOptions[T])
+
+// WithDeadLetterQueueLogger 設置日誌記錄器
+func WithDeadLetterQueueLogger[T any](logger *slog.Logger) DeadLetterQueueOption[T] {
+	return func(o *deadLetterQueueOptions[T]) {
+		o.logger = logger
+	}
+}
+
+// WithDeadLetterQueueParseFunc 設置消息解析函數
+func WithDeadLetterQueueParseFunc[T any](fn func(map[string]any) (T, error)) DeadLetterQueueOption[T] {
+	return func(o *deadLetterQueueOptions[T]) {
+		o.parseFunc = fn
+	}
+}
+
+// WithDeadLetterQueueSchema 以 Schema 解碼消息
+func WithDeadLetterQueueSchema[T any](schema *Schema[T]) DeadLetterQueueOption[T] {
+	return func(o *deadLetterQueueOptions[T]) {
+		o.parseFunc = schema.Decode
+	}
+}
+
+type DeadLetterQueue[T any] struct {
+	client     redis.UniversalClient
+	stream     string
+	deadLetter string
+	logger     *slog.Logger
+	options    deadLetterQueueOptions[T]
+}
+
+// NewDeadLetterQueue 建立 stream 的 dead-letter 管理工具，用於查看、重送和刪除處理失敗的訊息
+func NewDeadLetterQueue[T any](client redis.UniversalClient, stream string, opts ...DeadLetterQueueOption[T]) (IDeadLetterQueue[T], error) {
+	if isNilClient(client) {
+		return nil, errors.New("redis client cannot be nil")
+	}
+	if stream == "" {
+		return nil, errors.New("stream cannot be empty")
+	}
+
+	// 默認選項
+	options := deadLetterQueueOptions[T]{
+		logger:    slog.Default(),
+		parseFunc: DefaultParseFromMessage[T],
+	}
+
+	// 應用自定義選項
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &DeadLetterQueue[T]{
+		client:     client,
+		stream:     stream,
+		deadLetter: DeadLetterStream(stream),
+		logger:     options.logger.With(slog.String("caller", "DeadLetterQueue"), slog.String("stream", stream)),
+		options:    options,
+	}, nil
+}
+
+// List 由新到舊列出 dead-letter 訊息，lastID 為上一頁最後一筆訊息的ID，空字串表示從最新的訊息開始
+func (q *DeadLetterQueue[T]) List(ctx context.Context, lastID string, count int64) ([]DeadLetterEntry[T], error) {
+	const op = "DeadLetterQueue.List"
+	start := "+"
+	if lastID != "" {
+		if !streamIDPattern.MatchString(lastID) {
+			return nil, fmt.Errorf("[%s] %w: %s", op, ErrInvalidStreamID, lastID)
+		}
+		start = "(" + lastID
+	}
+	messages, err := q.client.XRevRangeN(ctx, q.deadLetter, start, "-", count).Result()
+	if err != nil {
+		return nil, fmt.Errorf("[%s] failed to read dead letter stream: %w", op, err)
+	}
+	return q.toEntries(messages), nil
+}
+
+// Get 取得指定的 dead-letter 訊息，不存在的訊息不會包含在結果中
+func (q *DeadLetterQueue[T]) Get(ctx context.Context, ids ...string) ([]DeadLetterEntry[T], error) {
+	const op = "DeadLetterQueue.Get"
+	if err := validateStreamIDs(ids); err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	cmds, err := q.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range ids {
+			pipe.XRange(ctx, q.deadLetter, id, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] failed to read dead letter stream: %w", op, err)
+	}
+	var messages []redis.XMessage
+	for _, cmd := range cmds {
+		messages = append(messages, cmd.(*redis.XMessageSliceCmd).Val()...)
+	}
+	return q.toEntries(messages), nil
+}
+
+// Replay 將 dead-letter 訊息以新的ID寫回原本的 stream 並從 dead-letter 刪除，返回成功重送的訊息ID
+// 重送的訊息只會交付給處理失敗的 consumer group，參考 ReplayGroupField
+// NOTE: 每筆訊息的寫回和刪除在同一個 Lua script 中完成，不會發生重複重送或遺失的情況
+func (q *DeadLetterQueue[T]) Replay(ctx context.Context, ids ...string) ([]string, error) {
+	const op = "DeadLetterQueue.Replay"
+	if err := validateStreamIDs(ids); err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	replayed, err := replayDeadLetterScript.Run(ctx, q.client, []string{q.deadLetter, q.stream}, toAnySlice(ids)...).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("[%s] failed to run replay script: %w", op, err)
+	}
+	q.logger.Info("dead letters replayed", slog.Any("ids", replayed))
+	return replayed, nil
+}
+
+// Discard 刪除 dead-letter 訊息，返回成功刪除的訊息ID
+func (q *DeadLetterQueue[T]) Discard(ctx context.Context, ids ...string) ([]string, error) {
+	const op = "DeadLetterQueue.Discard"
+	if err := validateStreamIDs(ids); err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	discarded, err := discardDeadLetterScript.Run(ctx, q.client, []string{q.deadLetter}, toAnySlice(ids)...).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("[%s] failed to run discard script: %w", op, err)
+	}
+	q.logger.Info("dead letters discarded", slog.Any("ids", discarded))
+	return discarded, nil
+}
+
+// toEntries 將 stream 訊息轉換成 DeadLetterEntry，解析失敗時記錄在 ParseError
+func (q *DeadLetterQueue[T]) toEntries(messages []redis.XMessage) []DeadLetterEntry[T] {
+	entries := make([]DeadLetterEntry[T], len(messages))
+	for i, message := range messages {
+		entries[i] = DeadLetterEntry[T]{
+			ID:     message.ID,
+			Values: message.Values,
+		}
+		if failErr, ok := message.Values[DeadLetterErrorField].(string); ok {
+			entries[i].Error = failErr
+		}
+		if group, ok := message.Values[DeadLetterGroupField].(string); ok {
+			entries[i].Group = group
+		}
+		entries[i].Data, entries[i].ParseError = q.options.parseFunc(message.Values)
+	}
+	return entries
+}
+
+// validateStreamIDs 檢查訊息ID的格式，避免不合法的ID造成 Lua script 執行失敗
+func validateStreamIDs(ids []string) error {
+	if len(ids) == 0 {
+		return errors.New("ids cannot be empty")
+	}
+	for _, id := range ids {
+		if !streamIDPattern.MatchString(id) {
+			return fmt.Errorf("%w: %s", ErrInvalidStreamID, id)
+		}
+	}
+	return nil
+}
+
+func toAnySlice(values []string) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
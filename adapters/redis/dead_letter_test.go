@@ -0,0 +1,219 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDeadLetterTest(t *testing.T) (*redis.Client, IDeadLetterQueue[TestMessage], []string) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	ctx := context.Background()
+	var ids []string
+	for _, msg := range []TestMessage{{ID: "1"}, {ID: "2"}, {ID: "3"}} {
+		values, err := DefaultParseToMessage(msg)
+		require.NoError(t, err)
+		values[DeadLetterErrorField] = "sync failed " + msg.ID
+		values[DeadLetterGroupField] = "test-group"
+		id, err := client.XAdd(ctx, &redis.XAddArgs{Stream: DeadLetterStream("test-stream"), Values: values}).Result()
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	// 解析失敗而被移入的訊息沒有失敗原因
+	id, err := client.XAdd(ctx, &redis.XAddArgs{Stream: DeadLetterStream("test-stream"), Values: map[string]any{"data": "invalid"}}).Result()
+	require.NoError(t, err)
+	ids = append(ids, id)
+
+	queue, err := NewDeadLetterQueue[TestMessage](client, "test-stream")
+	require.NoError(t, err)
+	return client, queue, ids
+}
+
+func TestNewDeadLetterQueue(t *testing.T) {
+	_, err := NewDeadLetterQueue[TestMessage](nil, "test-stream")
+	assert.Error(t, err)
+	_, err = NewDeadLetterQueue[TestMessage](redis.NewClient(&redis.Options{}), "")
+	assert.Error(t, err)
+}
+
+func TestDeadLetterQueue_List(t *testing.T) {
+	_, queue, ids := setupDeadLetterTest(t)
+	ctx := context.Background()
+
+	// 由新到舊分頁
+	page, err := queue.List(ctx, "", 3)
+	require.NoError(t, err)
+	require.Len(t, page, 3)
+	assert.Equal(t, []string{ids[3], ids[2], ids[1]}, []string{page[0].ID, page[1].ID, page[2].ID})
+	assert.Error(t, page[0].ParseError)
+	assert.Empty(t, page[0].Error)
+	assert.NoError(t, page[1].ParseError)
+	assert.Equal(t, "3", page[1].Data.ID)
+	assert.Equal(t, "sync failed 3", page[1].Error)
+	assert.Equal(t, "test-group", page[1].Group)
+
+	page, err = queue.List(ctx, page[2].ID, 3)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, ids[0], page[0].ID)
+
+	_, err = queue.List(ctx, "invalid", 3)
+	assert.ErrorIs(t, err, ErrInvalidStreamID)
+}
+
+func TestDeadLetterQueue_Get(t *testing.T) {
+	_, queue, ids := setupDeadLetterTest(t)
+	entries, err := queue.Get(context.Background(), ids[1], "1-0")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, ids[1], entries[0].ID)
+	assert.Equal(t, "2", entries[0].Data.ID)
+
+	_, err = queue.Get(context.Background())
+	assert.Error(t, err)
+}
+
+func TestDeadLetterQueue_Replay(t *testing.T) {
+	client, queue, ids := setupDeadLetterTest(t)
+	ctx := context.Background()
+
+	replayed, err := queue.Replay(ctx, ids[0], ids[2], "1-0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{ids[0], ids[2]}, replayed)
+
+	// 重送的訊息以新的ID寫回原本的 stream，並移除失敗原因，只交付給處理失敗的 consumer group
+	messages, err := client.XRange(ctx, "test-stream", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	for i, want := range []string{"1", "3"} {
+		assert.NotContains(t, messages[i].Values, DeadLetterErrorField)
+		assert.NotContains(t, messages[i].Values, DeadLetterGroupField)
+		assert.Equal(t, "test-group", messages[i].Values[ReplayGroupField])
+		data, err := DefaultParseFromMessage[TestMessage](messages[i].Values)
+		require.NoError(t, err)
+		assert.Equal(t, want, data.ID)
+	}
+	remaining, err := queue.List(ctx, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, remaining, 2)
+
+	// 已經重送的訊息不會重複重送
+	replayed, err = queue.Replay(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Empty(t, replayed)
+
+	_, err = queue.Replay(ctx, "invalid")
+	assert.ErrorIs(t, err, ErrInvalidStreamID)
+}
+
+func TestDeadLetterQueue_ReplayOnlyFailingGroup(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	newGroupConsumer := func(group string) IGroupConsumer[TestMessage] {
+		consumer, err := NewGroupConsumer[TestMessage](
+			client,
+			"test-stream",
+			group,
+			"test-consumer",
+			WithGroupConsumerCreateGroup[TestMessage]("0"),
+			WithGroupConsumerBlockTimeout[TestMessage](10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Start())
+		return consumer
+	}
+	failing := newGroupConsumer("failing-group")
+	defer failing.Close()
+	other := newGroupConsumer("other-group")
+	defer other.Close()
+	reader, err := NewConsumer[TestMessage](client, "test-stream", WithConsumerStartID[TestMessage]("0"), WithConsumerBlockTimeout[TestMessage](10*time.Millisecond))
+	require.NoError(t, err)
+	reader.Start()
+	defer reader.Close()
+
+	values, err := DefaultParseToMessage(TestMessage{ID: "1"})
+	require.NoError(t, err)
+	require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: "test-stream", Values: values}).Err())
+
+	receive := func(ch <-chan *Message[TestMessage]) *Message[TestMessage] {
+		select {
+		case msg := <-ch:
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for message")
+			return nil
+		}
+	}
+	require.NoError(t, receive(other.Subscribe()).Done(ctx))
+	require.NoError(t, receive(failing.Subscribe()).Fail(ctx, errors.New("sync failed")))
+	select {
+	case <-reader.Subscribe():
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+
+	// dead-letter 記錄處理失敗的 consumer group，重送後只有該 consumer group 會再次收到
+	queue, err := NewDeadLetterQueue[TestMessage](client, "test-stream")
+	require.NoError(t, err)
+	entries, err := queue.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "failing-group", entries[0].Group)
+	_, err = queue.Replay(ctx, entries[0].ID)
+	require.NoError(t, err)
+
+	msg := receive(failing.Subscribe())
+	assert.Equal(t, "1", msg.Data.ID)
+	require.NoError(t, msg.Done(ctx))
+	select {
+	case msg := <-other.Subscribe():
+		t.Fatalf("other group received replay %s", msg.ID())
+	case data := <-reader.Subscribe():
+		t.Fatalf("consumer received replay %v", data)
+	case <-time.After(100 * time.Millisecond):
+	}
+	// 其他 consumer group 會確認略過的訊息，不會留在 pending 中
+	pending, err := client.XPending(ctx, "test-stream", "other-group").Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count)
+
+	// Replay 也不會返回重送的訊息
+	var replayedIDs []string
+	require.NoError(t, reader.Replay(ctx, "-", "+", func(id string, _ TestMessage) error {
+		replayedIDs = append(replayedIDs, id)
+		return nil
+	}))
+	assert.Len(t, replayedIDs, 1)
+}
+
+func TestDeadLetterQueue_Discard(t *testing.T) {
+	client, queue, ids := setupDeadLetterTest(t)
+	ctx := context.Background()
+
+	discarded, err := queue.Discard(ctx, ids[1], ids[3], "1-0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{ids[1], ids[3]}, discarded)
+
+	length, err := client.XLen(ctx, DeadLetterStream("test-stream")).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), length)
+	length, err = client.XLen(ctx, "test-stream").Result()
+	require.NoError(t, err)
+	assert.Zero(t, length)
+
+	discarded, err = queue.Discard(ctx, ids[1])
+	require.NoError(t, err)
+	assert.Empty(t, discarded)
+}
@@ -0,0 +1,209 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 以關閉 miniredis 並在相同的位址啟動新的 miniredis 模擬 Redis 主從切換，新的 miniredis 代表新的主節點
+
+func setupFailoverTest(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	return mr, client
+}
+
+func publishToMiniredis(t *testing.T, mr *miniredis.Miniredis, stream string, msg TestMessage) {
+	values, err := DefaultParseToMessage(msg)
+	require.NoError(t, err)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	require.NoError(t, client.XAdd(context.Background(), &redis.XAddArgs{Stream: stream, Values: values}).Err())
+}
+
+// simulateFailover 關閉 miniredis，等待 downtime 後在相同的位址啟動新的 miniredis，返回新的 miniredis
+// 切換前的 stream 和 consumer group 會複製到新的 miniredis，代表已經同步到新主節點的資料
+// NOTE: 只複製 consumer group 最後讀取的位置，尚未確認的訊息不會複製
+func simulateFailover(t *testing.T, mr *miniredis.Miniredis, downtime time.Duration) *miniredis.Miniredis {
+	ctx := context.Background()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	streams := map[string][]miniredis.StreamEntry{}
+	groups := map[string][]redis.XInfoGroup{}
+	for _, key := range mr.Keys() {
+		if mr.Type(key) != "stream" {
+			continue
+		}
+		entries, err := mr.Stream(key)
+		require.NoError(t, err)
+		streams[key] = entries
+		groups[key], err = client.XInfoGroups(ctx, key).Result()
+		require.NoError(t, err)
+	}
+
+	addr := mr.Addr()
+	mr.Close()
+	time.Sleep(downtime)
+	next := startMiniredis(t, addr)
+
+	nextClient := redis.NewClient(&redis.Options{Addr: addr})
+	defer nextClient.Close()
+	for key, entries := range streams {
+		for _, entry := range entries {
+			_, err := next.XAdd(key, entry.ID, entry.Values)
+			require.NoError(t, err)
+		}
+		for _, group := range groups[key] {
+			require.NoError(t, nextClient.XGroupCreateMkStream(ctx, key, group.Name, group.LastDeliveredID).Err())
+		}
+	}
+	return next
+}
+
+// startMiniredis 在指定的位址啟動新的 miniredis
+func startMiniredis(t *testing.T, addr string) *miniredis.Miniredis {
+	mr := miniredis.NewMiniRedis()
+	assert.NoError(t, mr.StartAddr(addr))
+	t.Cleanup(mr.Close)
+	return mr
+}
+
+func TestConsumer_Failover(t *testing.T) {
+	mr, client := setupFailoverTest(t)
+
+	// 從頭開始讀取，第一次讀取前寫入的訊息也不會遺失
+	consumer, err := NewConsumer(client, "test-stream",
+		WithConsumerStartID[TestMessage]("0"),
+		WithConsumerBlockTimeout[TestMessage](50*time.Millisecond),
+		WithConsumerRetryDelay[TestMessage](20*time.Millisecond),
+	)
+	require.NoError(t, err)
+	consumer.Start()
+	defer consumer.Close()
+
+	publishToMiniredis(t, mr, "test-stream", TestMessage{ID: "1"})
+	receive := func() TestMessage {
+		select {
+		case msg := <-consumer.Subscribe():
+			return msg
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for message")
+			return TestMessage{}
+		}
+	}
+	assert.Equal(t, "1", receive().ID)
+
+	// 連線恢復後從最後讀取的位置繼續，不會重複收到已經讀取的訊息
+	mr = simulateFailover(t, mr, 100*time.Millisecond)
+	publishToMiniredis(t, mr, "test-stream", TestMessage{ID: "2"})
+	assert.Equal(t, "2", receive().ID)
+}
+
+func TestGroupConsumer_Failover(t *testing.T) {
+	mr, client := setupFailoverTest(t)
+
+	consumer, err := NewGroupConsumer(client, "test-stream", "test-group", "test-consumer",
+		WithGroupConsumerCreateGroup[TestMessage]("0"),
+		WithGroupConsumerBlockTimeout[TestMessage](50*time.Millisecond),
+		WithGroupConsumerRetryDelay[TestMessage](20*time.Millisecond),
+	)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Start())
+	defer consumer.Close()
+
+	receive := func() *Message[TestMessage] {
+		select {
+		case msg := <-consumer.Subscribe():
+			return msg
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for message")
+			return nil
+		}
+	}
+	publishToMiniredis(t, mr, "test-stream", TestMessage{ID: "1"})
+	msg := receive()
+	assert.Equal(t, "1", msg.Data.ID)
+	require.NoError(t, msg.Done(context.Background()))
+
+	mr = simulateFailover(t, mr, 100*time.Millisecond)
+	publishToMiniredis(t, mr, "test-stream", TestMessage{ID: "2"})
+	msg = receive()
+	assert.Equal(t, "2", msg.Data.ID)
+	require.NoError(t, msg.Done(context.Background()))
+
+	pending, err := client.XPending(context.Background(), "test-stream", "test-group").Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count)
+}
+
+func TestAutoRenewMutex_Failover(t *testing.T) {
+	t.Run("新的主節點沒有同步到鎖", func(t *testing.T) {
+		mr, client := setupFailoverTest(t)
+		mutex := NewAutoRenewMutex(client, "test-lock",
+			WithAutoRenewMutexExpiry(time.Second),
+			WithAutoRenewMutexRenewInterval(50*time.Millisecond),
+		)
+		lockCtx, err := mutex.Lock(context.Background())
+		require.NoError(t, err)
+
+		// 鎖沒有同步到新的主節點，並且已經被其他實例取得
+		mr.Del("test-lock")
+		require.NoError(t, mr.Set("test-lock", "other-instance"))
+
+		select {
+		case <-lockCtx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("lock context should be cancelled after renew fails")
+		}
+		assert.False(t, mutex.Valid())
+
+		// 釋放鎖時不會刪除其他實例持有的鎖
+		ok, err := mutex.Unlock()
+		assert.Error(t, err)
+		assert.False(t, ok)
+		value, err := mr.Get("test-lock")
+		require.NoError(t, err)
+		assert.Equal(t, "other-instance", value)
+	})
+
+	t.Run("切換期間無法連線", func(t *testing.T) {
+		mr, client := setupFailoverTest(t)
+		mutex := NewAutoRenewMutex(client, "test-lock",
+			WithAutoRenewMutexExpiry(time.Second),
+			WithAutoRenewMutexRenewInterval(50*time.Millisecond),
+			WithAutoRenewMutexRetryDelay(50*time.Millisecond),
+			WithAutoRenewMutexSkipLockError(true),
+		)
+		lockCtx, err := mutex.Lock(context.Background())
+		require.NoError(t, err)
+
+		addr := mr.Addr()
+		mr.Close()
+		select {
+		case <-lockCtx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("lock context should be cancelled when redis is unavailable")
+		}
+		mutex.Unlock()
+
+		// 忽略鎖定錯誤時會持續重試，連線恢復後重新取得鎖，新的主節點沒有同步到舊的鎖
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			startMiniredis(t, addr)
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		lockCtx, err = mutex.Lock(ctx)
+		require.NoError(t, err)
+		assert.NoError(t, lockCtx.Err())
+		_, err = mutex.Unlock()
+		assert.NoError(t, err)
+	})
+}
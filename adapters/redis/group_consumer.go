@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,26 +22,68 @@ var (
 type Message[T any] struct {
 	Data T
 
-	client    *redis.Client
+	client    redis.UniversalClient
 	done      bool
 	messageID string
 	stream    string
 	group     string
+	attempt   int64
+	policy    *retryPolicy
+	handler   MessageHandler[T]
+	// 嚴格順序模式下讀取消息時持有的鎖的 context，失去鎖時 Retry 會停止等待
+	lockCtx context.Context
+	// 嚴格順序模式下記錄尚未確認的消息，釋放鎖之前會等待所有交付的消息確認
+	inflight *inflight
+	settled  bool
 
 	raw map[string]any
 }
 
+// MessageHandler 處理消息的確認、失敗和重試，讓不是以 Redis stream 實作的 consumer 也可以交付 Message
+type MessageHandler[T any] interface {
+	// Done 確認消息已處理完成
+	Done(ctx context.Context, m *Message[T]) error
+	// Fail 確認消息處理失敗
+	Fail(ctx context.Context, m *Message[T], failErr error) error
+	// Retry 安排消息重新投遞，返回 true 時呼叫者需要立即重新處理這個消息，參考 Message.Retry
+	Retry(ctx context.Context, m *Message[T], failErr error) (bool, error)
+}
+
+// NewMessage 建立由 handler 確認的消息，attempt 為第幾次投遞，從1開始
+func NewMessage[T any](id string, data T, attempt int64, handler MessageHandler[T]) *Message[T] {
+	return &Message[T]{
+		Data:      data,
+		messageID: id,
+		attempt:   attempt,
+		handler:   handler,
+	}
+}
+
+// ID 取得消息的ID
+func (m *Message[T]) ID() string {
+	return m.messageID
+}
+
 // Done 確認消息已處理完成
 func (m *Message[T]) Done(ctx context.Context) error {
 	const op = "Message.Done"
 	if m.done {
 		return nil
 	}
+	if m.handler != nil {
+		if err := m.handler.Done(ctx, m); err != nil {
+			return fmt.Errorf("[%s] %w", op, err)
+		}
+		m.done = true
+		return nil
+	}
 	err := m.client.XAck(ctx, m.stream, m.group, m.messageID).Err()
 	if err != nil {
 		return fmt.Errorf("[%s] failed to ack message: %w", op, err)
 	}
 	m.done = true
+	m.settle()
+	m.clearAttempt(ctx)
 	return nil
 }
 
@@ -49,11 +93,23 @@ func (m *Message[T]) Fail(ctx context.Context, failErr error) error {
 	if m.done {
 		return nil
 	}
+	if m.handler != nil {
+		if err := m.handler.Fail(ctx, m, failErr); err != nil {
+			return fmt.Errorf("[%s] %w", op, err)
+		}
+		m.done = true
+		return nil
+	}
 
-	m.raw["error"] = failErr.Error()
+	m.raw[DeadLetterErrorField] = failErr.Error()
+	m.raw[DeadLetterGroupField] = m.group
+	if m.attempt > 0 {
+		// 嚴格順序模式在原地重試，投遞次數只記錄在記憶體和 RetryAttempts 中
+		m.raw[RetryAttemptField] = strconv.FormatInt(m.attempt, 10)
+	}
 	err := m.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: m.stream + ":dead-letter",
-		Values: m.raw,
+		Stream: DeadLetterStream(m.stream),
+		Values: FlattenFields(m.raw),
 	}).Err()
 	if err != nil {
 		return fmt.Errorf("[%s] failed to move message to dead letter queue: %w", op, err)
@@ -64,22 +120,76 @@ func (m *Message[T]) Fail(ctx context.Context, failErr error) error {
 		return fmt.Errorf("[%s] failed to ack failed message: %w", op, err)
 	}
 	m.done = true
+	m.settle()
+	m.clearAttempt(ctx)
+	return nil
+}
+
+// DoneMessages 確認多筆消息已處理完成，同一個 stream 的消息以一次XACK確認
+// 用於批次處理時在資料庫交易提交之後一起確認
+func DoneMessages[T any](ctx context.Context, messages ...*Message[T]) error {
+	const op = "DoneMessages"
+	type key struct{ stream, group string }
+	var keys []key
+	pending := map[key][]*Message[T]{}
+	for _, m := range messages {
+		if m.done {
+			continue
+		}
+		if m.handler != nil {
+			if err := m.Done(ctx); err != nil {
+				return fmt.Errorf("[%s] %w", op, err)
+			}
+			continue
+		}
+		k := key{m.stream, m.group}
+		if _, ok := pending[k]; !ok {
+			keys = append(keys, k)
+		}
+		pending[k] = append(pending[k], m)
+	}
+	for _, k := range keys {
+		ids := make([]string, len(pending[k]))
+		for i, m := range pending[k] {
+			ids[i] = m.messageID
+		}
+		if err := pending[k][0].client.XAck(ctx, k.stream, k.group, ids...).Err(); err != nil {
+			return fmt.Errorf("[%s] failed to ack messages: %w", op, err)
+		}
+		for _, m := range pending[k] {
+			m.done = true
+			m.settle()
+			m.clearAttempt(ctx)
+		}
+	}
 	return nil
 }
 
 type GroupConsumer[T any] struct {
-	client        *redis.Client
+	client        redis.UniversalClient
 	stream        string
 	group         string
 	consumer      string
 	downStream    chan *Message[T]
+	batch         bool // 批次模式，以batchStream整批交付消息
+	batchStream   chan []*Message[T]
 	cancelFunc    context.CancelFunc
 	wg            sync.WaitGroup
+	lifecycle     sync.Mutex // 保護 Start 和 Close，Close 可能同時被多個 goroutine 呼叫
 	closed        bool
 	logger        *slog.Logger
 	mutex         IAutoRenewMutex
+	inflight      *inflight // 嚴格順序模式下這次持有鎖期間交付的消息
 	pendingMsgIds []string
 	options       groupConsumerOptions[T]
+
+	// 非嚴格順序模式下認領閒置pending消息的狀態
+	claimedMessages []redis.XMessage
+	claimCursor     string
+	nextClaimAt     time.Time
+	// 非嚴格順序模式下認領到期重試消息的狀態
+	nextRetryClaimAt time.Time
+	retryPolicy      *retryPolicy
 }
 
 type groupConsumerOptions[T any] struct {
@@ -87,8 +197,21 @@ type groupConsumerOptions[T any] struct {
 	parseFunc      func(map[string]any) (T, error)
 	bufferSize     int
 	blockTimeout   time.Duration
+	retryDelay     time.Duration
 	mutex          IAutoRenewMutex
-	strictOrdering bool // 嚴格順序模式
+	strictOrdering bool   // 嚴格順序模式
+	createGroupID  string // 自動建立consumer group時的起始ID，空字串表示不自動建立
+	batchSize      int    // 每次從stream讀取的消息數量上限
+	claimMinIdle   time.Duration
+	claimInterval  time.Duration
+	claimCount     int64
+	maxDeliveries  int64
+	// 消息重試的策略，參考 Message.Retry
+	maxAttempts       int64
+	backoffBase       time.Duration
+	backoffMax        time.Duration
+	retryPollInterval time.Duration
+	releaseTimeout    time.Duration // 嚴格順序模式下釋放鎖之前等待消息確認的時間上限
 }
 
 type GroupConsumerOption[T any] func(*groupConsumerOptions[T])
@@ -107,6 +230,13 @@ func WithGroupConsumerParseFunc[T any](fn func(map[string]any) (T, error)) Group
 	}
 }
 
+// WithGroupConsumerSchema 以 Schema 解碼消息，舊版本的消息會經過 upcaster 轉換成目前的版本
+func WithGroupConsumerSchema[T any](schema *Schema[T]) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.parseFunc = schema.Decode
+	}
+}
+
 // WithGroupConsumerBufferSize 設置下游channel的緩衝大小
 func WithGroupConsumerBufferSize[T any](size int) GroupConsumerOption[T] {
 	return func(o *groupConsumerOptions[T]) {
@@ -121,6 +251,14 @@ func WithGroupConsumerBlockTimeout[T any](d time.Duration) GroupConsumerOption[T
 	}
 }
 
+// WithGroupConsumerRetryDelay 設置讀取失敗或處理流程重啟前的等待時間，預設為0表示立即重試
+// Redis 發生主從切換時，連線會在新的主節點可用之前持續失敗，設置等待時間可以避免大量重試
+func WithGroupConsumerRetryDelay[T any](d time.Duration) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.retryDelay = d
+	}
+}
+
 // WithGroupConsumerMutex 注入mutex (主要用於測試)
 func WithGroupConsumerMutex[T any](mutex IAutoRenewMutex) GroupConsumerOption[T] {
 	return func(o *groupConsumerOptions[T]) {
@@ -128,6 +266,14 @@ func WithGroupConsumerMutex[T any](mutex IAutoRenewMutex) GroupConsumerOption[T]
 	}
 }
 
+// WithGroupConsumerReleaseTimeout 設置嚴格順序模式下釋放鎖之前，等待已經交付的消息被確認的時間上限，預設為30秒
+// 超過時間仍然沒有確認的消息會留在 pending 中，由下一個取得鎖的 consumer 重新處理
+func WithGroupConsumerReleaseTimeout[T any](d time.Duration) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.releaseTimeout = d
+	}
+}
+
 // WithGroupConsumerStrictOrdering 設置是否使用嚴格順序模式
 func WithGroupConsumerStrictOrdering[T any](strict bool) GroupConsumerOption[T] {
 	return func(o *groupConsumerOptions[T]) {
@@ -135,12 +281,106 @@ func WithGroupConsumerStrictOrdering[T any](strict bool) GroupConsumerOption[T]
 	}
 }
 
+// WithGroupConsumerCreateGroup 設置在consumer group不存在時自動建立，startID為開始讀取的位置("0"或"$")
+// 使用分區的stream時，每個分區都需要一個consumer group，自動建立可以避免手動建立所有分區
+func WithGroupConsumerCreateGroup[T any](startID string) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.createGroupID = startID
+	}
+}
+
+// WithGroupConsumerBatchSize 設置每次從stream讀取的消息數量上限，預設為1
+// 非批次模式下讀取到的消息仍然會逐筆交給下游，只是減少和Redis之間的往返次數
+func WithGroupConsumerBatchSize[T any](size int) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.batchSize = size
+	}
+}
+
+// WithGroupConsumerClaimMinIdle 設置非嚴格順序模式下，pending消息閒置超過多久後由XAUTOCLAIM認領重新處理，預設為0表示不認領
+// 嚴格順序模式下取得鎖後會先處理所有pending消息，不需要認領
+func WithGroupConsumerClaimMinIdle[T any](d time.Duration) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.claimMinIdle = d
+	}
+}
+
+// WithGroupConsumerClaimInterval 設置認領閒置pending消息的間隔，預設為30秒
+func WithGroupConsumerClaimInterval[T any](d time.Duration) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.claimInterval = d
+	}
+}
+
+// WithGroupConsumerClaimCount 設置每次XAUTOCLAIM認領的消息數量上限，預設為100
+func WithGroupConsumerClaimCount[T any](count int64) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.claimCount = count
+	}
+}
+
+// WithGroupConsumerMaxDeliveries 設置認領的消息最多被投遞幾次，超過後移動到dead-letter，預設為0表示不限制
+func WithGroupConsumerMaxDeliveries[T any](n int64) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.maxDeliveries = n
+	}
+}
+
+// WithGroupConsumerMaxAttempts 設置消息最多投遞幾次，Message.Retry 達到上限後移動到dead-letter，預設為1表示不重試
+func WithGroupConsumerMaxAttempts[T any](n int64) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.maxAttempts = n
+	}
+}
+
+// WithGroupConsumerBackoff 設置 Message.Retry 的退避時間，從 base 開始每次加倍，最多為 max，預設為1秒到1分鐘
+func WithGroupConsumerBackoff[T any](base, max time.Duration) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.backoffBase = base
+		o.backoffMax = max
+	}
+}
+
+// WithGroupConsumerRetryPollInterval 設置非嚴格順序模式下檢查到期重試消息的間隔，預設為1秒
+func WithGroupConsumerRetryPollInterval[T any](d time.Duration) GroupConsumerOption[T] {
+	return func(o *groupConsumerOptions[T]) {
+		o.retryPollInterval = d
+	}
+}
+
 func NewGroupConsumer[T any](
-	client *redis.Client,
+	client redis.UniversalClient,
 	stream, group, consumer string,
 	opts ...GroupConsumerOption[T],
 ) (IGroupConsumer[T], error) {
-	if client == nil {
+	gc, err := newGroupConsumer(client, stream, group, consumer, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return gc, nil
+}
+
+// NewBatchGroupConsumer 建立以批次交付消息的 GroupConsumer，每批最多 batchSize 筆消息(預設為100)
+// 同一批的消息依照 stream 中的順序排列，嚴格順序模式下下游依序處理每一批即可維持順序
+func NewBatchGroupConsumer[T any](
+	client redis.UniversalClient,
+	stream, group, consumer string,
+	opts ...GroupConsumerOption[T],
+) (IBatchGroupConsumer[T], error) {
+	gc, err := newGroupConsumer(client, stream, group, consumer, append([]GroupConsumerOption[T]{WithGroupConsumerBatchSize[T](100)}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	gc.batch = true
+	return gc, nil
+}
+
+func newGroupConsumer[T any](
+	client redis.UniversalClient,
+	stream, group, consumer string,
+	opts ...GroupConsumerOption[T],
+) (*GroupConsumer[T], error) {
+	if isNilClient(client) {
 		return nil, errors.New("redis client cannot be nil")
 	}
 	if stream == "" || group == "" || consumer == "" {
@@ -154,12 +394,33 @@ func NewGroupConsumer[T any](
 		bufferSize:     1,
 		blockTimeout:   time.Second,
 		strictOrdering: false,
+		claimInterval:  30 * time.Second,
+		claimCount:     100,
+		batchSize:      1,
+
+		maxAttempts:       1,
+		backoffBase:       time.Second,
+		backoffMax:        time.Minute,
+		retryPollInterval: time.Second,
+		releaseTimeout:    30 * time.Second,
 	}
 
 	// 應用自定義選項
 	for _, opt := range opts {
 		opt(&options)
 	}
+	if options.claimMinIdle < 0 || options.claimInterval <= 0 || options.claimCount <= 0 || options.maxDeliveries < 0 {
+		return nil, errors.New("invalid claim options")
+	}
+	if options.batchSize <= 0 {
+		return nil, errors.New("batch size must be positive")
+	}
+	if options.maxAttempts <= 0 || options.backoffBase < 0 || options.backoffMax < options.backoffBase || options.retryPollInterval <= 0 {
+		return nil, errors.New("invalid retry options")
+	}
+	if options.releaseTimeout <= 0 {
+		return nil, errors.New("release timeout must be positive")
+	}
 
 	gc := &GroupConsumer[T]{
 		logger:   options.logger.With(slog.String("caller", "GroupConsumer"), slog.String("stream", stream), slog.String("group", group), slog.String("consumer", consumer)),
@@ -169,6 +430,12 @@ func NewGroupConsumer[T any](
 		consumer: consumer,
 		closed:   true,
 		options:  options,
+		retryPolicy: &retryPolicy{
+			maxAttempts: options.maxAttempts,
+			backoffBase: options.backoffBase,
+			backoffMax:  options.backoffMax,
+			strict:      options.strictOrdering,
+		},
 	}
 
 	// 只在嚴格順序模式下設置mutex
@@ -184,11 +451,17 @@ func NewGroupConsumer[T any](
 }
 
 func (s *GroupConsumer[T]) Start() error {
+	s.lifecycle.Lock()
+	defer s.lifecycle.Unlock()
 	if !s.closed {
 		return nil
 	}
 	ctx, cancel := context.WithCancel(context.Background())
-	s.downStream = make(chan *Message[T], s.options.bufferSize)
+	if s.batch {
+		s.batchStream = make(chan []*Message[T], s.options.bufferSize)
+	} else {
+		s.downStream = make(chan *Message[T], s.options.bufferSize)
+	}
 	s.cancelFunc = cancel
 	s.closed = false
 	s.logger.Info("starting group consumer")
@@ -197,21 +470,23 @@ func (s *GroupConsumer[T]) Start() error {
 	go func() {
 		defer s.wg.Done()
 		defer s.logger.Info("group consumer goroutine stopped")
-		defer close(s.downStream)
 		defer func() {
-			if s.options.strictOrdering {
-				s.mutex.Unlock()
+			if s.batch {
+				close(s.batchStream)
+			} else {
+				close(s.downStream)
 			}
 		}()
 
 		for {
 			workloadContext := ctx
+			release := func() {}
 
 			// 如果是嚴格順序模式下，會先拿鎖，然後再處理消息
 			if s.options.strictOrdering {
 				var err error
 				// workloadContext在嚴格順序模式下會被修改成帶鎖狀態的child context，可以接收到鎖的釋放信號
-				workloadContext, err = s.mutex.Lock(ctx)
+				workloadContext, release, err = s.lockWorkload(ctx)
 				if err != nil {
 					s.logger.Error("failed to acquire lock", slog.Any("error", err))
 					if errors.Is(err, context.Canceled) {
@@ -220,7 +495,10 @@ func (s *GroupConsumer[T]) Start() error {
 					continue
 				}
 			}
-			if err := s.messagesWorkflow(workloadContext); err != nil {
+			err := s.messagesWorkflow(workloadContext)
+			// 釋放鎖之前等待已經交付的消息確認，避免接手的 consumer 重複處理
+			release()
+			if err != nil {
 				// 如果是context.Canceled，且是因為外部context取消，則退出循環
 				if errors.Is(err, context.Canceled) && ctx.Err() != nil {
 					break
@@ -229,8 +507,11 @@ func (s *GroupConsumer[T]) Start() error {
 					// 如果是context.Canceled，且是因為鎖的context取消，則繼續循環
 					s.logger.Error("lock context cancelled, stopping current processing, restarting group consumer")
 				} else {
-					// 其他錯誤情況，重啟group consumer
+					// 其他錯誤情況，等待後重啟group consumer
 					s.logger.Error("error processing messages, stopping current processing, restarting group consumer", slog.Any("error", err))
+					if waitRetry(ctx, s.options.retryDelay) != nil {
+						break
+					}
 				}
 				continue
 			}
@@ -245,7 +526,14 @@ func (s *GroupConsumer[T]) Subscribe() <-chan *Message[T] {
 	return s.downStream
 }
 
+// SubscribeBatch 訂閱Stream，返回批次的Message通道，只有以 NewBatchGroupConsumer 建立時可以使用
+func (s *GroupConsumer[T]) SubscribeBatch() <-chan []*Message[T] {
+	return s.batchStream
+}
+
 func (s *GroupConsumer[T]) Close() error {
+	s.lifecycle.Lock()
+	defer s.lifecycle.Unlock()
 	if s.closed {
 		return nil
 	}
@@ -260,14 +548,31 @@ func (s *GroupConsumer[T]) Close() error {
 
 // messagesWorkflow 處理消息的工作流程
 func (s *GroupConsumer[T]) messagesWorkflow(ctx context.Context) error {
+	if s.options.createGroupID != "" {
+		if err := s.createGroup(ctx); err != nil {
+			s.logger.Error("create consumer group failed", slog.Any("error", err))
+			return err
+		}
+	}
 	if s.options.strictOrdering {
 		if err := s.fetchPendingMessageIds(ctx); err != nil {
 			s.logger.Error("initial pending messages fetch failed", slog.Any("error", err))
 			return err
 		}
+	} else {
+		// 每次重啟流程都從頭開始認領，之前認領但尚未處理的消息也會在之後重新認領
+		s.claimedMessages = nil
+		s.claimCursor = "0-0"
+		s.nextClaimAt = time.Time{}
+		s.nextRetryClaimAt = time.Time{}
+	}
+	// 嚴格順序模式下 ctx 為持有鎖的 context
+	var lockCtx context.Context
+	if s.options.strictOrdering {
+		lockCtx = ctx
 	}
 	for {
-		message, err := s.fetchNextMessage(ctx)
+		messages, err := s.fetchNextMessages(ctx)
 		if err != nil {
 			if errors.Is(err, redis.Nil) {
 				continue
@@ -276,45 +581,63 @@ func (s *GroupConsumer[T]) messagesWorkflow(ctx context.Context) error {
 			if errors.Is(err, context.Canceled) {
 				return err
 			}
-			// 其他的錯誤一般是server跟redis之間的通訊異常，重試即可
+			// 其他的錯誤一般是server跟redis之間的通訊異常(包含主從切換)，等待後重試即可
+			if err := waitRetry(ctx, s.options.retryDelay); err != nil {
+				return err
+			}
 			continue
 		}
-		data, err := s.options.parseFunc(message.Values)
-		if err != nil {
-			// 解析失敗的問題一個是原始資料，一個是解析方案，不管哪種都是需要額外處理的
-			// 不會因為重試就成功，因此先將消息移動到dead-letter，系統繼續處理下一條消息
-			s.logger.Error("failed to parse message",
-				slog.String("messageId", message.ID),
-				slog.Any("error", err),
-			)
-			if deadLetterErr := s.moveToDeadLetter(ctx, message); deadLetterErr != nil {
-				s.logger.Error("error moving message to dead letter",
-					slog.String("messageId", message.ID),
-					slog.Any("error", deadLetterErr),
-				)
-				// 如果在移動到dead-letter的過程中發生了異常，這個訊息會以pending的形式留在stream中
-				// WARN: 目前在嚴格順序模式下，這種狀況會在下一輪開始時優先處理這種訊息
-				// 		 但是在非嚴格順序模式下，會跳過pending訊息，一旦遇到這種狀況，訊息會一直以pending的形式留在stream中，需要手動對stream處理
-				return deadLetterErr
+		if messages, err = s.skipOtherReplays(ctx, messages); err != nil {
+			s.logger.Error("error acking messages replayed for other groups", slog.Any("error", err))
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			if err := waitRetry(ctx, s.options.retryDelay); err != nil {
+				return err
 			}
 			continue
 		}
-		msg := &Message[T]{
-			Data:      data,
-			messageID: message.ID,
-			stream:    s.stream,
-			group:     s.group,
-			client:    s.client,
-			raw:       message.Values,
+		batch := make([]*Message[T], 0, len(messages))
+		for _, message := range messages {
+			data, err := s.options.parseFunc(message.Values)
+			if err != nil {
+				// 解析失敗的問題一個是原始資料，一個是解析方案，不管哪種都是需要額外處理的
+				// 不會因為重試就成功，因此先將消息移動到dead-letter，系統繼續處理下一條消息
+				s.logger.Error("failed to parse message",
+					slog.String("messageId", message.ID),
+					slog.Any("error", err),
+				)
+				if deadLetterErr := s.moveToDeadLetter(ctx, message); deadLetterErr != nil {
+					s.logger.Error("error moving message to dead letter",
+						slog.String("messageId", message.ID),
+						slog.Any("error", deadLetterErr),
+					)
+					// 如果在移動到dead-letter的過程中發生了異常，這個訊息會以pending的形式留在stream中
+					// NOTE: 嚴格順序模式下，這種狀況會在下一輪開始時優先處理這種訊息
+					// 		 非嚴格順序模式下，訊息閒置超過claimMinIdle後會被重新認領，沒有設置claimMinIdle時需要手動對stream處理
+					return deadLetterErr
+				}
+				continue
+			}
+			batch = append(batch, &Message[T]{
+				Data:      data,
+				messageID: message.ID,
+				stream:    s.stream,
+				group:     s.group,
+				client:    s.client,
+				policy:    s.retryPolicy,
+				raw:       message.Values,
+				lockCtx:   lockCtx,
+			})
 		}
-		if err := s.moveToDownStream(ctx, msg); err != nil {
-			s.logger.Error("error moving message to downstream",
-				slog.String("messageId", message.ID),
+		if err := s.moveToDownStream(ctx, batch); err != nil {
+			s.logger.Error("error moving messages to downstream",
+				slog.Int("count", len(batch)),
 				slog.Any("error", err),
 			)
-			// 如果在移動到downstream的過程中發生了異常(只有可能是context.Canceled)，這個訊息會以pending的形式留在stream中
-			// WARN: 目前在嚴格順序模式下，這種狀況會在下一輪開始時優先處理這種訊息
-			// 		 但是在非嚴格順序模式下，會跳過pending訊息，一旦遇到這種狀況，訊息會一直以pending的形式留在stream中，需要手動對stream處理
+			// 如果在移動到downstream的過程中發生了異常(只有可能是context.Canceled)，這些訊息會以pending的形式留在stream中
+			// NOTE: 嚴格順序模式下，這種狀況會在下一輪開始時優先處理這種訊息
+			// 		 非嚴格順序模式下，訊息閒置超過claimMinIdle後會被重新認領，沒有設置claimMinIdle時需要手動對stream處理
 			return err
 		}
 	}
@@ -363,43 +686,177 @@ func (s *GroupConsumer[T]) fetchPendingMessageIds(ctx context.Context) error {
 	return nil
 }
 
-func (s *GroupConsumer[T]) fetchNextMessage(ctx context.Context) (redis.XMessage, error) {
-	var message redis.XMessage
-	var err error
+// fetchNextMessages 讀取下一批消息，最多batchSize筆，依序為認領的閒置消息、pending消息和新消息
+func (s *GroupConsumer[T]) fetchNextMessages(ctx context.Context) ([]redis.XMessage, error) {
+	batchSize := s.options.batchSize
+
+	if s.retryEnabled() && !time.Now().Before(s.nextRetryClaimAt) {
+		if err := s.claimDueRetries(ctx); err != nil {
+			return nil, err
+		}
+		s.nextRetryClaimAt = time.Now().Add(s.options.retryPollInterval)
+	}
+
+	if s.claimEnabled() && len(s.claimedMessages) == 0 && !time.Now().Before(s.nextClaimAt) {
+		if err := s.claimStaleMessages(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(s.claimedMessages) > 0 {
+		// 處理認領的閒置pending消息
+		n := min(batchSize, len(s.claimedMessages))
+		messages := s.claimedMessages[:n]
+		s.claimedMessages = s.claimedMessages[n:]
+		return messages, nil
+	}
 
 	if len(s.pendingMsgIds) > 0 {
 		// 讀取pending消息
-		var messages []redis.XMessage
-		messages, err = s.client.XRangeN(ctx, s.stream, s.pendingMsgIds[0], s.pendingMsgIds[0], 1).Result()
-		s.pendingMsgIds = s.pendingMsgIds[1:]
-		if len(messages) > 0 {
-			message = messages[0]
+		messages := make([]redis.XMessage, 0, min(batchSize, len(s.pendingMsgIds)))
+		for len(messages) < batchSize && len(s.pendingMsgIds) > 0 {
+			id := s.pendingMsgIds[0]
+			result, err := s.client.XRangeN(ctx, s.stream, id, id, 1).Result()
+			if err != nil {
+				return messages, err
+			}
+			s.pendingMsgIds = s.pendingMsgIds[1:]
+			if len(result) == 0 {
+				// 消息已經從stream刪除(例如被修剪)，無法再處理，直接確認
+				if err := s.client.XAck(ctx, s.stream, s.group, id).Err(); err != nil {
+					return messages, fmt.Errorf("error acking deleted message: %w", err)
+				}
+				continue
+			}
+			messages = append(messages, result[0])
 		}
-	} else {
-		// 讀取新消息
-		var streams []redis.XStream
-		streams, err = s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		if err := s.loadAttempts(ctx, messages); err != nil {
+			return messages, err
+		}
+		return messages, nil
+	}
+
+	// 讀取新消息
+	streams, err := s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    s.group,
+		Consumer: s.consumer,
+		Streams:  []string{s.stream, ">"},
+		Count:    int64(batchSize),
+		Block:    s.options.blockTimeout,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(streams) == 0 {
+		return nil, nil
+	}
+	return streams[0].Messages, nil
+}
+
+// claimEnabled 是否需要認領閒置的pending消息
+func (s *GroupConsumer[T]) claimEnabled() bool {
+	return !s.options.strictOrdering && s.options.claimMinIdle > 0
+}
+
+// claimStaleMessages 以XAUTOCLAIM認領其他consumer(例如意外下線的實例)閒置過久的pending消息
+//   - 每次只認領一批，cursor回到"0-0"表示已經掃描完整個PEL，等待claimInterval後再重新掃描
+//   - 設置maxDeliveries時，投遞次數超過上限的消息會直接移動到dead-letter，避免反覆處理失敗的消息
+//   - 已經從stream刪除的消息無法處理，直接確認
+//   - 還在等待重試的消息會略過，到期後由 claimDueRetries 認領
+func (s *GroupConsumer[T]) claimStaleMessages(ctx context.Context) error {
+	messages, cursor, err := s.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   s.stream,
+		Group:    s.group,
+		Consumer: s.consumer,
+		MinIdle:  s.options.claimMinIdle,
+		Start:    s.claimCursor,
+		Count:    s.options.claimCount,
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("error claiming pending messages: %w", err)
+	}
+	s.claimCursor = cursor
+	if cursor == "0-0" {
+		s.nextClaimAt = time.Now().Add(s.options.claimInterval)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	deliveries := map[string]int64{}
+	if s.options.maxDeliveries > 0 {
+		pending, err := s.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream:   s.stream,
 			Group:    s.group,
+			Start:    messages[0].ID,
+			End:      messages[len(messages)-1].ID,
+			Count:    int64(len(messages)),
 			Consumer: s.consumer,
-			Streams:  []string{s.stream, ">"},
-			Count:    1,
-			Block:    s.options.blockTimeout,
 		}).Result()
-		if len(streams) > 0 && len(streams[0].Messages) > 0 {
-			message = streams[0].Messages[0]
+		if err != nil {
+			return fmt.Errorf("error getting delivery counts of claimed messages: %w", err)
+		}
+		for _, p := range pending {
+			deliveries[p.ID] = p.RetryCount
 		}
 	}
 
-	return message, err
+	waiting, err := s.waitingRetry(ctx, messages)
+	if err != nil {
+		return err
+	}
+
+	claimed := make([]redis.XMessage, 0, len(messages))
+	for _, message := range messages {
+		if waiting[message.ID] {
+			continue
+		}
+		if message.Values == nil {
+			if err := s.client.XAck(ctx, s.stream, s.group, message.ID).Err(); err != nil {
+				return fmt.Errorf("error acking deleted message: %w", err)
+			}
+			continue
+		}
+		if count := deliveries[message.ID]; s.options.maxDeliveries > 0 && count > s.options.maxDeliveries {
+			s.logger.Warn("message exceeded max deliveries, moving to dead letter",
+				slog.String("messageId", message.ID),
+				slog.Int64("deliveries", count),
+			)
+			message.Values[DeadLetterErrorField] = fmt.Sprintf("exceeded max deliveries (%d)", s.options.maxDeliveries)
+			if err := s.moveToDeadLetter(ctx, message); err != nil {
+				return err
+			}
+			continue
+		}
+		claimed = append(claimed, message)
+	}
+	if err := s.loadAttempts(ctx, claimed); err != nil {
+		return err
+	}
+	if len(claimed) > 0 {
+		s.logger.Info("claimed stale pending messages", slog.Int("count", len(claimed)))
+	}
+	s.claimedMessages = claimed
+	return nil
+}
+
+// createGroup 建立consumer group，已經存在時不做任何處理
+func (s *GroupConsumer[T]) createGroup(ctx context.Context) error {
+	err := s.client.XGroupCreateMkStream(ctx, s.stream, s.group, s.options.createGroupID).Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("error creating consumer group: %w", err)
+	}
+	return nil
 }
 
 // 添加死信處理
 func (s *GroupConsumer[T]) moveToDeadLetter(ctx context.Context, message redis.XMessage) error {
-	deadLetterStream := s.stream + ":dead-letter"
+	deadLetterStream := DeadLetterStream(s.stream)
+	message.Values[DeadLetterGroupField] = s.group
 
 	err := s.client.XAdd(ctx, &redis.XAddArgs{
 		Stream: deadLetterStream,
-		Values: message.Values,
+		Values: FlattenFields(message.Values),
 	}).Err()
 
 	if err != nil {
@@ -410,15 +867,195 @@ func (s *GroupConsumer[T]) moveToDeadLetter(ctx context.Context, message redis.X
 	return s.client.XAck(ctx, s.stream, s.group, message.ID).Err()
 }
 
-// moveToDownStream 處理發送消息到下游channel
-func (s *GroupConsumer[T]) moveToDownStream(ctx context.Context, message *Message[T]) error {
+// skipOtherReplays 確認並略過重送給其他 consumer group 的消息，返回需要處理的消息
+// NOTE: 確認失敗時這些消息會以pending的形式留在stream中，之後重新讀取時再次略過
+func (s *GroupConsumer[T]) skipOtherReplays(ctx context.Context, messages []redis.XMessage) ([]redis.XMessage, error) {
+	var skipped []string
+	kept := make([]redis.XMessage, 0, len(messages))
+	for _, message := range messages {
+		if replayedForOtherGroup(message.Values, s.group) {
+			skipped = append(skipped, message.ID)
+			continue
+		}
+		kept = append(kept, message)
+	}
+	if len(skipped) == 0 {
+		return kept, nil
+	}
+	if err := s.client.XAck(ctx, s.stream, s.group, skipped...).Err(); err != nil {
+		return nil, err
+	}
+	s.logger.Debug("skipped messages replayed for other groups", slog.Any("messageIds", skipped))
+	return kept, nil
+}
+
+// moveToDownStream 處理發送消息到下游channel，批次模式下整批交付，否則逐筆交付
+func (s *GroupConsumer[T]) moveToDownStream(ctx context.Context, messages []*Message[T]) error {
+	if len(messages) == 0 {
+		return nil
+	}
 	if ctx.Err() != nil {
 		return context.Canceled
 	}
+	// 交付之前先計入未確認的消息，下游可能在交付後立即確認
+	s.track(messages)
+	if s.batch {
+		select {
+		case <-ctx.Done():
+			settleMessages(messages)
+			return context.Canceled
+		case s.batchStream <- messages:
+			return nil
+		}
+	}
+	for i, message := range messages {
+		select {
+		case <-ctx.Done():
+			settleMessages(messages[i:])
+			return context.Canceled
+		case s.downStream <- message:
+		}
+	}
+	return nil
+}
+
+// lockWorkload 取得嚴格順序模式的鎖，返回處理消息的 context 和釋放鎖的函式
+// 處理消息的 context 在 ctx 取消或失去鎖時取消，鎖則會持續續期到釋放為止，
+// 釋放時先等待這次持有鎖期間交付的消息確認(最多 releaseTimeout)，再解除鎖讓其他 consumer 接手
+func (s *GroupConsumer[T]) lockWorkload(ctx context.Context) (context.Context, func(), error) {
+	// 鎖的 context 不跟著 ctx 取消，只有在取得鎖的期間才會因為 ctx 取消而停止
+	lockBase, cancelLock := context.WithCancel(context.WithoutCancel(ctx))
+	stopAcquire := context.AfterFunc(ctx, cancelLock)
+	lockCtx, err := s.mutex.Lock(lockBase)
+	stopAcquire()
+	if err != nil {
+		cancelLock()
+		return nil, nil, err
+	}
+
+	workloadContext, cancelWorkload := context.WithCancel(lockCtx)
+	stopWorkload := context.AfterFunc(ctx, cancelWorkload)
+	tracker := newInflight()
+	s.inflight = tracker
+	release := func() {
+		stopWorkload()
+		cancelWorkload()
+		// 還留在下游channel中的消息不會再被處理，直接留在 pending 中
+		s.drainDownStream()
+		if !tracker.wait(lockCtx, s.options.releaseTimeout) {
+			s.logger.Warn("releasing lock with unacknowledged messages", slog.Int("count", tracker.len()))
+		}
+		s.inflight = nil
+		s.unlock()
+		cancelLock()
+	}
+	return workloadContext, release, nil
+}
+
+// unlock 釋放嚴格順序模式的鎖，鎖已經過期或被其他 consumer 取得時只記錄日誌
+func (s *GroupConsumer[T]) unlock() {
+	if _, err := s.mutex.Unlock(); err != nil {
+		s.logger.Warn("failed to release lock", slog.Any("error", err))
+	}
+}
+
+// track 將即將交付的消息計入這次持有鎖期間尚未確認的消息
+func (s *GroupConsumer[T]) track(messages []*Message[T]) {
+	if s.inflight == nil {
+		return
+	}
+	s.inflight.add(len(messages))
+	for _, m := range messages {
+		m.inflight = s.inflight
+	}
+}
+
+// drainDownStream 取出下游channel中還沒有被讀取的消息，這些消息會留在 pending 中由下一次取得鎖時重新處理
+func (s *GroupConsumer[T]) drainDownStream() {
+	for {
+		select {
+		case messages := <-s.batchStream:
+			settleMessages(messages)
+		case message := <-s.downStream:
+			message.settle()
+		default:
+			return
+		}
+	}
+}
+
+// settle 標記消息已經不需要等待確認，包含確認、移動到dead-letter、放棄重試和沒有被下游處理的消息
+func (m *Message[T]) settle() {
+	if m.inflight == nil || m.settled {
+		return
+	}
+	m.settled = true
+	m.inflight.done()
+}
+
+// settleMessages 標記多筆消息不需要等待確認
+func settleMessages[T any](messages []*Message[T]) {
+	for _, m := range messages {
+		m.settle()
+	}
+}
+
+// inflight 記錄已經交付給下游但還沒有確認的消息數量
+type inflight struct {
+	mu    sync.Mutex
+	count int
+	idle  chan struct{} // count 為0時關閉
+}
+
+func newInflight() *inflight {
+	idle := make(chan struct{})
+	close(idle)
+	return &inflight{idle: idle}
+}
+
+func (f *inflight) add(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if n <= 0 {
+		return
+	}
+	if f.count == 0 {
+		f.idle = make(chan struct{})
+	}
+	f.count += n
+}
+
+func (f *inflight) done() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.count == 0 {
+		return
+	}
+	f.count--
+	if f.count == 0 {
+		close(f.idle)
+	}
+}
+
+func (f *inflight) len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.count
+}
+
+// wait 等待所有消息確認，超過 timeout 或 ctx 取消(失去鎖)時返回 false
+func (f *inflight) wait(ctx context.Context, timeout time.Duration) bool {
+	f.mu.Lock()
+	idle := f.idle
+	f.mu.Unlock()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
 	select {
+	case <-idle:
+		return true
 	case <-ctx.Done():
-		return context.Canceled
-	case s.downStream <- message:
-		return nil
+		return false
+	case <-timer.C:
+		return false
 	}
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-redsync/redsync/v4"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -148,9 +150,9 @@ func TestGroupConsumer_StartStop(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockMutex := NewMockIAutoRenewMutex(ctrl)
+		// 沒有取得鎖，所以不需要釋放鎖
 		mockMutex.EXPECT().Lock(gomock.Any()).Return(nil, errors.New("non context error"))
 		mockMutex.EXPECT().Lock(gomock.Any()).Return(nil, context.Canceled).AnyTimes()
-		mockMutex.EXPECT().Unlock().Return(false, redsync.ErrLockAlreadyExpired)
 
 		consumer, err := NewGroupConsumer[TestMessage](
 			client,
@@ -265,7 +267,8 @@ func TestGroupConsumer_StartStop(t *testing.T) {
 			return nil, context.Canceled
 		})
 		mockMutex.EXPECT().Lock(gomock.Any()).Return(nil, context.Canceled).AnyTimes()
-		mockMutex.EXPECT().Unlock().Return(false, redsync.ErrLockAlreadyExpired)
+		// 每次取得的鎖都會在處理結束後釋放
+		mockMutex.EXPECT().Unlock().Return(false, redsync.ErrLockAlreadyExpired).Times(3)
 
 		// 模擬 pendingExt 使用三次失效鎖後的情況
 		for range 3 {
@@ -382,6 +385,8 @@ func TestGroupConsumer_MessageProcessing(t *testing.T) {
 			WithGroupConsumerStrictOrdering[TestMessage](true),
 			WithGroupConsumerMutex[TestMessage](mockMutex),
 			WithGroupConsumerBufferSize[TestMessage](0),
+			// 關閉後才確認消息，不需要等待確認
+			WithGroupConsumerReleaseTimeout[TestMessage](10*time.Millisecond),
 		)
 		require.NoError(t, err)
 
@@ -448,7 +453,7 @@ func TestGroupConsumer_MessageProcessing(t *testing.T) {
 		// Expect message to be moved to dead letter queue
 		mock.ExpectXAdd(&redis.XAddArgs{
 			Stream: "test-stream:dead-letter",
-			Values: map[string]interface{}{"data": "invalid"},
+			Values: []string{"data", "invalid", "group", "test-group"},
 		}).SetVal("1234-0")
 
 		mock.ExpectXAck("test-stream", "test-group", "1234-0").SetVal(1)
@@ -508,7 +513,7 @@ func TestGroupConsumer_MessageProcessing(t *testing.T) {
 		// Dead letter queue寫入失敗
 		mock.ExpectXAdd(&redis.XAddArgs{
 			Stream: "test-stream:dead-letter",
-			Values: map[string]interface{}{"data": "invalid"},
+			Values: []string{"data", "invalid", "group", "test-group"},
 		}).SetErr(errors.New("dead letter queue error"))
 
 		mock.ExpectXReadGroup(&redis.XReadGroupArgs{
@@ -965,9 +970,7 @@ func TestMessage_Fail(t *testing.T) {
 		// 期望消息被移動到死信隊列
 		mock.ExpectXAdd(&redis.XAddArgs{
 			Stream: "test-stream:dead-letter",
-			Values: map[string]any{
-				"error": "test error",
-			},
+			Values: []string{"error", "test error", "group", "test-group"},
 		}).SetVal("dlq-1234-0")
 
 		// 期望原始消息被確認
@@ -994,9 +997,7 @@ func TestMessage_Fail(t *testing.T) {
 		// 只應該呼叫一次XAdd和XAck
 		mock.ExpectXAdd(&redis.XAddArgs{
 			Stream: "test-stream:dead-letter",
-			Values: map[string]any{
-				"error": "test error",
-			},
+			Values: []string{"error", "test error", "group", "test-group"},
 		}).SetVal("dlq-1234-0")
 		mock.ExpectXAck("test-stream", "test-group", "1234-0").SetVal(1)
 
@@ -1025,9 +1026,7 @@ func TestMessage_Fail(t *testing.T) {
 		// 模擬死信隊列寫入失敗
 		mock.ExpectXAdd(&redis.XAddArgs{
 			Stream: "test-stream:dead-letter",
-			Values: map[string]any{
-				"error": "test error",
-			},
+			Values: []string{"error", "test error", "group", "test-group"},
 		}).SetErr(errors.New("dead letter queue error"))
 
 		err := msg.Fail(context.Background(), errors.New("test error"))
@@ -1052,9 +1051,7 @@ func TestMessage_Fail(t *testing.T) {
 		// 死信隊列寫入成功但ack失敗
 		mock.ExpectXAdd(&redis.XAddArgs{
 			Stream: "test-stream:dead-letter",
-			Values: map[string]any{
-				"error": "test error",
-			},
+			Values: []string{"error", "test error", "group", "test-group"},
 		}).SetVal("dlq-1234-0")
 		mock.ExpectXAck("test-stream", "test-group", "1234-0").SetErr(errors.New("ack error"))
 
@@ -1083,3 +1080,454 @@ func TestMessage_Fail(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestGroupConsumer_CreateGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "group created", err: nil, wantErr: false},
+		{name: "group already exists", err: errors.New("BUSYGROUP Consumer Group name already exists"), wantErr: false},
+		{name: "redis error", err: errors.New("connection refused"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, cleanup := setupTest(t)
+			defer cleanup()
+
+			consumer, err := NewGroupConsumer(client, "test-stream", "test-group", "test-consumer",
+				WithGroupConsumerCreateGroup[TestMessage]("0"),
+			)
+			require.NoError(t, err)
+
+			expect := mock.ExpectXGroupCreateMkStream("test-stream", "test-group", "0")
+			if tt.err != nil {
+				expect.SetErr(tt.err)
+			} else {
+				expect.SetVal("OK")
+			}
+
+			err = consumer.(*GroupConsumer[TestMessage]).createGroup(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGroupConsumer_ClaimStaleMessages(t *testing.T) {
+	claimArgs := &redis.XAutoClaimArgs{
+		Stream:   "test-stream",
+		Group:    "test-group",
+		Consumer: "test-consumer",
+		MinIdle:  time.Minute,
+		Start:    "0-0",
+		Count:    100,
+	}
+	readArgs := &redis.XReadGroupArgs{
+		Group:    "test-group",
+		Consumer: "test-consumer",
+		Streams:  []string{"test-stream", ">"},
+		Count:    1,
+		Block:    time.Second,
+	}
+
+	t.Run("claimed messages are delivered before new messages", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+		client, mock, cleanup := setupTest(t)
+		defer cleanup()
+
+		testMsg := TestMessage{ID: "1", Data: "test"}
+		msgData, err := DefaultParseToMessage(testMsg)
+		require.NoError(t, err)
+
+		mock.ExpectXAutoClaim(claimArgs).SetVal([]redis.XMessage{{ID: "1234-0", Values: msgData}}, "0-0")
+		mock.ExpectXAck("test-stream", "test-group", "1234-0").SetVal(1)
+		mock.ExpectXReadGroup(readArgs).SetErr(redis.Nil)
+
+		consumer, err := NewGroupConsumer[TestMessage](
+			client,
+			"test-stream",
+			"test-group",
+			"test-consumer",
+			WithGroupConsumerClaimMinIdle[TestMessage](time.Minute),
+			WithGroupConsumerRetryDelay[TestMessage](10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Start())
+
+		select {
+		case msg := <-consumer.Subscribe():
+			assert.Equal(t, testMsg, msg.Data)
+			assert.NoError(t, msg.Done(context.Background()))
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for claimed message")
+		}
+		// 等待下一次讀取新消息
+		time.Sleep(100 * time.Millisecond)
+
+		assert.NoError(t, consumer.Close())
+	})
+
+	t.Run("deleted messages are acked", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+		client, mock, cleanup := setupTest(t)
+		defer cleanup()
+
+		mock.ExpectXAutoClaim(claimArgs).SetVal([]redis.XMessage{{ID: "1234-0"}}, "0-0")
+		mock.ExpectXAck("test-stream", "test-group", "1234-0").SetVal(1)
+		mock.ExpectXReadGroup(readArgs).SetErr(redis.Nil)
+
+		consumer, err := NewGroupConsumer[TestMessage](
+			client,
+			"test-stream",
+			"test-group",
+			"test-consumer",
+			WithGroupConsumerClaimMinIdle[TestMessage](time.Minute),
+			WithGroupConsumerRetryDelay[TestMessage](10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Start())
+		time.Sleep(100 * time.Millisecond)
+
+		select {
+		case msg := <-consumer.Subscribe():
+			t.Fatalf("unexpected message %v", msg)
+		default:
+		}
+		assert.NoError(t, consumer.Close())
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+		client, mock, cleanup := setupTest(t)
+		defer cleanup()
+
+		mock.ExpectXAutoClaim(claimArgs).SetErr(errors.New("connection refused"))
+		// 讀取失敗後重新認領
+		mock.ExpectXAutoClaim(claimArgs).SetVal([]redis.XMessage{}, "0-0")
+		mock.ExpectXReadGroup(readArgs).SetErr(redis.Nil)
+
+		consumer, err := NewGroupConsumer[TestMessage](
+			client,
+			"test-stream",
+			"test-group",
+			"test-consumer",
+			WithGroupConsumerClaimMinIdle[TestMessage](time.Minute),
+			WithGroupConsumerRetryDelay[TestMessage](10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Start())
+		time.Sleep(100 * time.Millisecond)
+
+		assert.NoError(t, consumer.Close())
+	})
+
+	t.Run("strict ordering mode does not claim", func(t *testing.T) {
+		consumer, err := NewGroupConsumer[TestMessage](
+			redis.NewClient(&redis.Options{}),
+			"test-stream",
+			"test-group",
+			"test-consumer",
+			WithGroupConsumerStrictOrdering[TestMessage](true),
+			WithGroupConsumerClaimMinIdle[TestMessage](time.Minute),
+			WithGroupConsumerRetryDelay[TestMessage](10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		assert.False(t, consumer.(*GroupConsumer[TestMessage]).claimEnabled())
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, opt := range []GroupConsumerOption[TestMessage]{
+			WithGroupConsumerClaimMinIdle[TestMessage](-time.Second),
+			WithGroupConsumerClaimInterval[TestMessage](0),
+			WithGroupConsumerClaimCount[TestMessage](0),
+			WithGroupConsumerMaxDeliveries[TestMessage](-1),
+		} {
+			_, err := NewGroupConsumer[TestMessage](redis.NewClient(&redis.Options{}), "test-stream", "test-group", "test-consumer", opt)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestGroupConsumer_ClaimMaxDeliveries(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	now := time.Now()
+	mr.SetTime(now)
+	require.NoError(t, client.XGroupCreateMkStream(ctx, "test-stream", "test-group", "0").Err())
+	for _, msg := range []TestMessage{{ID: "1"}, {ID: "2"}} {
+		values, err := DefaultParseToMessage(msg)
+		require.NoError(t, err)
+		require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: "test-stream", Values: values}).Err())
+	}
+	// 模擬意外下線的consumer讀取了消息但沒有確認，第一筆消息已經被重新投遞過
+	require.NoError(t, client.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "test-group", Consumer: "crashed", Streams: []string{"test-stream", ">"}}).Err())
+	pending, err := client.XPendingExt(ctx, &redis.XPendingExtArgs{Stream: "test-stream", Group: "test-group", Start: "-", End: "+", Count: 10}).Result()
+	require.NoError(t, err)
+	require.NoError(t, client.XClaim(ctx, &redis.XClaimArgs{Stream: "test-stream", Group: "test-group", Consumer: "crashed", Messages: []string{pending[0].ID}}).Err())
+	mr.SetTime(now.Add(2 * time.Minute))
+
+	consumer, err := NewGroupConsumer[TestMessage](
+		client,
+		"test-stream",
+		"test-group",
+		"test-consumer",
+		WithGroupConsumerClaimMinIdle[TestMessage](time.Minute),
+		WithGroupConsumerMaxDeliveries[TestMessage](2),
+	)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Start())
+	defer consumer.Close()
+
+	// 第二筆消息只投遞過一次，認領後交給下游
+	select {
+	case msg := <-consumer.Subscribe():
+		assert.Equal(t, "2", msg.Data.ID)
+		assert.NoError(t, msg.Done(ctx))
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for claimed message")
+	}
+
+	// 第一筆消息超過投遞次數上限，移動到dead-letter
+	messages, err := client.XRange(ctx, DeadLetterStream("test-stream"), "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "exceeded max deliveries (2)", messages[0].Values[DeadLetterErrorField])
+	data, err := DefaultParseFromMessage[TestMessage](messages[0].Values)
+	require.NoError(t, err)
+	assert.Equal(t, "1", data.ID)
+
+	count, err := client.XPending(ctx, "test-stream", "test-group").Result()
+	require.NoError(t, err)
+	assert.Zero(t, count.Count)
+}
+
+func TestGroupConsumer_ReleaseWaitsForInflight(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+	values, err := DefaultParseToMessage(TestMessage{ID: "1"})
+	require.NoError(t, err)
+	require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: "test-stream", Values: values}).Err())
+
+	consumer, err := NewGroupConsumer[TestMessage](
+		client,
+		"test-stream",
+		"test-group",
+		"test-consumer",
+		WithGroupConsumerCreateGroup[TestMessage]("0"),
+		WithGroupConsumerStrictOrdering[TestMessage](true),
+		WithGroupConsumerMutex[TestMessage](NewAutoRenewMutex(client, "test-lock")),
+		WithGroupConsumerBlockTimeout[TestMessage](10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Start())
+
+	var msg *Message[TestMessage]
+	select {
+	case msg = <-consumer.Subscribe():
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		assert.NoError(t, consumer.Close())
+	}()
+
+	// 已經交付的消息還沒有確認，關閉時繼續持有鎖
+	select {
+	case <-closed:
+		t.Fatal("lock released before the delivered message was acknowledged")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.True(t, mr.Exists("test-lock"))
+
+	// 確認之後才釋放鎖
+	require.NoError(t, msg.Done(ctx))
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for group consumer to close")
+	}
+	assert.False(t, mr.Exists("test-lock"))
+}
+
+func TestBatchGroupConsumer(t *testing.T) {
+	t.Run("pending and new messages are delivered in batches", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+		client, mock, cleanup := setupTest(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMutex := NewMockIAutoRenewMutex(ctrl)
+		mockMutex.EXPECT().Lock(gomock.Any()).DoAndReturn(func(ctx context.Context) (context.Context, error) {
+			return ctx, nil
+		})
+		mockMutex.EXPECT().Lock(gomock.Any()).Return(nil, context.Canceled).AnyTimes()
+		mockMutex.EXPECT().Unlock().Return(true, nil).AnyTimes()
+
+		values := make([]map[string]any, 4)
+		for i := range values {
+			var err error
+			values[i], err = DefaultParseToMessage(TestMessage{ID: fmt.Sprint(i + 1)})
+			require.NoError(t, err)
+		}
+
+		mock.ExpectXPendingExt(&redis.XPendingExtArgs{
+			Stream: "test-stream",
+			Group:  "test-group",
+			Start:  "-",
+			End:    "+",
+			Count:  100,
+		}).SetVal([]redis.XPendingExt{{ID: "1-0"}, {ID: "2-0"}, {ID: "3-0"}})
+		mock.ExpectXRangeN("test-stream", "1-0", "1-0", 1).SetVal([]redis.XMessage{{ID: "1-0", Values: values[0]}})
+		// 已經被刪除的pending消息直接確認
+		mock.ExpectXRangeN("test-stream", "2-0", "2-0", 1).SetVal([]redis.XMessage{})
+		mock.ExpectXAck("test-stream", "test-group", "2-0").SetVal(1)
+		mock.ExpectXRangeN("test-stream", "3-0", "3-0", 1).SetVal([]redis.XMessage{{ID: "3-0", Values: values[2]}})
+		mock.ExpectXAck("test-stream", "test-group", "1-0", "3-0").SetVal(2)
+		mock.ExpectXReadGroup(&redis.XReadGroupArgs{
+			Group:    "test-group",
+			Consumer: "test-consumer",
+			Streams:  []string{"test-stream", ">"},
+			Count:    2,
+			Block:    time.Second,
+		}).SetVal([]redis.XStream{{
+			Stream:   "test-stream",
+			Messages: []redis.XMessage{{ID: "4-0", Values: values[3]}},
+		}})
+		mock.ExpectXAck("test-stream", "test-group", "4-0").SetVal(1)
+		mock.ExpectXReadGroup(&redis.XReadGroupArgs{
+			Group:    "test-group",
+			Consumer: "test-consumer",
+			Streams:  []string{"test-stream", ">"},
+			Count:    2,
+			Block:    time.Second,
+		}).SetErr(context.Canceled)
+
+		consumer, err := NewBatchGroupConsumer[TestMessage](
+			client,
+			"test-stream",
+			"test-group",
+			"test-consumer",
+			WithGroupConsumerStrictOrdering[TestMessage](true),
+			WithGroupConsumerMutex[TestMessage](mockMutex),
+			WithGroupConsumerBatchSize[TestMessage](2),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Start())
+
+		for _, want := range [][]string{{"1", "3"}, {"4"}} {
+			select {
+			case batch := <-consumer.SubscribeBatch():
+				var got []string
+				for _, msg := range batch {
+					got = append(got, msg.Data.ID)
+				}
+				assert.Equal(t, want, got)
+				assert.NoError(t, DoneMessages(context.Background(), batch...))
+			case <-time.After(time.Second):
+				t.Fatal("timeout waiting for batch")
+			}
+		}
+
+		assert.NoError(t, consumer.Close())
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		_, err := NewBatchGroupConsumer[TestMessage](redis.NewClient(&redis.Options{}), "test-stream", "test-group", "test-consumer", WithGroupConsumerBatchSize[TestMessage](0))
+		assert.Error(t, err)
+	})
+}
+
+func TestDoneMessages(t *testing.T) {
+	client, mock, cleanup := setupTest(t)
+	defer cleanup()
+
+	newMessage := func(stream, id string) *Message[TestMessage] {
+		return &Message[TestMessage]{messageID: id, stream: stream, group: "test-group", client: client}
+	}
+	done := newMessage("stream-a", "0-1")
+	done.done = true
+	messages := []*Message[TestMessage]{newMessage("stream-a", "1-0"), newMessage("stream-b", "2-0"), done, newMessage("stream-a", "3-0")}
+
+	mock.ExpectXAck("stream-a", "test-group", "1-0", "3-0").SetVal(2)
+	mock.ExpectXAck("stream-b", "test-group", "2-0").SetErr(errors.New("ack error"))
+
+	err := DoneMessages(context.Background(), messages...)
+	assert.ErrorContains(t, err, "ack error")
+	assert.True(t, messages[0].done)
+	assert.False(t, messages[1].done)
+	assert.True(t, messages[3].done)
+}
+
+// benchmarkGroupConsumer 比較逐筆讀取和批次讀取處理 n 筆消息的耗時
+func benchmarkGroupConsumer(b *testing.B, batchSize int) {
+	mr := miniredis.RunT(b)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+	const n = 1000
+
+	values, err := DefaultParseToMessage(TestMessage{ID: "1", Data: "benchmark"})
+	require.NoError(b, err)
+	b.ResetTimer()
+	for range b.N {
+		b.StopTimer()
+		mr.FlushAll()
+		for range n {
+			require.NoError(b, client.XAdd(ctx, &redis.XAddArgs{Stream: "test-stream", Values: values}).Err())
+		}
+		opts := []GroupConsumerOption[TestMessage]{
+			WithGroupConsumerCreateGroup[TestMessage]("0"),
+			WithGroupConsumerBatchSize[TestMessage](batchSize),
+			WithGroupConsumerBlockTimeout[TestMessage](10 * time.Millisecond),
+			WithGroupConsumerLogger[TestMessage](slog.New(slog.NewTextHandler(io.Discard, nil))),
+		}
+		b.StartTimer()
+
+		received := 0
+		if batchSize == 1 {
+			consumer, err := NewGroupConsumer[TestMessage](client, "test-stream", "test-group", "test-consumer", opts...)
+			require.NoError(b, err)
+			require.NoError(b, consumer.Start())
+			for received < n {
+				msg := <-consumer.Subscribe()
+				require.NoError(b, msg.Done(ctx))
+				received++
+			}
+			consumer.Close()
+		} else {
+			consumer, err := NewBatchGroupConsumer[TestMessage](client, "test-stream", "test-group", "test-consumer", opts...)
+			require.NoError(b, err)
+			require.NoError(b, consumer.Start())
+			for received < n {
+				batch := <-consumer.SubscribeBatch()
+				require.NoError(b, DoneMessages(ctx, batch...))
+				received += len(batch)
+			}
+			consumer.Close()
+		}
+	}
+}
+
+func BenchmarkGroupConsumer_Single(b *testing.B) {
+	benchmarkGroupConsumer(b, 1)
+}
+
+func BenchmarkGroupConsumer_Batch(b *testing.B) {
+	benchmarkGroupConsumer(b, 100)
+}
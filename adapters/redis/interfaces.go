@@ -10,6 +10,8 @@ import (
 type IProducer[T any] interface {
 	Start()
 	Publish(data T) error
+	PublishSync(ctx context.Context, data T) (string, error)
+	Flush(ctx context.Context) error
 	Close()
 }
 
@@ -20,10 +22,18 @@ type IGroupConsumer[T any] interface {
 	Close() error
 }
 
+// IBatchGroupConsumer 定義了以批次交付消息的 GroupConsumer 的操作介面
+type IBatchGroupConsumer[T any] interface {
+	Start() error
+	SubscribeBatch() <-chan []*Message[T]
+	Close() error
+}
+
 // IConsumer 定義了 Consumer 的操作介面
 type IConsumer[T any] interface {
 	Start()
 	Subscribe() <-chan T
+	Replay(ctx context.Context, start, end string, fn func(id string, data T) error) error
 	Close()
 }
 
@@ -33,3 +43,17 @@ type IAutoRenewMutex interface {
 	Unlock() (bool, error)
 	Valid() bool
 }
+
+// IRateLimiter 定義了 RateLimiter 的操作介面
+type IRateLimiter interface {
+	Allow(ctx context.Context, key string) (RateLimitResult, error)
+	Refund(ctx context.Context, key, token string) error
+}
+
+// IDeadLetterQueue 定義了 DeadLetterQueue 的操作介面
+type IDeadLetterQueue[T any] interface {
+	List(ctx context.Context, lastID string, count int64) ([]DeadLetterEntry[T], error)
+	Get(ctx context.Context, ids ...string) ([]DeadLetterEntry[T], error)
+	Replay(ctx context.Context, ids ...string) ([]string, error)
+	Discard(ctx context.Context, ids ...string) ([]string, error)
+}
@@ -0,0 +1,245 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// mergedConsumer 將多個 Consumer 的訊息合併到同一個 channel
+type mergedConsumer[T any] struct {
+	consumers  []IConsumer[T]
+	downStream chan T
+	cancelFunc context.CancelFunc
+	wg         sync.WaitGroup
+	mu         sync.Mutex // 保護 Start 和 Close，Close 可能同時被多個 goroutine 呼叫
+	closed     bool
+}
+
+// MergeConsumers 將多個 Consumer 合併成一個，用於同時讀取多個分區的 stream
+// NOTE: 同一個 Consumer 的訊息會保持順序，不同 Consumer 之間的訊息順序不保證
+func MergeConsumers[T any](consumers ...IConsumer[T]) (IConsumer[T], error) {
+	if len(consumers) == 0 {
+		return nil, errors.New("consumers cannot be empty")
+	}
+	if len(consumers) == 1 {
+		return consumers[0], nil
+	}
+	return &mergedConsumer[T]{
+		consumers: consumers,
+		closed:    true,
+	}, nil
+}
+
+func (m *mergedConsumer[T]) Start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.closed {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelFunc = cancel
+	m.closed = false
+	m.downStream = make(chan T)
+	for _, consumer := range m.consumers {
+		consumer.Start()
+	}
+	forward(ctx, &m.wg, m.downStream, m.consumers, func(c IConsumer[T]) <-chan T { return c.Subscribe() })
+}
+
+func (m *mergedConsumer[T]) Subscribe() <-chan T {
+	return m.downStream
+}
+
+// Replay 依序重播每個 Consumer 在範圍內的消息
+// NOTE: 每個 Consumer 的消息會保持順序，不同 Consumer 之間不會依照ID交錯排序
+func (m *mergedConsumer[T]) Replay(ctx context.Context, start, end string, fn func(id string, data T) error) error {
+	for _, consumer := range m.consumers {
+		if err := consumer.Replay(ctx, start, end, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mergedConsumer[T]) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return
+	}
+	m.closed = true
+	m.cancelFunc()
+	for _, consumer := range m.consumers {
+		consumer.Close()
+	}
+	m.wg.Wait()
+}
+
+// mergedGroupConsumer 將多個 GroupConsumer 的訊息合併到同一個 channel
+type mergedGroupConsumer[T any] struct {
+	consumers  []IGroupConsumer[T]
+	downStream chan *Message[T]
+	cancelFunc context.CancelFunc
+	wg         sync.WaitGroup
+	mu         sync.Mutex // 保護 Start 和 Close，Close 可能同時被多個 goroutine 呼叫
+	closed     bool
+}
+
+// MergeGroupConsumers 將多個 GroupConsumer 合併成一個，用於同時讀取多個分區的 stream
+// NOTE: 同一個 GroupConsumer 的訊息會保持順序，嚴格順序模式下每個分區各自持有自己的鎖
+func MergeGroupConsumers[T any](consumers ...IGroupConsumer[T]) (IGroupConsumer[T], error) {
+	if len(consumers) == 0 {
+		return nil, errors.New("consumers cannot be empty")
+	}
+	if len(consumers) == 1 {
+		return consumers[0], nil
+	}
+	return &mergedGroupConsumer[T]{
+		consumers: consumers,
+		closed:    true,
+	}, nil
+}
+
+func (m *mergedGroupConsumer[T]) Start() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.closed {
+		return nil
+	}
+	for i, consumer := range m.consumers {
+		if err := consumer.Start(); err != nil {
+			// 關閉已經啟動的 GroupConsumer
+			for _, started := range m.consumers[:i] {
+				started.Close()
+			}
+			return err
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelFunc = cancel
+	m.closed = false
+	m.downStream = make(chan *Message[T])
+	forward(ctx, &m.wg, m.downStream, m.consumers, func(c IGroupConsumer[T]) <-chan *Message[T] { return c.Subscribe() })
+	return nil
+}
+
+func (m *mergedGroupConsumer[T]) Subscribe() <-chan *Message[T] {
+	return m.downStream
+}
+
+func (m *mergedGroupConsumer[T]) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+	m.cancelFunc()
+	var errs []error
+	for _, consumer := range m.consumers {
+		errs = append(errs, consumer.Close())
+	}
+	m.wg.Wait()
+	return errors.Join(errs...)
+}
+
+type mergedBatchGroupConsumer[T any] struct {
+	consumers  []IBatchGroupConsumer[T]
+	downStream chan []*Message[T]
+	cancelFunc context.CancelFunc
+	wg         sync.WaitGroup
+	mu         sync.Mutex // 保護 Start 和 Close，Close 可能同時被多個 goroutine 呼叫
+	closed     bool
+}
+
+// MergeBatchGroupConsumers 將多個批次模式的 GroupConsumer 合併成一個，用於同時讀取多個分區的 stream
+// NOTE: 每一批只會包含同一個分區的訊息，同一個 GroupConsumer 的批次會保持順序
+func MergeBatchGroupConsumers[T any](consumers ...IBatchGroupConsumer[T]) (IBatchGroupConsumer[T], error) {
+	if len(consumers) == 0 {
+		return nil, errors.New("consumers cannot be empty")
+	}
+	if len(consumers) == 1 {
+		return consumers[0], nil
+	}
+	return &mergedBatchGroupConsumer[T]{
+		consumers: consumers,
+		closed:    true,
+	}, nil
+}
+
+func (m *mergedBatchGroupConsumer[T]) Start() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.closed {
+		return nil
+	}
+	for i, consumer := range m.consumers {
+		if err := consumer.Start(); err != nil {
+			// 關閉已經啟動的 GroupConsumer
+			for _, started := range m.consumers[:i] {
+				started.Close()
+			}
+			return err
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelFunc = cancel
+	m.closed = false
+	m.downStream = make(chan []*Message[T])
+	forward(ctx, &m.wg, m.downStream, m.consumers, func(c IBatchGroupConsumer[T]) <-chan []*Message[T] { return c.SubscribeBatch() })
+	return nil
+}
+
+func (m *mergedBatchGroupConsumer[T]) SubscribeBatch() <-chan []*Message[T] {
+	return m.downStream
+}
+
+func (m *mergedBatchGroupConsumer[T]) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+	m.cancelFunc()
+	var errs []error
+	for _, consumer := range m.consumers {
+		errs = append(errs, consumer.Close())
+	}
+	m.wg.Wait()
+	return errors.Join(errs...)
+}
+
+// forward 將每個來源 channel 的訊息轉送到 downStream，所有來源都關閉或 context 取消後關閉 downStream
+func forward[C any, V any](ctx context.Context, wg *sync.WaitGroup, downStream chan V, sources []C, subscribe func(C) <-chan V) {
+	var forwarders sync.WaitGroup
+	for _, source := range sources {
+		ch := subscribe(source)
+		forwarders.Add(1)
+		go func() {
+			defer forwarders.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-ch:
+					if !ok {
+						return
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case downStream <- v:
+					}
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		forwarders.Wait()
+		close(downStream)
+	}()
+}
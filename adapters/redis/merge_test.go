@@ -0,0 +1,172 @@
+package redis
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMergeConsumers(t *testing.T) {
+	t.Run("沒有consumer", func(t *testing.T) {
+		_, err := MergeConsumers[int]()
+		assert.Error(t, err)
+	})
+
+	t.Run("只有一個consumer時直接返回", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		consumer := NewMockIConsumer[int](ctrl)
+		merged, err := MergeConsumers[int](consumer)
+		require.NoError(t, err)
+		assert.Same(t, consumer, merged)
+	})
+
+	t.Run("合併多個consumer的訊息", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ch1, ch2 := make(chan int, 2), make(chan int, 2)
+		c1, c2 := NewMockIConsumer[int](ctrl), NewMockIConsumer[int](ctrl)
+		c1.EXPECT().Start()
+		c2.EXPECT().Start()
+		c1.EXPECT().Subscribe().Return((<-chan int)(ch1))
+		c2.EXPECT().Subscribe().Return((<-chan int)(ch2))
+		c1.EXPECT().Close().Do(func() { close(ch1) })
+		c2.EXPECT().Close().Do(func() { close(ch2) })
+
+		merged, err := MergeConsumers[int](c1, c2)
+		require.NoError(t, err)
+		merged.Start()
+		ch1 <- 1
+		ch2 <- 2
+		ch1 <- 3
+
+		var got []int
+		for range 3 {
+			select {
+			case v := <-merged.Subscribe():
+				got = append(got, v)
+			case <-time.After(time.Second):
+				t.Fatal("timeout waiting for message")
+			}
+		}
+		sort.Ints(got)
+		assert.Equal(t, []int{1, 2, 3}, got)
+
+		merged.Close()
+		_, ok := <-merged.Subscribe()
+		assert.False(t, ok, "merged channel should be closed")
+	})
+}
+
+func TestMergeGroupConsumers(t *testing.T) {
+	t.Run("啟動失敗時關閉已啟動的consumer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		c1, c2 := NewMockIGroupConsumer[int](ctrl), NewMockIGroupConsumer[int](ctrl)
+		c1.EXPECT().Start().Return(nil)
+		c1.EXPECT().Close().Return(nil)
+		c2.EXPECT().Start().Return(errors.New("start failed"))
+
+		merged, err := MergeGroupConsumers[int](c1, c2)
+		require.NoError(t, err)
+		assert.Error(t, merged.Start())
+	})
+
+	t.Run("合併多個group consumer的訊息並保持各自的順序", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ch1, ch2 := make(chan *Message[int], 2), make(chan *Message[int], 2)
+		c1, c2 := NewMockIGroupConsumer[int](ctrl), NewMockIGroupConsumer[int](ctrl)
+		c1.EXPECT().Start().Return(nil)
+		c2.EXPECT().Start().Return(nil)
+		c1.EXPECT().Subscribe().Return((<-chan *Message[int])(ch1))
+		c2.EXPECT().Subscribe().Return((<-chan *Message[int])(ch2))
+		c1.EXPECT().Close().DoAndReturn(func() error { close(ch1); return nil })
+		c2.EXPECT().Close().DoAndReturn(func() error { close(ch2); return errors.New("close failed") })
+
+		merged, err := MergeGroupConsumers[int](c1, c2)
+		require.NoError(t, err)
+		require.NoError(t, merged.Start())
+		ch1 <- &Message[int]{Data: 1}
+		ch1 <- &Message[int]{Data: 3}
+		ch2 <- &Message[int]{Data: 2}
+
+		var fromFirst []int
+		for range 3 {
+			select {
+			case msg := <-merged.Subscribe():
+				if msg.Data%2 == 1 {
+					fromFirst = append(fromFirst, msg.Data)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("timeout waiting for message")
+			}
+		}
+		assert.Equal(t, []int{1, 3}, fromFirst)
+
+		assert.Error(t, merged.Close())
+		_, ok := <-merged.Subscribe()
+		assert.False(t, ok, "merged channel should be closed")
+	})
+
+	t.Run("同時關閉時只關閉一次", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ch1, ch2 := make(chan *Message[int]), make(chan *Message[int])
+		c1, c2 := NewMockIGroupConsumer[int](ctrl), NewMockIGroupConsumer[int](ctrl)
+		c1.EXPECT().Start().Return(nil)
+		c2.EXPECT().Start().Return(nil)
+		c1.EXPECT().Subscribe().Return((<-chan *Message[int])(ch1))
+		c2.EXPECT().Subscribe().Return((<-chan *Message[int])(ch2))
+		c1.EXPECT().Close().DoAndReturn(func() error { close(ch1); return nil })
+		c2.EXPECT().Close().DoAndReturn(func() error { close(ch2); return nil })
+
+		merged, err := MergeGroupConsumers[int](c1, c2)
+		require.NoError(t, err)
+		require.NoError(t, merged.Start())
+
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, merged.Close())
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestMergeBatchGroupConsumers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ch1, ch2 := make(chan []*Message[int], 1), make(chan []*Message[int], 1)
+	c1, c2 := NewMockIBatchGroupConsumer[int](ctrl), NewMockIBatchGroupConsumer[int](ctrl)
+	c1.EXPECT().Start().Return(nil)
+	c2.EXPECT().Start().Return(nil)
+	c1.EXPECT().SubscribeBatch().Return((<-chan []*Message[int])(ch1))
+	c2.EXPECT().SubscribeBatch().Return((<-chan []*Message[int])(ch2))
+	c1.EXPECT().Close().DoAndReturn(func() error { close(ch1); return nil })
+	c2.EXPECT().Close().DoAndReturn(func() error { close(ch2); return nil })
+
+	merged, err := MergeBatchGroupConsumers[int](c1, c2)
+	require.NoError(t, err)
+	require.NoError(t, merged.Start())
+	ch1 <- []*Message[int]{{Data: 1}, {Data: 3}}
+	ch2 <- []*Message[int]{{Data: 2}}
+
+	total := 0
+	for range 2 {
+		select {
+		case batch := <-merged.SubscribeBatch():
+			total += len(batch)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for batch")
+		}
+	}
+	assert.Equal(t, 3, total)
+
+	assert.NoError(t, merged.Close())
+	_, ok := <-merged.SubscribeBatch()
+	assert.False(t, ok, "merged channel should be closed")
+}
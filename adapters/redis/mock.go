@@ -52,6 +52,20 @@ func (mr *MockIProducerMockRecorder[T]) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIProducer[T])(nil).Close))
 }
 
+// Flush mocks base method.
+func (m *MockIProducer[T]) Flush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockIProducerMockRecorder[T]) Flush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockIProducer[T])(nil).Flush), ctx)
+}
+
 // Publish mocks base method.
 func (m *MockIProducer[T]) Publish(data T) error {
 	m.ctrl.T.Helper()
@@ -66,6 +80,21 @@ func (mr *MockIProducerMockRecorder[T]) Publish(data any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIProducer[T])(nil).Publish), data)
 }
 
+// PublishSync mocks base method.
+func (m *MockIProducer[T]) PublishSync(ctx context.Context, data T) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishSync", ctx, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishSync indicates an expected call of PublishSync.
+func (mr *MockIProducerMockRecorder[T]) PublishSync(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishSync", reflect.TypeOf((*MockIProducer[T])(nil).PublishSync), ctx, data)
+}
+
 // Start mocks base method.
 func (m *MockIProducer[T]) Start() {
 	m.ctrl.T.Helper()
@@ -144,6 +173,72 @@ func (mr *MockIGroupConsumerMockRecorder[T]) Subscribe() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockIGroupConsumer[T])(nil).Subscribe))
 }
 
+// MockIBatchGroupConsumer is a mock of IBatchGroupConsumer interface.
+type MockIBatchGroupConsumer[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBatchGroupConsumerMockRecorder[T]
+	isgomock struct{}
+}
+
+// MockIBatchGroupConsumerMockRecorder is the mock recorder for MockIBatchGroupConsumer.
+type MockIBatchGroupConsumerMockRecorder[T any] struct {
+	mock *MockIBatchGroupConsumer[T]
+}
+
+// NewMockIBatchGroupConsumer creates a new mock instance.
+func NewMockIBatchGroupConsumer[T any](ctrl *gomock.Controller) *MockIBatchGroupConsumer[T] {
+	mock := &MockIBatchGroupConsumer[T]{ctrl: ctrl}
+	mock.recorder = &MockIBatchGroupConsumerMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBatchGroupConsumer[T]) EXPECT() *MockIBatchGroupConsumerMockRecorder[T] {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockIBatchGroupConsumer[T]) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIBatchGroupConsumerMockRecorder[T]) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIBatchGroupConsumer[T])(nil).Close))
+}
+
+// Start mocks base method.
+func (m *MockIBatchGroupConsumer[T]) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIBatchGroupConsumerMockRecorder[T]) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIBatchGroupConsumer[T])(nil).Start))
+}
+
+// SubscribeBatch mocks base method.
+func (m *MockIBatchGroupConsumer[T]) SubscribeBatch() <-chan []*Message[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeBatch")
+	ret0, _ := ret[0].(<-chan []*Message[T])
+	return ret0
+}
+
+// SubscribeBatch indicates an expected call of SubscribeBatch.
+func (mr *MockIBatchGroupConsumerMockRecorder[T]) SubscribeBatch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeBatch", reflect.TypeOf((*MockIBatchGroupConsumer[T])(nil).SubscribeBatch))
+}
+
 // MockIConsumer is a mock of IConsumer interface.
 type MockIConsumer[T any] struct {
 	ctrl     *gomock.Controller
@@ -180,6 +275,20 @@ func (mr *MockIConsumerMockRecorder[T]) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIConsumer[T])(nil).Close))
 }
 
+// Replay mocks base method.
+func (m *MockIConsumer[T]) Replay(ctx context.Context, start, end string, fn func(string, T) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", ctx, start, end, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockIConsumerMockRecorder[T]) Replay(ctx, start, end, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockIConsumer[T])(nil).Replay), ctx, start, end, fn)
+}
+
 // Start mocks base method.
 func (m *MockIConsumer[T]) Start() {
 	m.ctrl.T.Helper()
@@ -273,3 +382,155 @@ func (mr *MockIAutoRenewMutexMockRecorder) Valid() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Valid", reflect.TypeOf((*MockIAutoRenewMutex)(nil).Valid))
 }
+
+// MockIRateLimiter is a mock of IRateLimiter interface.
+type MockIRateLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateLimiterMockRecorder
+	isgomock struct{}
+}
+
+// MockIRateLimiterMockRecorder is the mock recorder for MockIRateLimiter.
+type MockIRateLimiterMockRecorder struct {
+	mock *MockIRateLimiter
+}
+
+// NewMockIRateLimiter creates a new mock instance.
+func NewMockIRateLimiter(ctrl *gomock.Controller) *MockIRateLimiter {
+	mock := &MockIRateLimiter{ctrl: ctrl}
+	mock.recorder = &MockIRateLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateLimiter) EXPECT() *MockIRateLimiterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockIRateLimiter) Allow(ctx context.Context, key string) (RateLimitResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", ctx, key)
+	ret0, _ := ret[0].(RateLimitResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockIRateLimiterMockRecorder) Allow(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockIRateLimiter)(nil).Allow), ctx, key)
+}
+
+// Refund mocks base method.
+func (m *MockIRateLimiter) Refund(ctx context.Context, key, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, key, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockIRateLimiterMockRecorder) Refund(ctx, key, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockIRateLimiter)(nil).Refund), ctx, key, token)
+}
+
+// MockIDeadLetterQueue is a mock of IDeadLetterQueue interface.
+type MockIDeadLetterQueue[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDeadLetterQueueMockRecorder[T]
+	isgomock struct{}
+}
+
+// MockIDeadLetterQueueMockRecorder is the mock recorder for MockIDeadLetterQueue.
+type MockIDeadLetterQueueMockRecorder[T any] struct {
+	mock *MockIDeadLetterQueue[T]
+}
+
+// NewMockIDeadLetterQueue creates a new mock instance.
+func NewMockIDeadLetterQueue[T any](ctrl *gomock.Controller) *MockIDeadLetterQueue[T] {
+	mock := &MockIDeadLetterQueue[T]{ctrl: ctrl}
+	mock.recorder = &MockIDeadLetterQueueMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDeadLetterQueue[T]) EXPECT() *MockIDeadLetterQueueMockRecorder[T] {
+	return m.recorder
+}
+
+// Discard mocks base method.
+func (m *MockIDeadLetterQueue[T]) Discard(ctx context.Context, ids ...string) ([]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Discard", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Discard indicates an expected call of Discard.
+func (mr *MockIDeadLetterQueueMockRecorder[T]) Discard(ctx any, ids ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discard", reflect.TypeOf((*MockIDeadLetterQueue[T])(nil).Discard), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockIDeadLetterQueue[T]) Get(ctx context.Context, ids ...string) ([]DeadLetterEntry[T], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].([]DeadLetterEntry[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIDeadLetterQueueMockRecorder[T]) Get(ctx any, ids ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIDeadLetterQueue[T])(nil).Get), varargs...)
+}
+
+// List mocks base method.
+func (m *MockIDeadLetterQueue[T]) List(ctx context.Context, lastID string, count int64) ([]DeadLetterEntry[T], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, lastID, count)
+	ret0, _ := ret[0].([]DeadLetterEntry[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIDeadLetterQueueMockRecorder[T]) List(ctx, lastID, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIDeadLetterQueue[T])(nil).List), ctx, lastID, count)
+}
+
+// Replay mocks base method.
+func (m *MockIDeadLetterQueue[T]) Replay(ctx context.Context, ids ...string) ([]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Replay", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockIDeadLetterQueueMockRecorder[T]) Replay(ctx any, ids ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockIDeadLetterQueue[T])(nil).Replay), varargs...)
+}
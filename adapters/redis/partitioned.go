@@ -0,0 +1,331 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// PartitionedGroupConsumer 在多個實例之間分配分區 stream 的批次 GroupConsumer
+//   - 每個實例定期在 registry (sorted set) 中更新心跳，超過 instanceTTL 沒有心跳的實例視為下線
+//   - 以 rendezvous hashing 決定每個分區由哪個存活的實例處理，實例增減時只有受影響的分區會移動
+//   - 實例只為分配到的分區啟動嚴格順序模式的 GroupConsumer，同步的吞吐量會隨著實例數量增加
+//
+// NOTE: 每個分區仍然各自持有嚴格順序模式的鎖，實例之間的分配結果短暫不一致時，也不會有兩個實例同時處理同一個分區，
+// 但鎖因為 Redis 異常而過期時仍然可能短暫重疊，下游需要能夠處理重複的消息
+type PartitionedGroupConsumer[T any] struct {
+	client     redis.UniversalClient
+	group      string
+	consumer   string
+	streams    []string
+	consumers  map[string]IBatchGroupConsumer[T]
+	owned      map[string]chan struct{} // 目前負責的分區，值在轉送消息的 goroutine 結束後關閉
+	ownedMu    sync.RWMutex
+	downStream chan []*Message[T]
+	cancelFunc context.CancelFunc
+	wg         sync.WaitGroup
+	closed     bool
+	logger     *slog.Logger
+	options    partitionedGroupConsumerOptions[T]
+}
+
+type partitionedGroupConsumerOptions[T any] struct {
+	logger            *slog.Logger
+	registry          string
+	heartbeatInterval time.Duration
+	instanceTTL       time.Duration
+	consumerOptions   []GroupConsumerOption[T]
+}
+
+type PartitionedGroupConsumerOption[T any] func(*partitionedGroupConsumerOptions[T])
+
+// WithPartitionedGroupConsumerLogger 設置日誌
+func WithPartitionedGroupConsumerLogger[T any](logger *slog.Logger) PartitionedGroupConsumerOption[T] {
+	return func(o *partitionedGroupConsumerOptions[T]) {
+		o.logger = logger
+	}
+}
+
+// WithPartitionedGroupConsumerRegistry 設置記錄存活實例的 sorted set 鍵，預設為 registry:<group>
+func WithPartitionedGroupConsumerRegistry[T any](key string) PartitionedGroupConsumerOption[T] {
+	return func(o *partitionedGroupConsumerOptions[T]) {
+		o.registry = key
+	}
+}
+
+// WithPartitionedGroupConsumerHeartbeat 設置心跳和重新分配分區的間隔，以及實例超過多久沒有心跳視為下線，預設為5秒和15秒
+func WithPartitionedGroupConsumerHeartbeat[T any](interval, ttl time.Duration) PartitionedGroupConsumerOption[T] {
+	return func(o *partitionedGroupConsumerOptions[T]) {
+		o.heartbeatInterval = interval
+		o.instanceTTL = ttl
+	}
+}
+
+// WithPartitionedGroupConsumerOptions 設置每個分區的 GroupConsumer 的選項，嚴格順序模式會固定開啟
+func WithPartitionedGroupConsumerOptions[T any](opts ...GroupConsumerOption[T]) PartitionedGroupConsumerOption[T] {
+	return func(o *partitionedGroupConsumerOptions[T]) {
+		o.consumerOptions = append(o.consumerOptions, opts...)
+	}
+}
+
+// NewPartitionedGroupConsumer 建立在實例之間分配分區的批次 GroupConsumer，consumer 同時作為實例在 registry 中的名稱，每個實例需要不同
+func NewPartitionedGroupConsumer[T any](
+	client redis.UniversalClient,
+	streams []string,
+	group, consumer string,
+	opts ...PartitionedGroupConsumerOption[T],
+) (*PartitionedGroupConsumer[T], error) {
+	if isNilClient(client) {
+		return nil, errors.New("redis client cannot be nil")
+	}
+	if len(streams) == 0 {
+		return nil, errors.New("streams cannot be empty")
+	}
+	if group == "" || consumer == "" {
+		return nil, errors.New("group and consumer cannot be empty")
+	}
+
+	// 默認選項
+	options := partitionedGroupConsumerOptions[T]{
+		logger:            slog.Default(),
+		registry:          fmt.Sprintf("registry:%s", group),
+		heartbeatInterval: 5 * time.Second,
+		instanceTTL:       15 * time.Second,
+	}
+
+	// 應用自定義選項
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.registry == "" || options.heartbeatInterval <= 0 || options.instanceTTL <= options.heartbeatInterval {
+		return nil, errors.New("invalid heartbeat options")
+	}
+
+	consumerOptions := append(slices.Clone(options.consumerOptions), WithGroupConsumerStrictOrdering[T](true))
+	consumers := make(map[string]IBatchGroupConsumer[T], len(streams))
+	for _, stream := range streams {
+		if _, ok := consumers[stream]; ok {
+			return nil, fmt.Errorf("duplicate stream %s", stream)
+		}
+		c, err := NewBatchGroupConsumer[T](client, stream, group, consumer, consumerOptions...)
+		if err != nil {
+			return nil, err
+		}
+		consumers[stream] = c
+	}
+
+	return &PartitionedGroupConsumer[T]{
+		client:    client,
+		group:     group,
+		consumer:  consumer,
+		streams:   streams,
+		consumers: consumers,
+		closed:    true,
+		logger:    options.logger.With(slog.String("caller", "PartitionedGroupConsumer"), slog.String("group", group), slog.String("consumer", consumer)),
+		options:   options,
+	}, nil
+}
+
+func (s *PartitionedGroupConsumer[T]) Start() error {
+	if !s.closed {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancelFunc = cancel
+	s.closed = false
+	s.downStream = make(chan []*Message[T])
+	s.owned = map[string]chan struct{}{}
+	s.logger.Info("starting partitioned group consumer", slog.Int("partitions", len(s.streams)))
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(s.downStream)
+		defer s.leave()
+
+		ticker := time.NewTicker(s.options.heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			if err := s.rebalance(ctx); err != nil && ctx.Err() == nil {
+				// 無法更新心跳時維持目前的分配，其他實例會在 instanceTTL 後接手，屆時由分區的鎖保證不會重複處理
+				s.logger.Error("failed to rebalance partitions", slog.Any("error", err))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// SubscribeBatch 訂閱所有負責的分區，返回批次的Message通道，每一批只會包含同一個分區的消息
+func (s *PartitionedGroupConsumer[T]) SubscribeBatch() <-chan []*Message[T] {
+	return s.downStream
+}
+
+// Partitions 返回目前由這個實例負責的分區 stream
+func (s *PartitionedGroupConsumer[T]) Partitions() []string {
+	s.ownedMu.RLock()
+	defer s.ownedMu.RUnlock()
+	partitions := make([]string, 0, len(s.owned))
+	for _, stream := range s.streams {
+		if _, ok := s.owned[stream]; ok {
+			partitions = append(partitions, stream)
+		}
+	}
+	return partitions
+}
+
+func (s *PartitionedGroupConsumer[T]) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.logger.Info("closing partitioned group consumer")
+	s.closed = true
+	s.cancelFunc()
+	s.wg.Wait()
+	s.logger.Info("partitioned group consumer closed gracefully")
+	return nil
+}
+
+// rebalance 更新心跳並依照存活的實例重新分配分區，啟動新分配到的分區並停止不再負責的分區
+func (s *PartitionedGroupConsumer[T]) rebalance(ctx context.Context) error {
+	instances, err := s.heartbeat(ctx)
+	if err != nil {
+		return err
+	}
+	for _, stream := range s.streams {
+		owner := rendezvousOwner(instances, stream)
+		s.ownedMu.RLock()
+		_, owned := s.owned[stream]
+		s.ownedMu.RUnlock()
+		switch {
+		case owner == s.consumer && !owned:
+			if err := s.acquire(ctx, stream); err != nil {
+				s.logger.Error("failed to start partition", slog.String("stream", stream), slog.Any("error", err))
+				continue
+			}
+			s.logger.Info("partition assigned", slog.String("stream", stream), slog.Int("instances", len(instances)))
+		case owner != s.consumer && owned:
+			s.release(stream)
+			s.logger.Info("partition revoked", slog.String("stream", stream), slog.String("owner", owner))
+		}
+	}
+	return nil
+}
+
+// heartbeat 以 Redis 的時間更新自己的心跳並移除過期的實例，返回所有存活的實例
+// NOTE: 使用 Redis 的時間避免實例之間的時鐘誤差影響存活的判斷
+func (s *PartitionedGroupConsumer[T]) heartbeat(ctx context.Context) ([]string, error) {
+	now, err := s.client.Time(ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting redis time: %w", err)
+	}
+	var members *redis.StringSliceCmd
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, s.options.registry, redis.Z{Score: float64(now.UnixMilli()), Member: s.consumer})
+		pipe.ZRemRangeByScore(ctx, s.options.registry, "-inf", "("+strconv.FormatInt(now.Add(-s.options.instanceTTL).UnixMilli(), 10))
+		members = pipe.ZRange(ctx, s.options.registry, 0, -1)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating heartbeat: %w", err)
+	}
+	return members.Val(), nil
+}
+
+// acquire 啟動分區的 GroupConsumer，並將消息轉送到 downStream
+func (s *PartitionedGroupConsumer[T]) acquire(ctx context.Context, stream string) error {
+	consumer := s.consumers[stream]
+	if err := consumer.Start(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	s.ownedMu.Lock()
+	s.owned[stream] = done
+	s.ownedMu.Unlock()
+
+	ch := consumer.SubscribeBatch()
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case batch, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					// 沒有交付的消息留在 pending 中，不需要等待確認
+					settleMessages(batch)
+					return
+				case s.downStream <- batch:
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// release 停止分區的 GroupConsumer，GroupConsumer 關閉時會釋放分區的鎖，讓新的負責實例接手
+// NOTE: GroupConsumer 會等待已經交付的消息確認之後才釋放鎖，避免接手的實例同時處理同一批消息，
+// 超過 WithGroupConsumerReleaseTimeout 仍然沒有確認的消息會留在 pending 中，由接手的實例重新處理
+func (s *PartitionedGroupConsumer[T]) release(stream string) {
+	s.ownedMu.Lock()
+	done := s.owned[stream]
+	delete(s.owned, stream)
+	s.ownedMu.Unlock()
+
+	if err := s.consumers[stream].Close(); err != nil {
+		s.logger.Error("failed to close partition", slog.String("stream", stream), slog.Any("error", err))
+	}
+	<-done
+}
+
+// leave 停止所有分區並從 registry 移除自己，讓其他實例不需要等待 instanceTTL 就可以接手
+func (s *PartitionedGroupConsumer[T]) leave() {
+	for _, stream := range s.Partitions() {
+		s.release(stream)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.options.heartbeatInterval)
+	defer cancel()
+	if err := s.client.ZRem(ctx, s.options.registry, s.consumer).Err(); err != nil {
+		s.logger.Error("failed to leave registry", slog.Any("error", err))
+	}
+}
+
+// rendezvousOwner 以 rendezvous (highest random weight) hashing 從實例中選出分區的負責實例
+// 每個實例對分區的權重只和兩者的名稱有關，實例增減時只有原本由該實例負責或改由該實例負責的分區會移動
+func rendezvousOwner(instances []string, stream string) string {
+	var owner string
+	var maxWeight uint64
+	for _, instance := range instances {
+		h := fnv.New64a()
+		h.Write([]byte(instance))
+		h.Write([]byte{0})
+		h.Write([]byte(stream))
+		// fnv 對只有結尾不同的名稱分佈不夠均勻，再以 splitmix64 的 finalizer 打散
+		weight := h.Sum64()
+		weight ^= weight >> 30
+		weight *= 0xbf58476d1ce4e5b9
+		weight ^= weight >> 27
+		weight *= 0x94d049bb133111eb
+		weight ^= weight >> 31
+		if owner == "" || weight > maxWeight || (weight == maxWeight && instance < owner) {
+			owner, maxWeight = instance, weight
+		}
+	}
+	return owner
+}
@@ -0,0 +1,143 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRendezvousOwner(t *testing.T) {
+	streams := make([]string, 64)
+	for i := range streams {
+		streams[i] = fmt.Sprintf("test-stream:{%d}", i)
+	}
+	instances := []string{"a", "b", "c"}
+
+	counts := map[string]int{}
+	owners := map[string]string{}
+	for _, stream := range streams {
+		owner := rendezvousOwner(instances, stream)
+		assert.Equal(t, owner, rendezvousOwner([]string{"c", "b", "a"}, stream), "owner should not depend on instance order")
+		owners[stream] = owner
+		counts[owner]++
+	}
+	for _, instance := range instances {
+		assert.Greater(t, counts[instance], 10, "partitions should be spread among instances")
+	}
+
+	// 實例下線時只有它負責的分區會移動
+	for _, stream := range streams {
+		owner := rendezvousOwner([]string{"a", "b"}, stream)
+		if owners[stream] != "c" {
+			assert.Equal(t, owners[stream], owner)
+		}
+	}
+	assert.Empty(t, rendezvousOwner(nil, streams[0]))
+}
+
+func TestNewPartitionedGroupConsumer(t *testing.T) {
+	client := redis.NewClient(&redis.Options{})
+	defer client.Close()
+
+	_, err := NewPartitionedGroupConsumer[TestMessage](nil, []string{"test-stream"}, "test-group", "a")
+	assert.Error(t, err)
+	_, err = NewPartitionedGroupConsumer[TestMessage](client, nil, "test-group", "a")
+	assert.Error(t, err)
+	_, err = NewPartitionedGroupConsumer[TestMessage](client, []string{"test-stream", "test-stream"}, "test-group", "a")
+	assert.Error(t, err)
+	_, err = NewPartitionedGroupConsumer[TestMessage](client, []string{"test-stream"}, "test-group", "a",
+		WithPartitionedGroupConsumerHeartbeat[TestMessage](time.Second, time.Second))
+	assert.Error(t, err)
+	_, err = NewPartitionedGroupConsumer[TestMessage](client, []string{"test-stream"}, "test-group", "a",
+		WithPartitionedGroupConsumerOptions(WithGroupConsumerBatchSize[TestMessage](0)))
+	assert.Error(t, err)
+}
+
+func TestPartitionedGroupConsumer(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	streams := make([]string, 8)
+	for i := range streams {
+		streams[i] = fmt.Sprintf("test-stream:{%d}", i)
+	}
+	received := make(chan *Message[TestMessage], 100)
+	newConsumer := func(name string) *PartitionedGroupConsumer[TestMessage] {
+		consumer, err := NewPartitionedGroupConsumer[TestMessage](
+			client,
+			streams,
+			"test-group",
+			name,
+			WithPartitionedGroupConsumerHeartbeat[TestMessage](20*time.Millisecond, 200*time.Millisecond),
+			WithPartitionedGroupConsumerOptions(
+				WithGroupConsumerCreateGroup[TestMessage]("0"),
+				WithGroupConsumerBlockTimeout[TestMessage](10*time.Millisecond),
+			),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Start())
+		go func() {
+			for batch := range consumer.SubscribeBatch() {
+				for _, msg := range batch {
+					received <- msg
+				}
+			}
+		}()
+		return consumer
+	}
+	expectPartitions := func(consumer *PartitionedGroupConsumer[TestMessage], instances []string) {
+		var expected []string
+		for _, stream := range streams {
+			if rendezvousOwner(instances, stream) == consumer.consumer {
+				expected = append(expected, stream)
+			}
+		}
+		assert.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual(expected, consumer.Partitions())
+		}, 2*time.Second, 10*time.Millisecond, "consumer %s should own %v", consumer.consumer, expected)
+	}
+
+	a := newConsumer("a")
+	defer a.Close()
+	expectPartitions(a, []string{"a"})
+
+	// 新的實例加入後分區在兩個實例之間重新分配
+	b := newConsumer("b")
+	expectPartitions(a, []string{"a", "b"})
+	expectPartitions(b, []string{"a", "b"})
+	assert.NotEmpty(t, a.Partitions())
+	assert.NotEmpty(t, b.Partitions())
+
+	// 每個分區的消息只會由負責的實例處理
+	for i, stream := range streams {
+		values, err := DefaultParseToMessage(TestMessage{ID: fmt.Sprint(i)})
+		require.NoError(t, err)
+		require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: values}).Err())
+	}
+	seen := map[string]bool{}
+	for range streams {
+		select {
+		case msg := <-received:
+			assert.False(t, seen[msg.Data.ID])
+			seen[msg.Data.ID] = true
+			assert.NoError(t, msg.Done(ctx))
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for message")
+		}
+	}
+
+	// 實例關閉時從 registry 移除自己，其他實例立即接手所有分區
+	require.NoError(t, b.Close())
+	expectPartitions(a, []string{"a"})
+	members, err := client.ZRange(ctx, "registry:test-group", 0, -1).Result()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, members)
+}
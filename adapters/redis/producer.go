@@ -3,18 +3,37 @@ package redis
 import (
 	"context"
 	"errors"
+	"expvar"
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/smallnest/chanx"
 )
 
+// ErrProducerBufferFull 表示有界緩衝已滿，且溢出策略為 OverflowDrop
+var ErrProducerBufferFull = errors.New("producer buffer is full")
+
+// OverflowPolicy 有界緩衝已滿時 Publish 的處理方式
+type OverflowPolicy int
+
+const (
+	// OverflowBlock 等待緩衝有空間，或是 Producer 被關閉
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDrop 丟棄消息並返回 ErrProducerBufferFull
+	OverflowDrop
+)
+
 type producerOptions[T any] struct {
-	logger     *slog.Logger
-	bufferSize int
-	parseFunc  func(T) (map[string]any, error)
+	logger         *slog.Logger
+	bufferSize     int
+	maxBuffer      int
+	overflowPolicy OverflowPolicy
+	parseFunc      func(T) (map[string]any, error)
+	metrics        *expvar.Map
 }
 
 type ProducerOption[T any] func(*producerOptions[T])
@@ -33,6 +52,15 @@ func WithProducerBufferSize[T any](size int) ProducerOption[T] {
 	}
 }
 
+// WithProducerMaxBuffer 設置有界緩衝的大小和緩衝已滿時的處理方式，預設為0表示使用無界緩衝
+// NOTE: 無界緩衝在 Redis 無法連線時會持續佔用記憶體，需要限制記憶體用量時應該使用有界緩衝
+func WithProducerMaxBuffer[T any](size int, policy OverflowPolicy) ProducerOption[T] {
+	return func(o *producerOptions[T]) {
+		o.maxBuffer = size
+		o.overflowPolicy = policy
+	}
+}
+
 // WithProducerParseFunc 設置消息序列化函數
 func WithProducerParseFunc[T any](fn func(T) (map[string]any, error)) ProducerOption[T] {
 	return func(o *producerOptions[T]) {
@@ -40,19 +68,41 @@ func WithProducerParseFunc[T any](fn func(T) (map[string]any, error)) ProducerOp
 	}
 }
 
+// WithProducerSchema 以 Schema 編碼消息，寫入的消息會帶有類型和版本的 envelope 欄位
+func WithProducerSchema[T any](schema *Schema[T]) ProducerOption[T] {
+	return func(o *producerOptions[T]) {
+		o.parseFunc = schema.Encode
+	}
+}
+
+// WithProducerMetrics 設置輸出指標的 expvar.Map，包含 queued(尚未寫入的消息數量)、published、failed 和 dropped
+func WithProducerMetrics[T any](m *expvar.Map) ProducerOption[T] {
+	return func(o *producerOptions[T]) {
+		o.metrics = m
+	}
+}
+
 type Producer[T any] struct {
-	client     *redis.Client
+	client     redis.UniversalClient
 	stream     string
 	upstream   *chanx.UnboundedChan[map[string]any]
+	bounded    chan map[string]any
+	ctx        context.Context
 	cancelFunc context.CancelFunc
 	wg         sync.WaitGroup
 	closed     bool
 	logger     *slog.Logger
 	options    producerOptions[T]
+
+	// 已經放入緩衝但還沒有完成寫入的消息數量，用於 Flush 和 queued 指標
+	queued    atomic.Int64
+	published expvar.Int
+	failed    expvar.Int
+	dropped   expvar.Int
 }
 
-func NewProducer[T any](client *redis.Client, stream string, opts ...ProducerOption[T]) (IProducer[T], error) {
-	if client == nil {
+func NewProducer[T any](client redis.UniversalClient, stream string, opts ...ProducerOption[T]) (IProducer[T], error) {
+	if isNilClient(client) {
 		return nil, errors.New("redis client cannot be nil")
 	}
 	if stream == "" {
@@ -70,6 +120,12 @@ func NewProducer[T any](client *redis.Client, stream string, opts ...ProducerOpt
 	for _, opt := range opts {
 		opt(&options)
 	}
+	if options.maxBuffer < 0 || (options.overflowPolicy != OverflowBlock && options.overflowPolicy != OverflowDrop) {
+		return nil, errors.New("invalid max buffer options")
+	}
+	if options.metrics == nil {
+		options.metrics = new(expvar.Map)
+	}
 
 	producer := &Producer[T]{
 		client:  client,
@@ -78,6 +134,10 @@ func NewProducer[T any](client *redis.Client, stream string, opts ...ProducerOpt
 		logger:  options.logger.With(slog.String("caller", "Producer"), slog.String("stream", stream)),
 		options: options,
 	}
+	options.metrics.Set("queued", expvar.Func(func() any { return producer.queued.Load() }))
+	options.metrics.Set("published", &producer.published)
+	options.metrics.Set("failed", &producer.failed)
+	options.metrics.Set("dropped", &producer.dropped)
 
 	return producer, nil
 }
@@ -87,7 +147,15 @@ func (p *Producer[T]) Start() {
 		return
 	}
 	ctx, cancel := context.WithCancel(context.Background())
-	p.upstream = chanx.NewUnboundedChan[map[string]any](ctx, p.options.bufferSize)
+	var out <-chan map[string]any
+	if p.options.maxBuffer > 0 {
+		p.bounded = make(chan map[string]any, p.options.maxBuffer)
+		out = p.bounded
+	} else {
+		p.upstream = chanx.NewUnboundedChan[map[string]any](ctx, p.options.bufferSize)
+		out = p.upstream.Out
+	}
+	p.ctx = ctx
 	p.cancelFunc = cancel
 	p.closed = false
 	p.logger.Info("starting stream producer")
@@ -101,14 +169,12 @@ func (p *Producer[T]) Start() {
 			select {
 			case <-ctx.Done():
 				return
-			case message := <-p.upstream.Out:
-				id, err := p.client.XAdd(ctx, &redis.XAddArgs{
-					Stream: p.stream,
-					Values: message,
-				}).Result()
-
+			case message := <-out:
+				id, err := p.xadd(ctx, message)
+				p.queued.Add(-1)
 				if err != nil {
 					if errors.Is(err, context.Canceled) {
+						p.dropped.Add(1)
 						return
 					}
 					p.logger.Error("publish message error", slog.Any("error", err))
@@ -121,6 +187,8 @@ func (p *Producer[T]) Start() {
 	}()
 }
 
+// Publish 將消息放入緩衝後立即返回，由背景的 goroutine 寫入 stream，寫入失敗時只會記錄在日誌和 failed 指標中
+// 使用有界緩衝時，緩衝已滿會依照溢出策略等待或返回 ErrProducerBufferFull
 func (p *Producer[T]) Publish(data T) error {
 	if p.closed {
 		return ErrConsumerClosed
@@ -131,10 +199,66 @@ func (p *Producer[T]) Publish(data T) error {
 		return fmt.Errorf("parse message error: %w", err)
 	}
 
-	p.upstream.In <- message
+	p.queued.Add(1)
+	if p.bounded == nil {
+		p.upstream.In <- message
+		return nil
+	}
+	if p.options.overflowPolicy == OverflowDrop {
+		select {
+		case p.bounded <- message:
+			return nil
+		default:
+			p.queued.Add(-1)
+			p.dropped.Add(1)
+			return ErrProducerBufferFull
+		}
+	}
+	select {
+	case p.bounded <- message:
+		return nil
+	case <-p.ctx.Done():
+		p.queued.Add(-1)
+		return ErrConsumerClosed
+	}
+}
+
+// PublishSync 直接將消息寫入 stream 並返回消息ID，不經過緩衝
+// NOTE: 不會等待緩衝中的消息，和 Publish 混用時兩者之間的順序不保證
+func (p *Producer[T]) PublishSync(ctx context.Context, data T) (string, error) {
+	if p.closed {
+		return "", ErrConsumerClosed
+	}
+
+	message, err := p.options.parseFunc(data)
+	if err != nil {
+		return "", fmt.Errorf("parse message error: %w", err)
+	}
+	id, err := p.xadd(ctx, message)
+	if err != nil {
+		return "", fmt.Errorf("publish message error: %w", err)
+	}
+	return id, nil
+}
+
+// Flush 等待緩衝中的消息都完成寫入(成功或失敗)，用於關閉前確保消息不會被丟棄
+func (p *Producer[T]) Flush(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for p.queued.Load() > 0 {
+		if p.closed {
+			return ErrConsumerClosed
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
 	return nil
 }
 
+// Close 停止寫入，緩衝中還沒有寫入的消息會被丟棄，需要保留時先呼叫 Flush
 func (p *Producer[T]) Close() {
 	if p.closed {
 		return
@@ -143,5 +267,25 @@ func (p *Producer[T]) Close() {
 	p.closed = true
 	p.cancelFunc()
 	p.wg.Wait()
+	if queued := p.queued.Swap(0); queued > 0 {
+		p.dropped.Add(queued)
+		p.logger.Warn("discard unpublished messages", slog.Int64("count", queued))
+	}
 	p.logger.Info("stream producer closed")
 }
+
+// xadd 寫入 stream 並更新指標
+func (p *Producer[T]) xadd(ctx context.Context, message map[string]any) (string, error) {
+	id, err := p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		Values: message,
+	}).Result()
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			p.failed.Add(1)
+		}
+		return "", err
+	}
+	p.published.Add(1)
+	return id, nil
+}
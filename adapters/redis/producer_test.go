@@ -1,11 +1,14 @@
 package redis
 
 import (
+	"context"
+	"expvar"
 	"fmt"
 	"log/slog"
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -223,3 +226,137 @@ func TestProducer_Publish(t *testing.T) {
 		producer.Close()
 	})
 }
+
+func TestProducer_PublishSync(t *testing.T) {
+	t.Run("returns stream id", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+		client, mock, cleanup := setupTest(t)
+		defer cleanup()
+
+		msgValues, err := DefaultParseToMessage(TestMessage{ID: "1"})
+		require.NoError(t, err)
+		mock.ExpectXAdd(&redis.XAddArgs{
+			Stream: "test-stream",
+			Values: msgValues,
+		}).SetVal("1234-0")
+
+		metrics := new(expvar.Map)
+		producer, err := NewProducer[TestMessage](client, "test-stream", WithProducerMetrics[TestMessage](metrics))
+		require.NoError(t, err)
+		producer.Start()
+		defer producer.Close()
+
+		id, err := producer.PublishSync(context.Background(), TestMessage{ID: "1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "1234-0", id)
+		assert.Equal(t, "1", metrics.Get("published").String())
+	})
+
+	t.Run("returns redis error", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+		client, mock, cleanup := setupTest(t)
+		defer cleanup()
+
+		msgValues, err := DefaultParseToMessage(TestMessage{ID: "1"})
+		require.NoError(t, err)
+		mock.ExpectXAdd(&redis.XAddArgs{
+			Stream: "test-stream",
+			Values: msgValues,
+		}).SetErr(redis.ErrClosed)
+
+		metrics := new(expvar.Map)
+		producer, err := NewProducer[TestMessage](client, "test-stream", WithProducerMetrics[TestMessage](metrics))
+		require.NoError(t, err)
+		producer.Start()
+		defer producer.Close()
+
+		_, err = producer.PublishSync(context.Background(), TestMessage{ID: "1"})
+		assert.ErrorIs(t, err, redis.ErrClosed)
+		assert.Equal(t, "1", metrics.Get("failed").String())
+	})
+
+	t.Run("closed producer", func(t *testing.T) {
+		producer, err := NewProducer[TestMessage](redis.NewClient(&redis.Options{}), "test-stream")
+		require.NoError(t, err)
+		_, err = producer.PublishSync(context.Background(), TestMessage{ID: "1"})
+		assert.ErrorIs(t, err, ErrConsumerClosed)
+	})
+}
+
+func TestProducer_Flush(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	metrics := new(expvar.Map)
+	producer, err := NewProducer[TestMessage](
+		client,
+		"test-stream",
+		WithProducerMaxBuffer[TestMessage](5, OverflowBlock),
+		WithProducerMetrics[TestMessage](metrics),
+	)
+	require.NoError(t, err)
+	producer.Start()
+	for i := range 20 {
+		require.NoError(t, producer.Publish(TestMessage{ID: fmt.Sprint(i)}))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, producer.Flush(ctx))
+	producer.Close()
+
+	length, err := client.XLen(context.Background(), "test-stream").Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), length)
+	assert.Equal(t, "0", metrics.Get("queued").String())
+	assert.Equal(t, "20", metrics.Get("published").String())
+	assert.Equal(t, "0", metrics.Get("dropped").String())
+}
+
+func TestProducer_BoundedBuffer(t *testing.T) {
+	// 不啟動寫入的 goroutine，讓緩衝保持在已滿的狀態
+	newFullProducer := func(policy OverflowPolicy) (*Producer[TestMessage], *expvar.Map, context.CancelFunc) {
+		metrics := new(expvar.Map)
+		p, err := NewProducer[TestMessage](
+			redis.NewClient(&redis.Options{}),
+			"test-stream",
+			WithProducerMaxBuffer[TestMessage](1, policy),
+			WithProducerMetrics[TestMessage](metrics),
+		)
+		require.NoError(t, err)
+		producer := p.(*Producer[TestMessage])
+		ctx, cancel := context.WithCancel(context.Background())
+		producer.ctx, producer.cancelFunc = ctx, cancel
+		producer.bounded = make(chan map[string]any, 1)
+		producer.closed = false
+		require.NoError(t, producer.Publish(TestMessage{ID: "1"}))
+		return producer, metrics, cancel
+	}
+
+	t.Run("drop", func(t *testing.T) {
+		producer, metrics, cancel := newFullProducer(OverflowDrop)
+		defer cancel()
+		assert.ErrorIs(t, producer.Publish(TestMessage{ID: "2"}), ErrProducerBufferFull)
+		assert.Equal(t, "1", metrics.Get("queued").String())
+		assert.Equal(t, "1", metrics.Get("dropped").String())
+	})
+
+	t.Run("block until closed", func(t *testing.T) {
+		producer, metrics, cancel := newFullProducer(OverflowBlock)
+		result := make(chan error, 1)
+		go func() { result <- producer.Publish(TestMessage{ID: "2"}) }()
+		select {
+		case err := <-result:
+			t.Fatalf("publish should block, got %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+		cancel()
+		assert.ErrorIs(t, <-result, ErrConsumerClosed)
+		assert.Equal(t, "1", metrics.Get("queued").String())
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := NewProducer[TestMessage](redis.NewClient(&redis.Options{}), "test-stream", WithProducerMaxBuffer[TestMessage](-1, OverflowDrop))
+		assert.Error(t, err)
+	})
+}
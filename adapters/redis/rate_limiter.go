@@ -0,0 +1,127 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimitResult 代表一次限流檢查的結果
+type RateLimitResult struct {
+	// Allowed 表示請求是否被允許
+	Allowed bool
+	// Remaining 表示目前時間窗內剩餘的可用次數
+	Remaining int64
+	// RetryAfter 表示被拒絕時需要等待多久才能再次請求，允許時為0
+	RetryAfter time.Duration
+	// Token 是允許時記錄的本次請求，可以交給 Refund 退回，拒絕時為空
+	Token string
+}
+
+// slidingWindowScript 使用 sorted set 實現滑動時間窗的限流
+//
+//	KEYS[1] - 限流鍵
+//	ARGV[1] - 時間窗內允許的請求數
+//	ARGV[2] - 時間窗長度(毫秒)
+//	ARGV[3] - 本次請求的唯一識別
+//
+// 返回值:
+//
+//	{是否允許(1/0), 剩餘次數, 需要等待的毫秒數}
+//
+// 流程:
+//   - 1. 以 Redis 的時間為準，移除時間窗以外的請求紀錄
+//   - 2a. 如果時間窗內的請求數未達上限，記錄本次請求並返回允許
+//   - 2b. 如果已達上限，以時間窗內最早的請求計算需要等待的時間並返回拒絕
+var slidingWindowScript = redis.NewScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+-- 移除時間窗以外的請求紀錄
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+
+local count = redis.call('ZCARD', KEYS[1])
+if count < limit then
+    redis.call('ZADD', KEYS[1], now, ARGV[3])
+    redis.call('PEXPIRE', KEYS[1], window)
+    return {1, limit - count - 1, 0}
+end
+
+-- 最早的請求離開時間窗後才能再次請求
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+local retry_after = tonumber(oldest[2]) + window - now
+if retry_after < 1 then
+    retry_after = 1
+end
+return {0, 0, retry_after}
+`)
+
+type RateLimiter struct {
+	client redis.UniversalClient
+	name   string
+	limit  int64
+	window time.Duration
+}
+
+// NewRateLimiter 建立一個以 Redis 為基礎的滑動時間窗限流器
+// 同一個 name 的限流器共用計數，可以在多個實例間共同限流
+//   - name: 限流器名稱，會作為限流鍵的前綴
+//   - limit: 時間窗內允許的請求數
+//   - window: 時間窗長度
+func NewRateLimiter(client redis.UniversalClient, name string, limit int64, window time.Duration) (IRateLimiter, error) {
+	if isNilClient(client) {
+		return nil, errors.New("redis client cannot be nil")
+	}
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	if limit <= 0 || window < time.Millisecond {
+		return nil, errors.New("limit and window must be positive")
+	}
+	return &RateLimiter{
+		client: client,
+		name:   name,
+		limit:  limit,
+		window: window,
+	}, nil
+}
+
+// Allow 檢查指定的鍵是否還能請求，允許時會同時記錄本次請求
+func (l *RateLimiter) Allow(ctx context.Context, key string) (RateLimitResult, error) {
+	const op = "RateLimiter.Allow"
+	token := uuid.NewString()
+	values, err := slidingWindowScript.Run(ctx, l.client,
+		[]string{l.name + ":" + key},
+		l.limit, l.window.Milliseconds(), token,
+	).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("[%s] failed to run rate limit script: %w", op, err)
+	}
+	if len(values) != 3 {
+		return RateLimitResult{}, fmt.Errorf("[%s] invalid script return value: %v", op, values)
+	}
+	result := RateLimitResult{
+		Allowed:    values[0] == 1,
+		Remaining:  values[1],
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}
+	if result.Allowed {
+		result.Token = token
+	}
+	return result, nil
+}
+
+// Refund 退回 Allow 記錄的請求，用於同一個請求的其他限流檢查被拒絕時，避免被拒絕的請求佔用次數
+func (l *RateLimiter) Refund(ctx context.Context, key, token string) error {
+	const op = "RateLimiter.Refund"
+	if err := l.client.ZRem(ctx, l.name+":"+key, token).Err(); err != nil {
+		return fmt.Errorf("[%s] failed to remove request: %w", op, err)
+	}
+	return nil
+}
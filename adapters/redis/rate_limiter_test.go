@@ -0,0 +1,170 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	client := redis.NewClient(&redis.Options{})
+	defer client.Close()
+
+	tests := []struct {
+		name    string
+		client  *redis.Client
+		limiter string
+		limit   int64
+		window  time.Duration
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid configuration",
+			client:  client,
+			limiter: "test-limiter",
+			limit:   1,
+			window:  time.Second,
+			wantErr: false,
+		},
+		{
+			name:    "nil client",
+			client:  nil,
+			limiter: "test-limiter",
+			limit:   1,
+			window:  time.Second,
+			wantErr: true,
+			errMsg:  "redis client cannot be nil",
+		},
+		{
+			name:    "empty name",
+			client:  client,
+			limiter: "",
+			limit:   1,
+			window:  time.Second,
+			wantErr: true,
+			errMsg:  "name cannot be empty",
+		},
+		{
+			name:    "invalid limit",
+			client:  client,
+			limiter: "test-limiter",
+			limit:   0,
+			window:  time.Second,
+			wantErr: true,
+			errMsg:  "limit and window must be positive",
+		},
+		{
+			name:    "invalid window",
+			client:  client,
+			limiter: "test-limiter",
+			limit:   1,
+			window:  0,
+			wantErr: true,
+			errMsg:  "limit and window must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter, err := NewRateLimiter(tt.client, tt.limiter, tt.limit, tt.window)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, limiter)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, limiter)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	now := time.Now()
+	mr.SetTime(now)
+
+	limiter, err := NewRateLimiter(client, "test-limiter", 2, 10*time.Second)
+	require.NoError(t, err)
+
+	t.Run("allow until limit reached", func(t *testing.T) {
+		result, err := limiter.Allow(ctx, "user-1")
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, int64(1), result.Remaining)
+		assert.Zero(t, result.RetryAfter)
+
+		mr.SetTime(now.Add(2 * time.Second))
+		result, err = limiter.Allow(ctx, "user-1")
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, int64(0), result.Remaining)
+	})
+
+	t.Run("reject when limit reached", func(t *testing.T) {
+		mr.SetTime(now.Add(4 * time.Second))
+		result, err := limiter.Allow(ctx, "user-1")
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, int64(0), result.Remaining)
+		// 最早的請求在第0秒，需要等到第10秒才會離開時間窗
+		assert.Equal(t, 6*time.Second, result.RetryAfter)
+	})
+
+	t.Run("keys are limited independently", func(t *testing.T) {
+		result, err := limiter.Allow(ctx, "user-2")
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("allow again after window slides", func(t *testing.T) {
+		mr.SetTime(now.Add(10*time.Second + time.Millisecond))
+		result, err := limiter.Allow(ctx, "user-1")
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, int64(0), result.Remaining)
+	})
+
+	t.Run("key expires with window", func(t *testing.T) {
+		assert.True(t, mr.Exists("test-limiter:user-1"))
+		assert.Equal(t, 10*time.Second, mr.TTL("test-limiter:user-1"))
+	})
+
+	t.Run("refund releases the request", func(t *testing.T) {
+		result, err := limiter.Allow(ctx, "user-3")
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		assert.NotEmpty(t, result.Token)
+		result, err = limiter.Allow(ctx, "user-3")
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+
+		require.NoError(t, limiter.Refund(ctx, "user-3", result.Token))
+		result, err = limiter.Allow(ctx, "user-3")
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		result, err = limiter.Allow(ctx, "user-3")
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Empty(t, result.Token)
+	})
+
+	t.Run("redis error", func(t *testing.T) {
+		closedClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		closedClient.Close()
+		closedLimiter, err := NewRateLimiter(closedClient, "test-limiter", 2, 10*time.Second)
+		require.NoError(t, err)
+		_, err = closedLimiter.Allow(ctx, "user-1")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,247 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RetryAttemptField 是消息中記錄第幾次投遞的欄位，讀取重試的消息時由 RetryAttempts 寫回，移動到 dead-letter 時一起保存
+const RetryAttemptField = "attempt"
+
+// RetryQueue 取得 consumer group 延遲重試的 sorted set 鍵，成員為消息ID，分數為重試的時間(Unix毫秒)
+// NOTE: 和 DeadLetterStream 一樣只加上後綴，原本鍵中的 hash tag 會被保留
+func RetryQueue(stream, group string) string {
+	return stream + ":retry:" + group
+}
+
+// RetryAttempts 取得 consumer group 記錄消息投遞次數的 hash 鍵，欄位為消息ID
+// NOTE: 重試的消息留在原本的 stream 中，無法把投遞次數寫回消息，所以另外記錄，讀取時寫回 RetryAttemptField
+func RetryAttempts(stream, group string) string {
+	return stream + ":attempts:" + group
+}
+
+// scheduleRetryScript 記錄消息的投遞次數並放入延遲重試的 sorted set，兩個操作需要一起完成
+// 消息不會被確認，而是留在 consumer group 的 pending 中，到期後只有這個 consumer group 會重新讀取
+//
+//	KEYS[1] - 延遲重試的 sorted set
+//	KEYS[2] - 記錄投遞次數的 hash
+//	ARGV[1] - 消息ID
+//	ARGV[2] - 重試的時間(Unix毫秒)
+//	ARGV[3] - 下一次是第幾次投遞
+var scheduleRetryScript = redis.NewScript(`
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[3])
+return redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+`)
+
+// claimDueRetriesScript 以XCLAIM將到期的重試消息認領給目前的 consumer
+//
+//	KEYS[1] - 延遲重試的 sorted set
+//	KEYS[2] - 原本的 stream
+//	KEYS[3] - 記錄投遞次數的 hash
+//	ARGV[1] - 目前的時間(Unix毫秒)
+//	ARGV[2] - 每次最多認領的數量
+//	ARGV[3] - consumer group
+//	ARGV[4] - consumer
+//
+// 返回值: 認領的消息ID，已經不在 pending 中(例如已經被確認)的消息會被略過並刪除投遞次數的紀錄
+var claimDueRetriesScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[2]))
+local claimed = {}
+for _, id in ipairs(due) do
+    redis.call('ZREM', KEYS[1], id)
+    local ids = redis.call('XCLAIM', KEYS[2], ARGV[3], ARGV[4], 0, id, 'JUSTID')
+    if #ids > 0 then
+        table.insert(claimed, id)
+    else
+        redis.call('HDEL', KEYS[3], id)
+    end
+end
+return claimed
+`)
+
+// retryPolicy 消息的重試策略
+type retryPolicy struct {
+	maxAttempts int64
+	backoffBase time.Duration
+	backoffMax  time.Duration
+	strict      bool
+}
+
+// backoff 取得第 attempt 次投遞失敗後的等待時間，以指數成長並且不超過 backoffMax
+func (p *retryPolicy) backoff(attempt int64) time.Duration {
+	delay := p.backoffBase
+	for i := int64(1); i < attempt && delay < p.backoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, p.backoffMax)
+}
+
+// Attempt 取得消息目前是第幾次投遞，從1開始
+func (m *Message[T]) Attempt() int64 {
+	if m.attempt > 0 {
+		return m.attempt
+	}
+	if value, ok := m.raw[RetryAttemptField]; ok {
+		if attempt, err := strconv.ParseInt(fmt.Sprint(value), 10, 64); err == nil && attempt > 0 {
+			return attempt
+		}
+	}
+	return 1
+}
+
+// Retry 標記消息處理失敗，在退避時間後重新投遞，投遞次數達到上限時改為移動到 dead-letter
+//   - 非嚴格順序模式: 消息留在 pending 中並放入 consumer group 的延遲重試 sorted set，到期後由同一個 consumer group 認領，返回 false
+//     重試不會寫入新的消息，stream 的其他讀取者(其他 consumer group 和 Consumer)不會重複收到
+//   - 嚴格順序模式: 為了維持順序不能讓後面的消息先被處理，會在原地等待退避時間後返回 true，呼叫者需要立即重新處理這個消息
+//
+// 沒有設定重試策略(最大投遞次數為1)時等同於 Fail，以 NewMessage 建立的消息交由 MessageHandler 處理
+func (m *Message[T]) Retry(ctx context.Context, failErr error) (bool, error) {
+	const op = "Message.Retry"
+	if m.done {
+		return false, nil
+	}
+	if m.handler != nil {
+		retry, err := m.handler.Retry(ctx, m, failErr)
+		if err != nil {
+			return false, fmt.Errorf("[%s] %w", op, err)
+		}
+		if retry {
+			m.attempt = m.Attempt() + 1
+		} else {
+			m.done = true
+		}
+		return retry, nil
+	}
+	attempt := m.Attempt()
+	if m.policy == nil || attempt >= m.policy.maxAttempts {
+		return false, m.Fail(ctx, failErr)
+	}
+	delay := m.policy.backoff(attempt)
+
+	if m.policy.strict {
+		// 失去鎖時停止等待，消息留在 pending 中由取得鎖的 consumer 處理
+		if m.lockCtx != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			defer cancel()
+			stop := context.AfterFunc(m.lockCtx, cancel)
+			defer stop()
+		}
+		// 停止重試的消息留在 pending 中，不再需要等待確認
+		if err := m.client.HSet(ctx, RetryAttempts(m.stream, m.group), m.messageID, attempt+1).Err(); err != nil {
+			m.settle()
+			return false, fmt.Errorf("[%s] failed to save retry attempt: %w", op, err)
+		}
+		if err := waitRetry(ctx, delay); err != nil {
+			m.settle()
+			return false, fmt.Errorf("[%s] %w", op, err)
+		}
+		m.attempt = attempt + 1
+		return true, nil
+	}
+
+	keys := []string{RetryQueue(m.stream, m.group), RetryAttempts(m.stream, m.group)}
+	err := scheduleRetryScript.Run(ctx, m.client, keys, m.messageID, time.Now().Add(delay).UnixMilli(), attempt+1).Err()
+	if err != nil {
+		return false, fmt.Errorf("[%s] failed to schedule retry: %w", op, err)
+	}
+	m.done = true
+	m.settle()
+	return false, nil
+}
+
+// clearAttempt 刪除記錄的投遞次數，只有重試過的消息會有紀錄，刪除失敗時紀錄會留在 hash 中但不影響處理
+func (m *Message[T]) clearAttempt(ctx context.Context) {
+	if m.policy == nil || m.Attempt() <= 1 {
+		return
+	}
+	m.client.HDel(ctx, RetryAttempts(m.stream, m.group), m.messageID)
+}
+
+// loadAttempts 讀取記錄的投遞次數，寫回消息的 RetryAttemptField
+func (s *GroupConsumer[T]) loadAttempts(ctx context.Context, messages []redis.XMessage) error {
+	if len(messages) == 0 || s.options.maxAttempts <= 1 {
+		return nil
+	}
+	ids := make([]string, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+	attempts, err := s.client.HMGet(ctx, RetryAttempts(s.stream, s.group), ids...).Result()
+	if err != nil {
+		return fmt.Errorf("error getting retry attempts: %w", err)
+	}
+	for i, attempt := range attempts {
+		if attempt != nil {
+			messages[i].Values[RetryAttemptField] = attempt
+		}
+	}
+	return nil
+}
+
+// retryEnabled 是否需要認領到期的重試消息，只有非嚴格順序模式會使用延遲重試的 sorted set
+func (s *GroupConsumer[T]) retryEnabled() bool {
+	return !s.options.strictOrdering && s.options.maxAttempts > 1
+}
+
+// claimDueRetries 認領到期的重試消息，多個 consumer 同時執行時由 Lua script 保證每個消息只會被認領一次
+// 認領的消息放在 claimedMessages 中，下一次讀取時優先處理
+func (s *GroupConsumer[T]) claimDueRetries(ctx context.Context) error {
+	keys := []string{RetryQueue(s.stream, s.group), s.stream, RetryAttempts(s.stream, s.group)}
+	ids, err := claimDueRetriesScript.Run(ctx, s.client, keys, time.Now().UnixMilli(), s.options.claimCount, s.group, s.consumer).StringSlice()
+	if err != nil {
+		return fmt.Errorf("error claiming due retries: %w", err)
+	}
+	messages := make([]redis.XMessage, 0, len(ids))
+	for _, id := range ids {
+		result, err := s.client.XRangeN(ctx, s.stream, id, id, 1).Result()
+		if err != nil {
+			return fmt.Errorf("error reading due retry: %w", err)
+		}
+		if len(result) == 0 {
+			// 消息已經從stream刪除(例如被修剪)，無法再處理，直接確認
+			if err := s.client.XAck(ctx, s.stream, s.group, id).Err(); err != nil {
+				return fmt.Errorf("error acking deleted message: %w", err)
+			}
+			s.client.HDel(ctx, RetryAttempts(s.stream, s.group), id)
+			continue
+		}
+		messages = append(messages, result[0])
+	}
+	if err := s.loadAttempts(ctx, messages); err != nil {
+		return err
+	}
+	if len(messages) > 0 {
+		s.logger.Info("claimed due retries", slog.Int("count", len(messages)))
+		s.claimedMessages = append(s.claimedMessages, messages...)
+	}
+	return nil
+}
+
+// waitingRetry 過濾掉還在等待重試的消息，這些消息由 claimDueRetries 在到期後認領，不會因為閒置過久而提早處理
+func (s *GroupConsumer[T]) waitingRetry(ctx context.Context, messages []redis.XMessage) (map[string]bool, error) {
+	waiting := map[string]bool{}
+	if !s.retryEnabled() || len(messages) == 0 {
+		return waiting, nil
+	}
+	ids := make([]string, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+	scores, err := s.client.ZMScore(ctx, RetryQueue(s.stream, s.group), ids...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error checking retry queue: %w", err)
+	}
+	for i, score := range scores {
+		// 不存在的成員分數為0，重試的時間一定大於0
+		if score > 0 {
+			waiting[ids[i]] = true
+		}
+	}
+	return waiting, nil
+}
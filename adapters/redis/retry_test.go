@@ -0,0 +1,306 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &retryPolicy{backoffBase: time.Second, backoffMax: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.backoff(1))
+	assert.Equal(t, 2*time.Second, policy.backoff(2))
+	assert.Equal(t, 4*time.Second, policy.backoff(3))
+	assert.Equal(t, 5*time.Second, policy.backoff(4))
+	assert.Equal(t, 5*time.Second, policy.backoff(100))
+}
+
+func TestNewGroupConsumer_RetryOptions(t *testing.T) {
+	client := redis.NewClient(&redis.Options{})
+	defer client.Close()
+	for _, opt := range []GroupConsumerOption[TestMessage]{
+		WithGroupConsumerMaxAttempts[TestMessage](0),
+		WithGroupConsumerBackoff[TestMessage](time.Minute, time.Second),
+		WithGroupConsumerRetryPollInterval[TestMessage](0),
+	} {
+		_, err := NewGroupConsumer[TestMessage](client, "test-stream", "test-group", "test-consumer", opt)
+		assert.Error(t, err)
+	}
+}
+
+func TestMessage_RetryStrict(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	lockCtx, releaseLock := context.WithCancel(context.Background())
+	defer releaseLock()
+	msg := &Message[TestMessage]{
+		messageID: "1-0",
+		stream:    "test-stream",
+		group:     "test-group",
+		client:    client,
+		policy:    &retryPolicy{maxAttempts: 3, backoffBase: time.Millisecond, backoffMax: time.Millisecond, strict: true},
+		raw:       map[string]any{},
+		lockCtx:   lockCtx,
+	}
+	ctx := context.Background()
+
+	// 嚴格順序模式在原地等待後要求呼叫者重新處理，並記錄投遞次數
+	assert.Equal(t, int64(1), msg.Attempt())
+	retry, err := msg.Retry(ctx, errors.New("sync failed"))
+	require.NoError(t, err)
+	assert.True(t, retry)
+	assert.Equal(t, int64(2), msg.Attempt())
+	assert.Equal(t, "2", mr.HGet(RetryAttempts("test-stream", "test-group"), "1-0"))
+
+	// 等待重試時 context 被取消，消息留在 pending 中
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	msg.policy.backoffBase, msg.policy.backoffMax = time.Minute, time.Minute
+	retry, err = msg.Retry(cancelCtx, errors.New("sync failed"))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, retry)
+	assert.Equal(t, int64(2), msg.Attempt())
+
+	// 等待重試時失去鎖，停止重試
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		releaseLock()
+	}()
+	retry, err = msg.Retry(ctx, errors.New("sync failed"))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, retry)
+	assert.Equal(t, int64(2), msg.Attempt())
+
+	// 確認後刪除投遞次數的紀錄
+	require.NoError(t, msg.Done(ctx))
+	assert.False(t, mr.Exists(RetryAttempts("test-stream", "test-group")))
+}
+
+func TestGroupConsumer_RetryStrictAttemptsSurviveRestart(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+	values, err := DefaultParseToMessage(TestMessage{ID: "1"})
+	require.NoError(t, err)
+	require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: "test-stream", Values: values}).Err())
+
+	newConsumer := func() IGroupConsumer[TestMessage] {
+		consumer, err := NewGroupConsumer[TestMessage](
+			client,
+			"test-stream",
+			"test-group",
+			"test-consumer",
+			WithGroupConsumerCreateGroup[TestMessage]("0"),
+			WithGroupConsumerStrictOrdering[TestMessage](true),
+			WithGroupConsumerMutex[TestMessage](NewAutoRenewMutex(client, "test-lock")),
+			WithGroupConsumerBlockTimeout[TestMessage](10*time.Millisecond),
+			WithGroupConsumerMaxAttempts[TestMessage](3),
+			WithGroupConsumerBackoff[TestMessage](time.Millisecond, time.Millisecond),
+			WithGroupConsumerReleaseTimeout[TestMessage](10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Start())
+		return consumer
+	}
+	receive := func(consumer IGroupConsumer[TestMessage]) *Message[TestMessage] {
+		select {
+		case msg := <-consumer.Subscribe():
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for message")
+			return nil
+		}
+	}
+
+	consumer := newConsumer()
+	msg := receive(consumer)
+	retry, err := msg.Retry(ctx, errors.New("sync failed"))
+	require.NoError(t, err)
+	require.True(t, retry)
+	assert.Equal(t, int64(2), msg.Attempt())
+	// 重試期間重啟，消息留在 pending 中
+	require.NoError(t, consumer.Close())
+
+	consumer = newConsumer()
+	defer consumer.Close()
+	msg = receive(consumer)
+	assert.Equal(t, "1", msg.Data.ID)
+	assert.Equal(t, int64(2), msg.Attempt())
+	retry, err = msg.Retry(ctx, errors.New("sync failed"))
+	require.NoError(t, err)
+	require.True(t, retry)
+
+	// 達到最大投遞次數後移動到 dead-letter，並刪除投遞次數的紀錄
+	retry, err = msg.Retry(ctx, errors.New("sync failed"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+	deadLetters, err := client.XRange(ctx, DeadLetterStream("test-stream"), "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, "3", deadLetters[0].Values[RetryAttemptField])
+	assert.False(t, mr.Exists(RetryAttempts("test-stream", "test-group")))
+}
+
+func TestGroupConsumer_Retry(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	consumer, err := NewGroupConsumer[TestMessage](
+		client,
+		"test-stream",
+		"test-group",
+		"test-consumer",
+		WithGroupConsumerCreateGroup[TestMessage]("0"),
+		WithGroupConsumerBlockTimeout[TestMessage](10*time.Millisecond),
+		WithGroupConsumerMaxAttempts[TestMessage](3),
+		WithGroupConsumerBackoff[TestMessage](10*time.Millisecond, 20*time.Millisecond),
+		WithGroupConsumerRetryPollInterval[TestMessage](10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Start())
+	defer consumer.Close()
+
+	values, err := DefaultParseToMessage(TestMessage{ID: "1"})
+	require.NoError(t, err)
+	require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: "test-stream", Values: values}).Err())
+
+	receive := func() *Message[TestMessage] {
+		select {
+		case msg := <-consumer.Subscribe():
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for message")
+			return nil
+		}
+	}
+
+	// 前兩次投遞失敗後消息留在 pending 中，到期後以原本的ID重新投遞
+	var messageID string
+	for attempt := int64(1); attempt < 3; attempt++ {
+		msg := receive()
+		assert.Equal(t, "1", msg.Data.ID)
+		assert.Equal(t, attempt, msg.Attempt())
+		if messageID == "" {
+			messageID = msg.ID()
+		}
+		assert.Equal(t, messageID, msg.ID())
+		retry, err := msg.Retry(ctx, errors.New("sync failed"))
+		require.NoError(t, err)
+		assert.False(t, retry)
+
+		count, err := client.XPending(ctx, "test-stream", "test-group").Result()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count.Count)
+	}
+	length, err := client.XLen(ctx, "test-stream").Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), length)
+
+	// 達到最大投遞次數後移動到dead-letter
+	msg := receive()
+	assert.Equal(t, int64(3), msg.Attempt())
+	retry, err := msg.Retry(ctx, errors.New("sync failed"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	messages, err := client.XRange(ctx, DeadLetterStream("test-stream"), "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "sync failed", messages[0].Values[DeadLetterErrorField])
+	assert.Equal(t, "3", messages[0].Values[RetryAttemptField])
+	data, err := DefaultParseFromMessage[TestMessage](messages[0].Values)
+	require.NoError(t, err)
+	assert.Equal(t, "1", data.ID)
+
+	size, err := client.ZCard(ctx, RetryQueue("test-stream", "test-group")).Result()
+	require.NoError(t, err)
+	assert.Zero(t, size)
+	assert.False(t, mr.Exists(RetryAttempts("test-stream", "test-group")))
+	count, err := client.XPending(ctx, "test-stream", "test-group").Result()
+	require.NoError(t, err)
+	assert.Zero(t, count.Count)
+}
+
+func TestGroupConsumer_RetryOnlyFailingGroup(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	newGroupConsumer := func(group string) IGroupConsumer[TestMessage] {
+		consumer, err := NewGroupConsumer[TestMessage](
+			client,
+			"test-stream",
+			group,
+			"test-consumer",
+			WithGroupConsumerCreateGroup[TestMessage]("0"),
+			WithGroupConsumerBlockTimeout[TestMessage](10*time.Millisecond),
+			WithGroupConsumerMaxAttempts[TestMessage](3),
+			WithGroupConsumerBackoff[TestMessage](10*time.Millisecond, 10*time.Millisecond),
+			WithGroupConsumerRetryPollInterval[TestMessage](10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Start())
+		return consumer
+	}
+	failing := newGroupConsumer("failing-group")
+	defer failing.Close()
+	other := newGroupConsumer("other-group")
+	defer other.Close()
+	reader, err := NewConsumer[TestMessage](client, "test-stream", WithConsumerStartID[TestMessage]("0"), WithConsumerBlockTimeout[TestMessage](10*time.Millisecond))
+	require.NoError(t, err)
+	reader.Start()
+	defer reader.Close()
+
+	values, err := DefaultParseToMessage(TestMessage{ID: "1"})
+	require.NoError(t, err)
+	require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: "test-stream", Values: values}).Err())
+
+	receive := func(ch <-chan *Message[TestMessage]) *Message[TestMessage] {
+		select {
+		case msg := <-ch:
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for message")
+			return nil
+		}
+	}
+	msg := receive(other.Subscribe())
+	require.NoError(t, msg.Done(ctx))
+
+	// 失敗的 consumer group 重試後再次收到同一個消息
+	msg = receive(failing.Subscribe())
+	retry, err := msg.Retry(ctx, errors.New("sync failed"))
+	require.NoError(t, err)
+	require.False(t, retry)
+	msg = receive(failing.Subscribe())
+	assert.Equal(t, int64(2), msg.Attempt())
+	require.NoError(t, msg.Done(ctx))
+
+	// 其他 consumer group 和 Consumer 只會收到一次
+	select {
+	case <-reader.Subscribe():
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+	select {
+	case msg := <-other.Subscribe():
+		t.Fatalf("other group received retry %s", msg.ID())
+	case data := <-reader.Subscribe():
+		t.Fatalf("consumer received retry %v", data)
+	case <-time.After(100 * time.Millisecond):
+	}
+	length, err := client.XLen(ctx, "test-stream").Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), length)
+}
@@ -1,11 +1,15 @@
 package redis
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/vmihailenco/msgpack/v5"
 )
 
@@ -68,3 +72,41 @@ func DefaultParseFromMessage[T any](message map[string]any) (T, error) {
 
 	return result, nil
 }
+
+// FlattenFields 將消息的欄位依照名稱排序後展開成 field, value, ... 的形式，讓 XADD 以固定的順序寫入欄位
+func FlattenFields(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fields := make([]string, 0, len(keys)*2)
+	for _, k := range keys {
+		fields = append(fields, k, fmt.Sprint(values[k]))
+	}
+	return fields
+}
+
+// isNilClient 檢查 client 是否為空，包含以 nil 指標實作 redis.UniversalClient 的情況
+func isNilClient(client redis.UniversalClient) bool {
+	if client == nil {
+		return true
+	}
+	v := reflect.ValueOf(client)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// waitRetry 在重試前等待 d 的時間，d 小於等於0時立即返回，context 取消時返回 context 的錯誤
+func waitRetry(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
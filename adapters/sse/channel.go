@@ -2,27 +2,88 @@ package sse
 
 import (
 	"sync"
+	"sync/atomic"
 )
 
+// OverflowPolicy 訂閱者的佇列已滿時 Broadcast 的處理方式
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest 丟棄佇列中最舊的訊息，再放入新的訊息
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowCoalesce 丟棄佇列中所有的訊息，只保留最新的訊息，適用於只需要最新狀態(例如最新價格)的訂閱者
+	OverflowCoalesce
+	// OverflowDisconnect 取消訂閱並關閉通道，由訂閱者重新連線後補送遺漏的訊息
+	OverflowDisconnect
+)
+
+// ChannelStats 頻道的運行指標
+type ChannelStats struct {
+	// 目前的訂閱者數量
+	Subscribers int `json:"subscribers"`
+	// 所有訂閱者的佇列中尚未讀取的訊息數量
+	Queued int `json:"queued"`
+	// 放入訂閱者佇列的訊息數量
+	Delivered uint64 `json:"delivered"`
+	// 因為佇列已滿而丟棄的訊息數量，包含 OverflowCoalesce 合併掉的訊息
+	Dropped uint64 `json:"dropped"`
+	// 因為佇列已滿而被取消訂閱的訂閱者數量
+	Disconnected uint64 `json:"disconnected"`
+}
+
+type channelOptions struct {
+	bufferSize     int
+	overflowPolicy OverflowPolicy
+}
+
+// ChannelOption 定義頻道設定選項的函式型別
+type ChannelOption func(*channelOptions)
+
+// WithChannelBuffer 設定每個訂閱者的佇列大小(最小為1)和佇列已滿時的處理方式，預設為16和 OverflowDropOldest
+func WithChannelBuffer(size int, policy OverflowPolicy) ChannelOption {
+	return func(o *channelOptions) {
+		o.bufferSize = size
+		o.overflowPolicy = policy
+	}
+}
+
 // Channel 用於管理針對某個主題 (Topic) 的所有訂閱者，
 // 並將接收到的訊息廣播給所有訂閱者。
+// 每個訂閱者各自有一個有界的佇列，Broadcast 不會等待訂閱者讀取，
+// 單一訂閱者讀取太慢時只會依照 OverflowPolicy 影響自己，不會拖慢其他訂閱者。
 type Channel[T any] struct {
-	subscribers map[<-chan T]chan<- T
+	subscribers map[<-chan T]chan T
 	mu          sync.RWMutex
+	options     channelOptions
+
+	delivered    atomic.Uint64
+	dropped      atomic.Uint64
+	disconnected atomic.Uint64
 }
 
 // NewChannel creates a new SSE channel.
-func NewChannel[T any]() IChannel[T] {
+func NewChannel[T any](opts ...ChannelOption) IChannel[T] {
+	options := channelOptions{
+		bufferSize:     16,
+		overflowPolicy: OverflowDropOldest,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	options.bufferSize = max(options.bufferSize, 1)
+
 	return &Channel[T]{
-		subscribers: make(map[<-chan T]chan<- T),
+		subscribers: make(map[<-chan T]chan T),
+		options:     options,
 	}
 }
 
 // Subscribe 建立一個新的 chan T，將其加入 subscribers，並回傳唯讀通道給呼叫者。
+// 通道可能因為 OverflowDisconnect 被關閉，呼叫者需要檢查通道是否已經關閉。
 func (c *Channel[T]) Subscribe() <-chan T {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	ch := make(chan T)
+	ch := make(chan T, c.options.bufferSize)
 	c.subscribers[ch] = ch
 	return ch
 }
@@ -41,19 +102,58 @@ func (c *Channel[T]) Unsubscribe(ch <-chan T) {
 func (c *Channel[T]) UnsubscribeAll() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-
 	for _, writeCh := range c.subscribers {
 		close(writeCh)
 	}
 	clear(c.subscribers)
 }
 
-// Broadcast 將訊息廣播給所有仍在訂閱清單中的通道。
+// Broadcast 將訊息放入所有訂閱者的佇列，不會等待訂閱者讀取。
+// 佇列已滿時依照 OverflowPolicy 處理。
+// NOTE: 丟棄佇列中的訊息時需要確保沒有其他 Broadcast 同時寫入，所以持有寫入鎖
 func (c *Channel[T]) Broadcast(message T) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	for _, writeCh := range c.subscribers {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for readCh, writeCh := range c.subscribers {
+		select {
+		case writeCh <- message:
+			c.delivered.Add(1)
+			continue
+		default:
+		}
+
+		switch c.options.overflowPolicy {
+		case OverflowDisconnect:
+			delete(c.subscribers, readCh)
+			close(writeCh)
+			c.disconnected.Add(1)
+			continue
+		case OverflowCoalesce:
+			c.dropped.Add(uint64(drain(writeCh)))
+		default:
+			// 訂閱者可能在這段期間讀取了訊息，佇列不一定還是滿的
+			select {
+			case <-writeCh:
+				c.dropped.Add(1)
+			default:
+			}
+		}
+		// 只有 Broadcast 會寫入佇列，且已經持有寫入鎖，這裡一定有空間
 		writeCh <- message
+		c.delivered.Add(1)
+	}
+}
+
+// drain 丟棄佇列中所有的訊息，返回丟棄的數量
+func drain[T any](ch chan T) int {
+	n := 0
+	for {
+		select {
+		case <-ch:
+			n++
+		default:
+			return n
+		}
 	}
 }
 
@@ -63,3 +163,19 @@ func (c *Channel[T]) IsIdle() bool {
 	defer c.mu.RUnlock()
 	return len(c.subscribers) == 0
 }
+
+// Stats 取得頻道的運行指標
+func (c *Channel[T]) Stats() ChannelStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	stats := ChannelStats{
+		Subscribers:  len(c.subscribers),
+		Delivered:    c.delivered.Load(),
+		Dropped:      c.dropped.Load(),
+		Disconnected: c.disconnected.Load(),
+	}
+	for _, writeCh := range c.subscribers {
+		stats.Queued += len(writeCh)
+	}
+	return stats
+}
@@ -60,3 +60,70 @@ func TestChannel_UnsubscribeAll(t *testing.T) {
 	// 驗證訂閱清單已清空
 	assert.True(t, ch.IsIdle(), "channel should be idle after UnsubscribeAll")
 }
+
+func TestChannel_OverflowPolicy(t *testing.T) {
+	broadcast := func(ch sse.IChannel[Message], data ...string) {
+		for _, d := range data {
+			ch.Broadcast(Message{Data: d})
+		}
+	}
+	receive := func(sub <-chan Message) []string {
+		var received []string
+		for {
+			select {
+			case msg, ok := <-sub:
+				if !ok {
+					return received
+				}
+				received = append(received, msg.Data)
+			default:
+				return received
+			}
+		}
+	}
+
+	t.Run("drop oldest", func(t *testing.T) {
+		ch := sse.NewChannel[Message](sse.WithChannelBuffer(2, sse.OverflowDropOldest))
+		sub := ch.Subscribe()
+		broadcast(ch, "1", "2", "3", "4")
+		assert.Equal(t, []string{"3", "4"}, receive(sub))
+
+		stats := ch.(*sse.Channel[Message]).Stats()
+		assert.Equal(t, uint64(4), stats.Delivered)
+		assert.Equal(t, uint64(2), stats.Dropped)
+		assert.Equal(t, 0, stats.Queued)
+	})
+
+	t.Run("coalesce", func(t *testing.T) {
+		ch := sse.NewChannel[Message](sse.WithChannelBuffer(2, sse.OverflowCoalesce))
+		sub := ch.Subscribe()
+		broadcast(ch, "1", "2", "3")
+		assert.Equal(t, []string{"3"}, receive(sub))
+		broadcast(ch, "4")
+		assert.Equal(t, []string{"4"}, receive(sub))
+
+		stats := ch.(*sse.Channel[Message]).Stats()
+		assert.Equal(t, uint64(2), stats.Dropped)
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		ch := sse.NewChannel[Message](sse.WithChannelBuffer(2, sse.OverflowDisconnect))
+		slow := ch.Subscribe()
+		fast := ch.Subscribe()
+		broadcast(ch, "1", "2")
+		assert.Equal(t, []string{"1", "2"}, receive(fast))
+		broadcast(ch, "3")
+
+		// 佇列已滿的訂閱者讀完佇列中的訊息後通道關閉
+		assert.Equal(t, []string{"1", "2"}, receive(slow))
+		_, ok := <-slow
+		assert.False(t, ok, "slow subscriber should be disconnected")
+		assert.Equal(t, []string{"3"}, receive(fast))
+
+		stats := ch.(*sse.Channel[Message]).Stats()
+		assert.Equal(t, 1, stats.Subscribers)
+		assert.Equal(t, uint64(1), stats.Disconnected)
+		ch.Unsubscribe(slow)
+		assert.False(t, ch.IsIdle())
+	})
+}
@@ -16,7 +16,7 @@ type IChannel[T any] interface {
 	Unsubscribe(ch <-chan T)
 	// UnsubscribeAll 取消所有訂閱
 	UnsubscribeAll()
-	// Broadcast 將訊息廣播給所有訂閱者
+	// Broadcast 將訊息廣播給所有訂閱者，不應該等待訂閱者讀取，避免讀取太慢的訂閱者拖慢其他訂閱者
 	Broadcast(message T)
 	// IsIdle 檢查是否沒有訂閱者
 	IsIdle() bool
@@ -30,6 +30,7 @@ type IConnectionManager[T any] interface {
 	// Done 停止 ConnectionManager，釋放所有資源。
 	Done()
 	// Subscribe 註冊並訂閱指定頻道，返回一個新的 chan Message。
+	// 讀取太慢的訂閱者可能依照頻道的 OverflowPolicy 被關閉通道，呼叫者需要檢查通道是否已經關閉。
 	Subscribe(channelName string) (<-chan T, error)
 	// Publish 將資料推送到指定頻道。
 	Publish(channelName string, data T) error
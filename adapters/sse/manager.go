@@ -3,6 +3,7 @@ package sse
 import (
 	"context"
 	"errors"
+	"expvar"
 	"log/slog"
 	"sync"
 )
@@ -52,6 +53,8 @@ type connectionManagerOptions[T any] struct {
 	publisher         Publisher[PublishRequest[T]]  // 選用：用於發送訊息到上游的發布者
 	logger            *slog.Logger                  // 選用：用於記錄日誌的 logger
 	createChannelFunc func() IChannel[T]            // 選用：用於建立新頻道的函數
+	channelOptions    []ChannelOption               // 選用：建立預設頻道時使用的選項
+	metrics           *expvar.Map                   // 選用：用於輸出指標的 expvar.Map
 }
 
 // ConnectionManagerOption 定義設定選項的函式型別
@@ -89,6 +92,22 @@ func WithCreateChannelFunc[T any](createFunc func() IChannel[T]) ConnectionManag
 	}
 }
 
+// WithChannelOptions 設定建立新頻道時使用的選項
+// opts: 傳給 NewChannel 的選項，例如每個訂閱者的佇列大小和佇列已滿時的處理方式，設定 WithCreateChannelFunc 時不會使用
+func WithChannelOptions[T any](opts ...ChannelOption) ConnectionManagerOption[T] {
+	return func(o *connectionManagerOptions[T]) {
+		o.channelOptions = opts
+	}
+}
+
+// WithMetrics 設定輸出指標的 expvar.Map
+// m: 以 channels 輸出每個頻道的 ChannelStats，沒有實作 Stats 的頻道(例如mock物件)不會輸出
+func WithMetrics[T any](m *expvar.Map) ConnectionManagerOption[T] {
+	return func(o *connectionManagerOptions[T]) {
+		o.metrics = m
+	}
+}
+
 // NewConnectionManager 建立一個新的連線管理器
 // opts: 可變參數，用於設定連線管理器的選項
 // 返回:
@@ -96,26 +115,49 @@ func WithCreateChannelFunc[T any](createFunc func() IChannel[T]) ConnectionManag
 //   - error: 若未提供必要的訂閱者，將返回 ErrSubscriberRequired
 func NewConnectionManager[T any](opts ...ConnectionManagerOption[T]) (IConnectionManager[T], error) {
 	options := connectionManagerOptions[T]{
-		logger:            slog.Default(),
-		createChannelFunc: NewChannel[T], // 設定預設的建立頻道函數
+		logger: slog.Default(),
 	}
 
 	for _, opt := range opts {
 		opt(&options)
 	}
+	if options.createChannelFunc == nil {
+		// 設定預設的建立頻道函數
+		options.createChannelFunc = func() IChannel[T] {
+			return NewChannel[T](options.channelOptions...)
+		}
+	}
 
 	if options.subscriber == nil {
 		return nil, ErrSubscriberRequired
 	}
 
-	return &ConnectionManager[T]{
+	cm := &ConnectionManager[T]{
 		logger:            options.logger.With("Caller", "ConnectionManager"),
 		channels:          make(map[string]IChannel[T]),
 		subscriber:        options.subscriber,
 		publisher:         options.publisher,
 		active:            false,
 		createChannelFunc: options.createChannelFunc,
-	}, nil
+	}
+	if options.metrics != nil {
+		options.metrics.Set("channels", expvar.Func(func() any { return cm.channelStats() }))
+	}
+	return cm, nil
+}
+
+// channelStats 取得每個頻道的運行指標
+func (cm *ConnectionManager[T]) channelStats() map[string]ChannelStats {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	stats := make(map[string]ChannelStats, len(cm.channels))
+	for name, channel := range cm.channels {
+		if c, ok := channel.(interface{ Stats() ChannelStats }); ok {
+			stats[name] = c.Stats()
+		}
+	}
+	return stats
 }
 
 // Start 啟動連線管理器
@@ -2,6 +2,7 @@ package sse_test
 
 import (
 	"context"
+	"expvar"
 	"log/slog"
 	"testing"
 	"time"
@@ -402,3 +403,59 @@ func TestConnectionManager_Done(t *testing.T) {
 		close(msgChan)
 	})
 }
+
+func TestConnectionManager_SlowSubscriber(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	setup := setupConnectionManagerTest(t)
+	defer setup.ctrl.Finish()
+
+	msgChan := make(chan sse.PublishRequest[Message])
+	setup.subscriber.EXPECT().Subscribe().Return(msgChan)
+
+	metrics := new(expvar.Map)
+	cm, err := sse.NewConnectionManager[Message](
+		sse.WithSubscriber[Message](setup.subscriber),
+		sse.WithChannelOptions[Message](sse.WithChannelBuffer(1, sse.OverflowDisconnect)),
+		sse.WithMetrics[Message](metrics),
+	)
+	assert.NoError(t, err)
+	cm.Start()
+
+	slow, err := cm.Subscribe("test-channel")
+	assert.NoError(t, err)
+	fast, err := cm.Subscribe("test-channel")
+	assert.NoError(t, err)
+
+	// 不讀取的訂閱者不會阻塞其他訂閱者
+	for _, data := range []string{"1", "2", "3"} {
+		select {
+		case msgChan <- sse.PublishRequest[Message]{Channel: "test-channel", Message: Message{Data: data}}:
+		case <-time.After(time.Second):
+			t.Fatal("timeout sending message")
+		}
+		select {
+		case msg := <-fast:
+			assert.Equal(t, data, msg.Data)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for message")
+		}
+	}
+
+	// 佇列已滿的訂閱者被取消訂閱，讀完佇列中的訊息後通道關閉
+	msg, ok := <-slow
+	assert.True(t, ok)
+	assert.Equal(t, "1", msg.Data)
+	_, ok = <-slow
+	assert.False(t, ok, "slow subscriber should be disconnected")
+
+	stats := metrics.Get("channels").(expvar.Func).Value().(map[string]sse.ChannelStats)
+	assert.Equal(t, 1, stats["test-channel"].Subscribers)
+	assert.Equal(t, uint64(1), stats["test-channel"].Disconnected)
+	assert.Equal(t, uint64(4), stats["test-channel"].Delivered)
+
+	// 被取消訂閱的通道再次取消訂閱不會有影響
+	cm.Unsubscribe("test-channel", slow)
+	cm.Unsubscribe("test-channel", fast)
+	close(msgChan)
+	cm.Done()
+}
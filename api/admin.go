@@ -0,0 +1,465 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// Suspend a user
+// (POST /admin/user/{userID}/suspend)
+func (impl *ServerImpl) PostAdminUserUserIDSuspend(ctx context.Context, request openapi.PostAdminUserUserIDSuspendRequestObject) (openapi.PostAdminUserUserIDSuspendResponseObject, error) {
+	const op = "PostAdminUserUserIDSuspend"
+	// 檢查使用者是否有權限停權其他使用者
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostAdminUserUserIDSuspend401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostAdminUserUserIDSuspend401Response{}, nil
+	}
+	//  - 檢查使用者是否為管理員
+	if admin, err := impl.isActiveAdmin(token); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user role, err=%w", op, err)
+	} else if !admin {
+		return openapi.PostAdminUserUserIDSuspend403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 檢查操作原因
+	reason := strings.TrimSpace(request.Body.Reason)
+	if len(reason) == 0 {
+		return openapi.PostAdminUserUserIDSuspend400JSONResponse{
+			Message: lo.ToPtr("Reason is required"),
+		}, nil
+	}
+	// 管理員不能停權自己，避免系統中沒有可用的管理員
+	if token.Subject == request.UserID.String() {
+		return openapi.PostAdminUserUserIDSuspend400JSONResponse{
+			Message: lo.ToPtr("Cannot suspend yourself"),
+		}, nil
+	}
+	// 檢查使用者是否存在
+	user := models.User{ID: request.UserID}
+	if result := impl.db.First(&user); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return openapi.PostAdminUserUserIDSuspend404Response{}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to find user, err=%w", op, result.Error)
+	}
+	if user.SuspendedAt != nil {
+		return openapi.PostAdminUserUserIDSuspend409Response{}, nil
+	}
+	// 停權使用者並記錄稽核紀錄
+	err = impl.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Model(&user).Update("suspended_at", time.Now()); result.Error != nil {
+			return fmt.Errorf("fail to suspend user, err=%w", result.Error)
+		}
+		return impl.createAuditLog(tx, token, models.AuditActionSuspendUser, user.ID, reason)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	slog.Info("User suspended", slog.String("admin", token.Subject), slog.String("user", user.ID.String()), slog.String("reason", reason))
+	return openapi.PostAdminUserUserIDSuspend200Response{}, nil
+}
+
+// Unsuspend a user
+// (POST /admin/user/{userID}/unsuspend)
+func (impl *ServerImpl) PostAdminUserUserIDUnsuspend(ctx context.Context, request openapi.PostAdminUserUserIDUnsuspendRequestObject) (openapi.PostAdminUserUserIDUnsuspendResponseObject, error) {
+	const op = "PostAdminUserUserIDUnsuspend"
+	// 檢查使用者是否有權限解除其他使用者的停權
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostAdminUserUserIDUnsuspend401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostAdminUserUserIDUnsuspend401Response{}, nil
+	}
+	//  - 檢查使用者是否為管理員
+	if admin, err := impl.isActiveAdmin(token); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user role, err=%w", op, err)
+	} else if !admin {
+		return openapi.PostAdminUserUserIDUnsuspend403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 檢查操作原因
+	reason := strings.TrimSpace(request.Body.Reason)
+	if len(reason) == 0 {
+		return openapi.PostAdminUserUserIDUnsuspend400JSONResponse{
+			Message: lo.ToPtr("Reason is required"),
+		}, nil
+	}
+	// 檢查使用者是否存在
+	user := models.User{ID: request.UserID}
+	if result := impl.db.First(&user); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return openapi.PostAdminUserUserIDUnsuspend404Response{}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to find user, err=%w", op, result.Error)
+	}
+	if user.SuspendedAt == nil {
+		return openapi.PostAdminUserUserIDUnsuspend409Response{}, nil
+	}
+	// 解除停權並記錄稽核紀錄
+	err = impl.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Model(&user).Update("suspended_at", nil); result.Error != nil {
+			return fmt.Errorf("fail to unsuspend user, err=%w", result.Error)
+		}
+		return impl.createAuditLog(tx, token, models.AuditActionUnsuspendUser, user.ID, reason)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	slog.Info("User unsuspended", slog.String("admin", token.Subject), slog.String("user", user.ID.String()), slog.String("reason", reason))
+	return openapi.PostAdminUserUserIDUnsuspend200Response{}, nil
+}
+
+// Force-cancel an auction
+// (POST /admin/auction/item/{itemID}/cancel)
+func (impl *ServerImpl) PostAdminAuctionItemItemIDCancel(ctx context.Context, request openapi.PostAdminAuctionItemItemIDCancelRequestObject) (openapi.PostAdminAuctionItemItemIDCancelResponseObject, error) {
+	const op = "PostAdminAuctionItemItemIDCancel"
+	// 檢查使用者是否有權限下架拍賣物品
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostAdminAuctionItemItemIDCancel401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostAdminAuctionItemItemIDCancel401Response{}, nil
+	}
+	//  - 檢查使用者是否為管理員
+	if admin, err := impl.isActiveAdmin(token); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user role, err=%w", op, err)
+	} else if !admin {
+		return openapi.PostAdminAuctionItemItemIDCancel403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 檢查操作原因
+	reason := strings.TrimSpace(request.Body.Reason)
+	if len(reason) == 0 {
+		return openapi.PostAdminAuctionItemItemIDCancel400JSONResponse{
+			Message: lo.ToPtr("Reason is required"),
+		}, nil
+	}
+	// 檢查拍賣物品是否存在
+	auction := models.AuctionItem{ID: request.ItemID}
+	if result := impl.db.First(&auction); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return openapi.PostAdminAuctionItemItemIDCancel404Response{}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to find auction item, err=%w", op, result.Error)
+	}
+	// 已經下架或已經結算的拍賣物品不能再下架
+	if status := auction.EffectiveStatus(time.Now()); status == openapi.Cancelled || status == openapi.Settled {
+		return openapi.PostAdminAuctionItemItemIDCancel409Response{}, nil
+	}
+	// 下架拍賣物品並記錄稽核紀錄
+	// NOTE: 下架後出價和同步出價時都會檢查下架狀態，所以不需要處理Redis中的最高競價
+	err = impl.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Model(&auction).Update("status", openapi.Cancelled); result.Error != nil {
+			return fmt.Errorf("fail to cancel auction item, err=%w", result.Error)
+		}
+		auction.Status = openapi.Cancelled
+		if err := enqueueAuctionEvent(tx, models.OutboxEventAuctionCancelled, auction); err != nil {
+			return err
+		}
+		return impl.createAuditLog(tx, token, models.AuditActionCancelAuction, auction.ID, reason)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	slog.Info("Auction cancelled", slog.String("admin", token.Subject), slog.String("auctionID", auction.ID.String()), slog.String("reason", reason))
+	return openapi.PostAdminAuctionItemItemIDCancel200Response{}, nil
+}
+
+// Remove a bid
+// (POST /admin/auction/bid/{bidID}/remove)
+func (impl *ServerImpl) PostAdminAuctionBidBidIDRemove(ctx context.Context, request openapi.PostAdminAuctionBidBidIDRemoveRequestObject) (openapi.PostAdminAuctionBidBidIDRemoveResponseObject, error) {
+	const op = "PostAdminAuctionBidBidIDRemove"
+	// 檢查使用者是否有權限移除出價紀錄
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostAdminAuctionBidBidIDRemove401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostAdminAuctionBidBidIDRemove401Response{}, nil
+	}
+	//  - 檢查使用者是否為管理員
+	if admin, err := impl.isActiveAdmin(token); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user role, err=%w", op, err)
+	} else if !admin {
+		return openapi.PostAdminAuctionBidBidIDRemove403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 檢查操作原因
+	reason := strings.TrimSpace(request.Body.Reason)
+	if len(reason) == 0 {
+		return openapi.PostAdminAuctionBidBidIDRemove400JSONResponse{
+			Message: lo.ToPtr("Reason is required"),
+		}, nil
+	}
+	// 檢查出價紀錄是否存在(包含已被移除的紀錄)
+	bid := models.Bid{ID: request.BidID}
+	if result := impl.db.Unscoped().First(&bid); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return openapi.PostAdminAuctionBidBidIDRemove404Response{}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to find bid, err=%w", op, result.Error)
+	}
+	if bid.DeletedAt.Valid {
+		return openapi.PostAdminAuctionBidBidIDRemove409Response{}, nil
+	}
+	// 移除出價紀錄並記錄稽核紀錄
+	// 如果移除的是目前的最高出價，需要回退到剩下的最高出價，沒有剩下的出價則回退到起標價
+	auction := models.AuctionItem{ID: bid.AuctionItemID}
+	isCurrentBid := false
+	var fallbackBid uint32
+	err = impl.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Delete(&bid); result.Error != nil {
+			return fmt.Errorf("fail to remove bid, err=%w", result.Error)
+		}
+		if result := tx.First(&auction); result.Error != nil {
+			return fmt.Errorf("fail to find auction item, err=%w", result.Error)
+		}
+		if auction.CurrentBidID != nil && *auction.CurrentBidID == bid.ID {
+			isCurrentBid = true
+			var nextBid models.Bid
+			result := tx.Where("auction_item_id = ?", auction.ID).Order("amount DESC").Limit(1).Find(&nextBid)
+			if result.Error != nil {
+				return fmt.Errorf("fail to find next highest bid, err=%w", result.Error)
+			}
+			var nextBidID *uuid.UUID
+			fallbackBid = auction.StartingPrice
+			if result.RowsAffected > 0 {
+				nextBidID = &nextBid.ID
+				fallbackBid = nextBid.Amount
+			}
+			if result := tx.Model(&auction).Update("current_bid_id", nextBidID); result.Error != nil {
+				return fmt.Errorf("fail to update current bid, err=%w", result.Error)
+			}
+		}
+		return impl.createAuditLog(tx, token, models.AuditActionRemoveBid, bid.ID, reason)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	// 回退Redis中的最高競價
+	// NOTE: 如果Redis中的最高競價已經高於被移除的出價(還在stream中等待同步的出價)，不會做任何處理
+	if isCurrentBid {
+		if err := impl.bidStore.RevertBid(ctx, auction.ID, bid.Amount, fallbackBid); err != nil {
+			return nil, fmt.Errorf("[%s] %w", op, err)
+		}
+	}
+	slog.Info("Bid removed", slog.String("admin", token.Subject), slog.String("bidID", bid.ID.String()), slog.String("auctionID", auction.ID.String()), slog.String("reason", reason))
+	return openapi.PostAdminAuctionBidBidIDRemove200Response{}, nil
+}
+
+// List shill bidding findings
+// (GET /admin/shill/findings)
+func (impl *ServerImpl) GetAdminShillFindings(ctx context.Context, request openapi.GetAdminShillFindingsRequestObject) (openapi.GetAdminShillFindingsResponseObject, error) {
+	const op = "GetAdminShillFindings"
+	// 檢查使用者是否有權限查看可疑出價
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.GetAdminShillFindings401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.GetAdminShillFindings401Response{}, nil
+	}
+	//  - 檢查使用者是否為管理員
+	if admin, err := impl.isActiveAdmin(token); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user role, err=%w", op, err)
+	} else if !admin {
+		return openapi.GetAdminShillFindings403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 建立查詢，由新到舊排序(ID為uuid v7，可以直接作為時間順序的cursor)
+	query := impl.db.Preload("User").Model(&models.ShillFinding{}).Order("id DESC")
+	//  - status
+	if request.Params.Status != nil {
+		switch *request.Params.Status {
+		case openapi.Pending, openapi.Confirmed, openapi.Dismissed:
+			query = query.Where("status = ?", *request.Params.Status)
+		default:
+			return openapi.GetAdminShillFindings400JSONResponse{
+				Message: lo.ToPtr("Invalid status"),
+			}, nil
+		}
+	}
+	//  - cursor
+	if request.Params.LastFindingID != nil {
+		query = query.Where("id < ?", *request.Params.LastFindingID)
+	}
+	//  - size
+	size := uint32(20)
+	if request.Params.Size != nil {
+		size = *request.Params.Size
+	}
+	query = query.Limit(int(size))
+	// 查詢可疑出價
+	var findings []models.ShillFinding
+	if result := query.Find(&findings); result.Error != nil {
+		return nil, fmt.Errorf("[%s] Fail to list shill findings, err=%w", op, result.Error)
+	}
+	output := make([]openapi.ShillFinding, len(findings))
+	for i, finding := range findings {
+		output[i] = openapi.ShillFinding{
+			Id:     finding.ID,
+			Rule:   finding.Rule,
+			ItemID: finding.AuctionItemID,
+			UserID: finding.UserID,
+			RelatedUserIDs: lo.FilterMap(finding.RelatedUserIDs, func(id string, _ int) (uuid.UUID, bool) {
+				parsed, err := uuid.Parse(id)
+				return parsed, err == nil
+			}),
+			Username:   finding.User.Username,
+			Detail:     finding.Detail,
+			Status:     finding.Status,
+			CreatedAt:  finding.CreatedAt,
+			ReviewerID: finding.ReviewerID,
+			ReviewedAt: finding.ReviewedAt,
+		}
+		if finding.ReviewedAt != nil {
+			output[i].ReviewNote = lo.ToPtr(finding.ReviewNote)
+		}
+	}
+	return openapi.GetAdminShillFindings200JSONResponse{
+		Count:    len(output),
+		Findings: output,
+	}, nil
+}
+
+// Resolve a shill bidding finding
+// (POST /admin/shill/findings/{findingID}/resolve)
+func (impl *ServerImpl) PostAdminShillFindingsFindingIDResolve(ctx context.Context, request openapi.PostAdminShillFindingsFindingIDResolveRequestObject) (openapi.PostAdminShillFindingsFindingIDResolveResponseObject, error) {
+	const op = "PostAdminShillFindingsFindingIDResolve"
+	// 檢查使用者是否有權限審查可疑出價
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostAdminShillFindingsFindingIDResolve401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostAdminShillFindingsFindingIDResolve401Response{}, nil
+	}
+	//  - 檢查使用者是否為管理員
+	if admin, err := impl.isActiveAdmin(token); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user role, err=%w", op, err)
+	} else if !admin {
+		return openapi.PostAdminShillFindingsFindingIDResolve403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 檢查審查結果和原因
+	if request.Body.Status != openapi.Confirmed && request.Body.Status != openapi.Dismissed {
+		return openapi.PostAdminShillFindingsFindingIDResolve400JSONResponse{
+			Message: lo.ToPtr("Status must be confirmed or dismissed"),
+		}, nil
+	}
+	reason := strings.TrimSpace(request.Body.Reason)
+	if len(reason) == 0 {
+		return openapi.PostAdminShillFindingsFindingIDResolve400JSONResponse{
+			Message: lo.ToPtr("Reason is required"),
+		}, nil
+	}
+	// 檢查可疑出價是否存在
+	finding := models.ShillFinding{ID: request.FindingID}
+	if result := impl.db.First(&finding); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return openapi.PostAdminShillFindingsFindingIDResolve404Response{}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to find shill finding, err=%w", op, result.Error)
+	}
+	if finding.Status != openapi.Pending {
+		return openapi.PostAdminShillFindingsFindingIDResolve409Response{}, nil
+	}
+	// 更新審查結果並記錄稽核紀錄
+	//  - 只更新仍在待審查狀態的紀錄，避免多個管理員同時審查時重複記錄
+	errResolved := errors.New("shill finding is already resolved")
+	err = impl.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&finding).Where("status = ?", openapi.Pending).Updates(map[string]any{
+			"status":      request.Body.Status,
+			"reviewer_id": uuid.MustParse(token.Subject),
+			"reviewed_at": time.Now(),
+			"review_note": reason,
+		})
+		if result.Error != nil {
+			return fmt.Errorf("fail to resolve shill finding, err=%w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errResolved
+		}
+		return impl.createAuditLog(tx, token, models.AuditActionResolveShillFinding, finding.ID, reason)
+	})
+	if errors.Is(err, errResolved) {
+		return openapi.PostAdminShillFindingsFindingIDResolve409Response{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	slog.Info("Shill finding resolved", slog.String("admin", token.Subject), slog.String("finding", finding.ID.String()), slog.String("status", string(request.Body.Status)))
+	return openapi.PostAdminShillFindingsFindingIDResolve200Response{}, nil
+}
+
+// isActiveAdmin 檢查使用者是否為未被停權的管理員
+// NOTE: access token 中的角色只用於快速排除一般使用者，實際權限以資料庫為準，避免被撤銷的管理員在 token 過期前仍可操作
+func (impl *ServerImpl) isActiveAdmin(token *openapi.JWT) (bool, error) {
+	if token.Role != openapi.Admin {
+		return false, nil
+	}
+	var count int64
+	if result := impl.db.Model(&models.User{}).Where("id = ? AND role = ? AND suspended_at IS NULL", token.Subject, openapi.Admin).Count(&count); result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+// createAuditLog 在指定的交易中建立稽核紀錄
+func (impl *ServerImpl) createAuditLog(tx *gorm.DB, token *openapi.JWT, action models.AuditAction, targetID uuid.UUID, reason string) error {
+	return impl.createAuditLogWithRef(tx, token, action, targetID, "", reason)
+}
+
+// createAuditLogWithRef 在指定的交易中建立稽核紀錄，並記錄不是資料庫紀錄的操作對象
+func (impl *ServerImpl) createAuditLogWithRef(tx *gorm.DB, token *openapi.JWT, action models.AuditAction, targetID uuid.UUID, targetRef string, reason string) error {
+	auditLog := models.AuditLog{
+		ActorID:   uuid.MustParse(token.Subject),
+		Action:    action,
+		TargetID:  targetID,
+		TargetRef: targetRef,
+		Reason:    reason,
+	}
+	if result := tx.Create(&auditLog); result.Error != nil {
+		return fmt.Errorf("fail to create audit log, err=%w", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,359 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	internalS3 "q4/adapters/s3"
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// importMaxFileSize 批次匯入檔案的大小上限
+const importMaxFileSize = 10 << 20
+
+// importCarouselSeparator CSV 中分隔多個圖片網址的字元
+const importCarouselSeparator = "|"
+
+// importRow 代表匯入檔案中的一筆資料
+type importRow struct {
+	// 資料在檔案中的序號，從1開始，不包含 CSV 的標題列
+	Row int
+	// 對應到新增拍賣物品的請求內容
+	Body openapi.PostAuctionItemJSONRequestBody
+	// 資料無法解析時的錯誤訊息
+	Err string
+}
+
+// parseImportRows 依照檔案格式解析匯入的資料，單筆資料解析失敗會記錄在該筆資料中，
+// 只有整個檔案無法處理時才回傳錯誤
+func parseImportRows(format openapi.FileFormat, r io.Reader, maxRows int) ([]importRow, error) {
+	switch format {
+	case openapi.Csv:
+		return parseImportCSV(r, maxRows)
+	case openapi.Ndjson:
+		return parseImportNDJSON(r, maxRows)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// parseImportCSV 解析 CSV 格式的匯入資料，第一列為欄位名稱，欄位名稱和新增拍賣物品的 JSON 欄位相同
+func parseImportCSV(r io.Reader, maxRows int) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fail to read header row, err=%w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "title", "description", "startingPrice", "reservePrice", "startTime", "endTime", "carousels", "draft", "autoRelistRounds":
+		default:
+			return nil, fmt.Errorf("unknown column: %s", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("duplicate column: %s", name)
+		}
+		columns[name] = i
+	}
+	for _, name := range []string{"title", "endTime"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column: %s", name)
+		}
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fail to read row %d, err=%w", len(rows)+1, err)
+		}
+		if len(rows) >= maxRows {
+			return nil, fmt.Errorf("too many rows, at most %d rows are allowed", maxRows)
+		}
+		row := importRow{Row: len(rows) + 1}
+		if err := parseImportRecord(columns, record, &row.Body); err != nil {
+			row.Err = err.Error()
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseImportRecord 將 CSV 的一列轉換成新增拍賣物品的請求內容，空白的欄位視為未提供
+func parseImportRecord(columns map[string]int, record []string, body *openapi.PostAuctionItemJSONRequestBody) error {
+	value := func(name string) (string, bool) {
+		i, ok := columns[name]
+		if !ok {
+			return "", false
+		}
+		v := strings.TrimSpace(record[i])
+		return v, v != ""
+	}
+	parseInt := func(name string) (*int64, error) {
+		v, ok := value(name)
+		if !ok {
+			return nil, nil
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s: %s", name, v)
+		}
+		return &n, nil
+	}
+	parseTime := func(name string) (*time.Time, error) {
+		v, ok := value(name)
+		if !ok {
+			return nil, nil
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s: %s", name, v)
+		}
+		return &t, nil
+	}
+
+	var err error
+	body.Title, _ = value("title")
+	if v, ok := value("description"); ok {
+		body.Description = &v
+	}
+	if body.StartingPrice, err = parseInt("startingPrice"); err != nil {
+		return err
+	}
+	if body.ReservePrice, err = parseInt("reservePrice"); err != nil {
+		return err
+	}
+	if body.AutoRelistRounds, err = parseInt("autoRelistRounds"); err != nil {
+		return err
+	}
+	if body.StartTime, err = parseTime("startTime"); err != nil {
+		return err
+	}
+	endTime, err := parseTime("endTime")
+	if err != nil {
+		return err
+	}
+	if endTime == nil {
+		return errors.New("End time is required")
+	}
+	body.EndTime = *endTime
+	if v, ok := value("carousels"); ok {
+		carousels := lo.Compact(lo.Map(strings.Split(v, importCarouselSeparator), func(s string, _ int) string {
+			return strings.TrimSpace(s)
+		}))
+		body.Carousels = &carousels
+	}
+	if v, ok := value("draft"); ok {
+		draft, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("Invalid draft: %s", v)
+		}
+		body.Draft = &draft
+	}
+	return nil
+}
+
+// parseImportNDJSON 解析 NDJSON 格式的匯入資料，每一行是一個新增拍賣物品的 JSON 物件，空白行會被略過
+func parseImportNDJSON(r io.Reader, maxRows int) ([]importRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), importMaxFileSize)
+	var rows []importRow
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if len(rows) >= maxRows {
+			return nil, fmt.Errorf("too many rows, at most %d rows are allowed", maxRows)
+		}
+		row := importRow{Row: len(rows) + 1}
+		if err := json.Unmarshal([]byte(line), &row.Body); err != nil {
+			row.Err = "Invalid JSON object"
+		} else if row.Body.EndTime.IsZero() {
+			row.Err = "End time is required"
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("fail to read row %d, err=%w", len(rows)+1, err)
+	}
+	return rows, nil
+}
+
+// Import auction items in bulk
+// (POST /auction/items/import)
+func (impl *ServerImpl) PostAuctionItemsImport(ctx context.Context, request openapi.PostAuctionItemsImportRequestObject) (openapi.PostAuctionItemsImportResponseObject, error) {
+	const op = "PostAuctionItemsImport"
+	// 檢查使用者是否有權限新增拍賣物品
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostAuctionItemsImport401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostAuctionItemsImport401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PostAuctionItemsImport403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	// 解析匯入的檔案
+	body := internalS3.NewMaxSizeReader(request.Body, importMaxFileSize)
+	rows, err := parseImportRows(request.Params.Format, body, impl.config.Auction.ImportMaxRows)
+	if err != nil {
+		return openapi.PostAuctionItemsImport400JSONResponse{
+			Message: lo.ToPtr(fmt.Sprintf("Invalid file: %s", err)),
+		}, nil
+	}
+	if len(rows) == 0 {
+		return openapi.PostAuctionItemsImport400JSONResponse{
+			Message: lo.ToPtr("Invalid file: no rows"),
+		}, nil
+	}
+	// 使用和單筆新增相同的規則檢查每一筆資料
+	userID := uuid.MustParse(token.Subject)
+	now := time.Now()
+	results := make([]openapi.AuctionItemImportResult, len(rows))
+	auctions := make([]models.AuctionItem, len(rows))
+	for i, row := range rows {
+		results[i].Row = row.Row
+		if row.Err != "" {
+			results[i].Message = lo.ToPtr(row.Err)
+			continue
+		}
+		auction, msg := impl.auctionItemFromRequest(userID, row.Body, now)
+		if msg != "" {
+			results[i].Message = lo.ToPtr(msg)
+			continue
+		}
+		auctions[i] = auction
+	}
+	//  - 圖片必須是使用者先前透過 PostImage 上傳的圖片
+	if err := impl.checkImportCarousels(userID, auctions, results); err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	valid := lo.Filter(lo.Range(len(rows)), func(i int, _ int) bool { return results[i].Message == nil })
+	// 儲存拍賣物品
+	//  - 完整匯入時，任何一筆資料不合法就不寫入任何資料
+	if lo.FromPtrOr(request.Params.Atomic, true) {
+		if len(valid) != len(rows) {
+			return openapi.PostAuctionItemsImport422JSONResponse(importReport(results)), nil
+		}
+		err := impl.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.CreateInBatches(&auctions, impl.config.Auction.ImportChunkSize).Error; err != nil {
+				return err
+			}
+			return enqueueAuctionEvents(tx, models.OutboxEventAuctionCreated, auctions)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[%s] Fail to create auction items, err=%w", op, err)
+		}
+		for i := range auctions {
+			results[i].Id = &auctions[i].ID
+		}
+		return openapi.PostAuctionItemsImport200JSONResponse(importReport(results)), nil
+	}
+	//  - 部分匯入時，略過不合法的資料並分批寫入，避免單一交易過大
+	for _, chunk := range lo.Chunk(valid, impl.config.Auction.ImportChunkSize) {
+		batch := lo.Map(chunk, func(i int, _ int) models.AuctionItem { return auctions[i] })
+		err := impl.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&batch).Error; err != nil {
+				return err
+			}
+			return enqueueAuctionEvents(tx, models.OutboxEventAuctionCreated, batch)
+		})
+		if err == nil {
+			for j, i := range chunk {
+				results[i].Id = &batch[j].ID
+			}
+			continue
+		}
+		// 整批寫入失敗時改為逐筆寫入，只讓有問題的資料失敗
+		slog.Warn("Fail to create auction items in batch, retry one by one", slog.String("op", op), slog.Any("error", err))
+		for _, i := range chunk {
+			err := impl.db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Create(&auctions[i]).Error; err != nil {
+					return err
+				}
+				return enqueueAuctionEvent(tx, models.OutboxEventAuctionCreated, auctions[i])
+			})
+			if err != nil {
+				slog.Error("Fail to create auction item", slog.String("op", op), slog.Int("row", results[i].Row), slog.Any("error", err))
+				results[i].Message = lo.ToPtr("Fail to create auction item")
+				continue
+			}
+			results[i].Id = &auctions[i].ID
+		}
+	}
+	return openapi.PostAuctionItemsImport200JSONResponse(importReport(results)), nil
+}
+
+// checkImportCarousels 檢查匯入資料的圖片網址是否都由使用者上傳，不合法的資料會在結果中記錄錯誤訊息
+func (impl *ServerImpl) checkImportCarousels(userID uuid.UUID, auctions []models.AuctionItem, results []openapi.AuctionItemImportResult) error {
+	var urls []string
+	for i, auction := range auctions {
+		if results[i].Message == nil {
+			urls = append(urls, auction.Carousels...)
+		}
+	}
+	urls = lo.Uniq(urls)
+	if len(urls) == 0 {
+		return nil
+	}
+	known, err := impl.uploadedImages(userID, urls)
+	if err != nil {
+		return err
+	}
+	for i, auction := range auctions {
+		if results[i].Message != nil {
+			continue
+		}
+		for _, url := range auction.Carousels {
+			if _, ok := known[url]; !ok {
+				results[i].Message = lo.ToPtr(fmt.Sprintf("Image is not uploaded by user: %s", url))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// importReport 統計每一筆資料的匯入結果
+func importReport(results []openapi.AuctionItemImportResult) openapi.AuctionItemImportReport {
+	created := lo.CountBy(results, func(r openapi.AuctionItemImportResult) bool { return r.Id != nil })
+	return openapi.AuctionItemImportReport{
+		Total:   len(results),
+		Created: created,
+		Failed:  len(results) - created,
+		Results: results,
+	}
+}
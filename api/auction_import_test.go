@@ -0,0 +1,325 @@
+package api
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"q4/api/openapi"
+	"q4/models"
+)
+
+func TestParseImportRows_CSV(t *testing.T) {
+	endTime := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("解析所有欄位", func(t *testing.T) {
+		file := "title,description,startingPrice,reservePrice,startTime,endTime,carousels,draft,autoRelistRounds\n" +
+			"Item 1,<b>desc</b>,100,200,2030-01-01T00:00:00Z,2030-01-02T03:04:05Z,https://a/1.png|https://a/2.png,true,2\n"
+		rows, err := parseImportRows(openapi.Csv, strings.NewReader(file), 10)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, importRow{
+			Row: 1,
+			Body: openapi.PostAuctionItemJSONRequestBody{
+				Title:            "Item 1",
+				Description:      lo.ToPtr("<b>desc</b>"),
+				StartingPrice:    lo.ToPtr(int64(100)),
+				ReservePrice:     lo.ToPtr(int64(200)),
+				StartTime:        lo.ToPtr(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+				EndTime:          endTime,
+				Carousels:        lo.ToPtr([]string{"https://a/1.png", "https://a/2.png"}),
+				Draft:            lo.ToPtr(true),
+				AutoRelistRounds: lo.ToPtr(int64(2)),
+			},
+		}, rows[0])
+	})
+
+	t.Run("空白的欄位視為未提供", func(t *testing.T) {
+		file := "title,endTime,startingPrice\nItem 1,2030-01-02T03:04:05Z,\n"
+		rows, err := parseImportRows(openapi.Csv, strings.NewReader(file), 10)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, openapi.PostAuctionItemJSONRequestBody{Title: "Item 1", EndTime: endTime}, rows[0].Body)
+		assert.Empty(t, rows[0].Err)
+	})
+
+	t.Run("單筆資料錯誤只記錄在該筆資料", func(t *testing.T) {
+		file := "title,endTime,startingPrice\n" +
+			"Item 1,2030-01-02T03:04:05Z,abc\n" +
+			"Item 2,tomorrow,\n" +
+			"Item 3,,\n" +
+			"Item 4,2030-01-02T03:04:05Z,10\n"
+		rows, err := parseImportRows(openapi.Csv, strings.NewReader(file), 10)
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		assert.Equal(t, "Invalid startingPrice: abc", rows[0].Err)
+		assert.Equal(t, "Invalid endTime: tomorrow", rows[1].Err)
+		assert.Equal(t, "End time is required", rows[2].Err)
+		assert.Empty(t, rows[3].Err)
+		assert.Equal(t, []int{1, 2, 3, 4}, lo.Map(rows, func(r importRow, _ int) int { return r.Row }))
+	})
+
+	tests := []struct {
+		name string
+		file string
+	}{
+		{name: "沒有標題列", file: ""},
+		{name: "未知的欄位", file: "title,endTime,price\n"},
+		{name: "重複的欄位", file: "title,endTime,title\n"},
+		{name: "缺少必要欄位", file: "title,description\n"},
+		{name: "欄位數量不一致", file: "title,endTime\nItem 1\n"},
+		{name: "超過筆數上限", file: "title,endTime\na,2030-01-02T03:04:05Z\nb,2030-01-02T03:04:05Z\nc,2030-01-02T03:04:05Z\n"},
+	}
+	for _, tt := range tests {
+		t.Run("檔案錯誤:"+tt.name, func(t *testing.T) {
+			_, err := parseImportRows(openapi.Csv, strings.NewReader(tt.file), 2)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseImportRows_NDJSON(t *testing.T) {
+	t.Run("略過空白行並記錄單筆錯誤", func(t *testing.T) {
+		file := `{"title":"Item 1","endTime":"2030-01-02T03:04:05Z","startingPrice":10}` + "\n\n" +
+			`{"title":"Item 2"}` + "\n" +
+			`not json` + "\n"
+		rows, err := parseImportRows(openapi.Ndjson, strings.NewReader(file), 10)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Equal(t, openapi.PostAuctionItemJSONRequestBody{
+			Title:         "Item 1",
+			EndTime:       time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+			StartingPrice: lo.ToPtr(int64(10)),
+		}, rows[0].Body)
+		assert.Empty(t, rows[0].Err)
+		assert.Equal(t, 2, rows[1].Row)
+		assert.Equal(t, "End time is required", rows[1].Err)
+		assert.Equal(t, 3, rows[2].Row)
+		assert.Equal(t, "Invalid JSON object", rows[2].Err)
+	})
+
+	t.Run("超過筆數上限", func(t *testing.T) {
+		file := strings.Repeat(`{"title":"Item","endTime":"2030-01-02T03:04:05Z"}`+"\n", 3)
+		_, err := parseImportRows(openapi.Ndjson, strings.NewReader(file), 2)
+		assert.Error(t, err)
+	})
+}
+
+// newTestAccessToken 使用 impl 的私鑰簽發指定使用者的 access token
+func newTestAccessToken(tb testing.TB, impl *ServerImpl, user models.User) *string {
+	token := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, openapi.JWT{
+		Username: user.Username,
+		Role:     user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Subject:   user.ID.String(),
+			ID:        uuid.NewString(),
+		},
+	})
+	signed, err := token.SignedString(impl.config.Auth.PrivateKey)
+	require.NoError(tb, err)
+	return &signed
+}
+
+// newTestListingServer 建立測試新增拍賣物品用的 ServerImpl
+func newTestListingServer(tb testing.TB) *ServerImpl {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(tb, err)
+	return &ServerImpl{
+		htmlChecker: bluemonday.UGCPolicy(),
+		config: ServerConfig{
+			Auth:    AuthConfig{PrivateKey: privateKey},
+			Auction: AuctionConfig{MaxAutoRelistRounds: 3, ImportMaxRows: 100, ImportChunkSize: 2},
+		},
+	}
+}
+
+func TestAuctionItemFromRequest(t *testing.T) {
+	impl := newTestListingServer(t)
+	userID := uuid.New()
+	now := time.Now()
+
+	t.Run("套用預設值並過濾描述的 HTML", func(t *testing.T) {
+		auction, msg := impl.auctionItemFromRequest(userID, openapi.PostAuctionItemJSONRequestBody{
+			Title:       "Item",
+			Description: lo.ToPtr(`<b>desc</b><script>alert(1)</script>`),
+			EndTime:     now.Add(time.Hour),
+		}, now)
+		require.Empty(t, msg)
+		assert.Equal(t, userID, auction.UserID)
+		assert.Equal(t, "<b>desc</b>", auction.Description)
+		assert.Equal(t, now, auction.StartTime)
+		assert.Equal(t, uint32(0), auction.StartingPrice)
+		assert.Equal(t, uint32(0), auction.ReservePrice)
+		assert.Empty(t, auction.Carousels)
+		assert.Equal(t, openapi.Live, auction.Status)
+	})
+
+	t.Run("草稿和排程", func(t *testing.T) {
+		auction, msg := impl.auctionItemFromRequest(userID, openapi.PostAuctionItemJSONRequestBody{
+			Title: "Item", Draft: lo.ToPtr(true), EndTime: now.Add(time.Hour),
+		}, now)
+		require.Empty(t, msg)
+		assert.Equal(t, openapi.Draft, auction.Status)
+
+		auction, msg = impl.auctionItemFromRequest(userID, openapi.PostAuctionItemJSONRequestBody{
+			Title: "Item", StartTime: lo.ToPtr(now.Add(time.Hour)), EndTime: now.Add(2 * time.Hour),
+		}, now)
+		require.Empty(t, msg)
+		assert.Equal(t, openapi.Scheduled, auction.Status)
+	})
+
+	tests := []struct {
+		name string
+		body openapi.PostAuctionItemJSONRequestBody
+		want string
+	}{
+		{name: "沒有標題", body: openapi.PostAuctionItemJSONRequestBody{Title: " ", EndTime: now.Add(time.Hour)}, want: "Title is required"},
+		{name: "起標價為負數", body: openapi.PostAuctionItemJSONRequestBody{Title: "Item", StartingPrice: lo.ToPtr(int64(-1)), EndTime: now.Add(time.Hour)}, want: "Invalid starting price"},
+		{name: "自動重新上架次數超過上限", body: openapi.PostAuctionItemJSONRequestBody{Title: "Item", AutoRelistRounds: lo.ToPtr(int64(4)), EndTime: now.Add(time.Hour)}, want: "Auto relist rounds must be between 0 and 3"},
+		{name: "結束時間已經過去", body: openapi.PostAuctionItemJSONRequestBody{Title: "Item", EndTime: now.Add(-time.Hour)}, want: "Invalid auction time"},
+		{name: "結束時間早於開始時間", body: openapi.PostAuctionItemJSONRequestBody{Title: "Item", StartTime: lo.ToPtr(now.Add(2 * time.Hour)), EndTime: now.Add(time.Hour)}, want: "Invalid auction time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, msg := impl.auctionItemFromRequest(userID, tt.body, now)
+			assert.Equal(t, tt.want, msg)
+		})
+	}
+}
+
+// setupImportTest 建立測試匯入用的 ServerImpl 和使用者，需要 Q4_TEST_DSN
+func setupImportTest(t *testing.T) (*ServerImpl, models.User, *string) {
+	db, user, _ := setupBidSyncDB(t)
+	impl := newTestListingServer(t)
+	impl.db = db
+	t.Cleanup(func() {
+		var ids []uuid.UUID
+		db.Unscoped().Model(&models.AuctionItem{}).Where("user_id = ?", user.ID).Pluck("id", &ids)
+		db.Unscoped().Where("aggregate_id IN ?", append(ids, uuid.Nil)).Delete(&models.OutboxEvent{})
+		db.Unscoped().Where("user_id = ?", user.ID).Delete(&models.AuctionItem{})
+		db.Unscoped().Where("uploader_id = ?", user.ID).Delete(&models.Image{})
+	})
+	return impl, user, newTestAccessToken(t, impl, user)
+}
+
+func TestPostAuctionItemsImport(t *testing.T) {
+	ctx := context.Background()
+	endTime := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	t.Run("匯入的資料使用和單筆新增相同的規則", func(t *testing.T) {
+		impl, user, token := setupImportTest(t)
+		file := "title,description,startingPrice,startTime,endTime,draft\n" +
+			"Item 1,<b>desc</b><script>alert(1)</script>,,," + endTime + ",\n" +
+			"Item 2,,-1,," + endTime + ",\n" +
+			"Item 3,,,," + time.Now().Add(-time.Hour).UTC().Format(time.RFC3339) + ",\n" +
+			"Item 4,,,2000-01-01T00:00:00Z," + endTime + ",true\n"
+		resp, err := impl.PostAuctionItemsImport(ctx, openapi.PostAuctionItemsImportRequestObject{
+			Params: openapi.PostAuctionItemsImportParams{AccessToken: token, Format: openapi.Csv, Atomic: lo.ToPtr(false)},
+			Body:   strings.NewReader(file),
+		})
+		require.NoError(t, err)
+		require.IsType(t, openapi.PostAuctionItemsImport200JSONResponse{}, resp)
+		report := resp.(openapi.PostAuctionItemsImport200JSONResponse)
+		assert.Equal(t, 4, report.Total)
+		assert.Equal(t, 2, report.Created)
+		assert.Equal(t, "Invalid starting price", lo.FromPtr(report.Results[1].Message))
+		assert.Equal(t, "Invalid auction time", lo.FromPtr(report.Results[2].Message))
+
+		var created models.AuctionItem
+		require.NoError(t, impl.db.Where("id = ?", report.Results[0].Id).First(&created).Error)
+		assert.Equal(t, user.ID, created.UserID)
+		assert.Equal(t, "<b>desc</b>", created.Description)
+		assert.Equal(t, uint32(0), created.StartingPrice)
+		assert.Equal(t, openapi.Live, created.Status)
+		require.NoError(t, impl.db.Where("id = ?", report.Results[3].Id).First(&created).Error)
+		assert.Equal(t, openapi.Draft, created.Status)
+	})
+
+	t.Run("完整匯入時任何一筆不合法就不寫入", func(t *testing.T) {
+		impl, user, token := setupImportTest(t)
+		file := "title,startingPrice,endTime\n" +
+			"Item 1,," + endTime + "\n" +
+			"Item 2,-1," + endTime + "\n"
+		resp, err := impl.PostAuctionItemsImport(ctx, openapi.PostAuctionItemsImportRequestObject{
+			Params: openapi.PostAuctionItemsImportParams{AccessToken: token, Format: openapi.Csv},
+			Body:   strings.NewReader(file),
+		})
+		require.NoError(t, err)
+		require.IsType(t, openapi.PostAuctionItemsImport422JSONResponse{}, resp)
+		var count int64
+		require.NoError(t, impl.db.Model(&models.AuctionItem{}).Where("user_id = ?", user.ID).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("圖片必須由使用者上傳", func(t *testing.T) {
+		impl, user, token := setupImportTest(t)
+		other := models.User{Username: "import-test-other"}
+		require.NoError(t, impl.db.Create(&other).Error)
+		t.Cleanup(func() {
+			impl.db.Unscoped().Where("uploader_id = ?", other.ID).Delete(&models.Image{})
+			impl.db.Unscoped().Delete(&other)
+		})
+		owned := "https://images/" + uuid.NewString()
+		foreign := "https://images/" + uuid.NewString()
+		require.NoError(t, impl.db.Create(&models.Image{UploaderID: user.ID, Url: owned}).Error)
+		require.NoError(t, impl.db.Create(&models.Image{UploaderID: other.ID, Url: foreign}).Error)
+
+		file := "title,endTime,carousels\n" +
+			"Item 1," + endTime + "," + owned + "\n" +
+			"Item 2," + endTime + "," + owned + "|" + foreign + "\n" +
+			"Item 3," + endTime + ",https://images/unknown\n"
+		resp, err := impl.PostAuctionItemsImport(ctx, openapi.PostAuctionItemsImportRequestObject{
+			Params: openapi.PostAuctionItemsImportParams{AccessToken: token, Format: openapi.Csv, Atomic: lo.ToPtr(false)},
+			Body:   strings.NewReader(file),
+		})
+		require.NoError(t, err)
+		require.IsType(t, openapi.PostAuctionItemsImport200JSONResponse{}, resp)
+		report := resp.(openapi.PostAuctionItemsImport200JSONResponse)
+		assert.Equal(t, 1, report.Created)
+		assert.NotNil(t, report.Results[0].Id)
+		assert.Equal(t, "Image is not uploaded by user: "+foreign, lo.FromPtr(report.Results[1].Message))
+		assert.Equal(t, "Image is not uploaded by user: https://images/unknown", lo.FromPtr(report.Results[2].Message))
+	})
+
+	t.Run("整批寫入失敗時改為逐筆寫入", func(t *testing.T) {
+		impl, user, token := setupImportTest(t)
+		// 標題超過資料庫欄位長度，通過檢查但無法寫入，和 Item 1 在同一批
+		file := "title,endTime\n" +
+			"Item 1," + endTime + "\n" +
+			strings.Repeat("x", 256) + "," + endTime + "\n" +
+			"Item 3," + endTime + "\n"
+		resp, err := impl.PostAuctionItemsImport(ctx, openapi.PostAuctionItemsImportRequestObject{
+			Params: openapi.PostAuctionItemsImportParams{AccessToken: token, Format: openapi.Csv, Atomic: lo.ToPtr(false)},
+			Body:   strings.NewReader(file),
+		})
+		require.NoError(t, err)
+		require.IsType(t, openapi.PostAuctionItemsImport200JSONResponse{}, resp)
+		report := resp.(openapi.PostAuctionItemsImport200JSONResponse)
+		assert.Equal(t, 2, report.Created)
+		assert.NotNil(t, report.Results[0].Id)
+		assert.Equal(t, "Fail to create auction item", lo.FromPtr(report.Results[1].Message))
+		assert.NotNil(t, report.Results[2].Id)
+
+		var titles []string
+		require.NoError(t, impl.db.Model(&models.AuctionItem{}).Where("user_id = ?", user.ID).Order("title").Pluck("title", &titles).Error)
+		assert.Equal(t, []string{"Item 1", "Item 3"}, titles)
+		var events int64
+		require.NoError(t, impl.db.Model(&models.OutboxEvent{}).Where("aggregate_id IN ?", lo.Map(report.Results, func(r openapi.AuctionItemImportResult, _ int) uuid.UUID {
+			return lo.FromPtr(r.Id)
+		})).Count(&events).Error)
+		assert.Equal(t, int64(2), events)
+	})
+}
@@ -0,0 +1,431 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// Update an auction item
+// (PATCH /auction/item/{itemID})
+func (impl *ServerImpl) PatchAuctionItemItemID(ctx context.Context, request openapi.PatchAuctionItemItemIDRequestObject) (openapi.PatchAuctionItemItemIDResponseObject, error) {
+	const op = "PatchAuctionItemItemID"
+	// 檢查使用者是否有權限修改拍賣物品
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PatchAuctionItemItemID401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PatchAuctionItemItemID401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PatchAuctionItemItemID403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	// 檢查拍賣物品是否存在
+	auction := models.AuctionItem{ID: request.ItemID}
+	if result := impl.db.First(&auction); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return openapi.PatchAuctionItemItemID404Response{}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to find auction item, err=%w", op, result.Error)
+	}
+	//  - 檢查使用者是否為賣家
+	if auction.UserID.String() != token.Subject {
+		return openapi.PatchAuctionItemItemID403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 只有草稿和還沒開始的拍賣物品可以修改
+	now := time.Now()
+	status := auction.EffectiveStatus(now)
+	if msg := uneditableAuctionMessage(status); msg != "" {
+		return openapi.PatchAuctionItemItemID409JSONResponse{
+			Message: lo.ToPtr(msg),
+		}, nil
+	}
+	// 檢查標題、價格和自動重新上架次數是否合法，規則和新增拍賣物品相同
+	if request.Body.Title != nil && strings.TrimSpace(*request.Body.Title) == "" {
+		return openapi.PatchAuctionItemItemID400JSONResponse{
+			Message: lo.ToPtr("Title is required"),
+		}, nil
+	}
+	if msg := impl.validateListingPrice(request.Body.StartingPrice, request.Body.ReservePrice, request.Body.AutoRelistRounds); msg != "" {
+		return openapi.PatchAuctionItemItemID400JSONResponse{
+			Message: lo.ToPtr(msg),
+		}, nil
+	}
+	//  - 圖片必須由使用者上傳
+	if request.Body.Carousels != nil {
+		if msg, err := impl.checkCarousels(auction.UserID, *request.Body.Carousels); err != nil {
+			return nil, fmt.Errorf("[%s] %w", op, err)
+		} else if msg != "" {
+			return openapi.PatchAuctionItemItemID400JSONResponse{
+				Message: lo.ToPtr(msg),
+			}, nil
+		}
+	}
+	// 整理要更新的欄位
+	updates := map[string]any{}
+	if request.Body.Title != nil {
+		updates["title"] = *request.Body.Title
+	}
+	if request.Body.Description != nil {
+		updates["description"] = impl.htmlChecker.Sanitize(*request.Body.Description)
+	}
+	if request.Body.StartingPrice != nil {
+		updates["starting_price"] = uint32(*request.Body.StartingPrice)
+	}
+	if request.Body.ReservePrice != nil {
+		updates["reserve_price"] = uint32(*request.Body.ReservePrice)
+	}
+	if request.Body.AutoRelistRounds != nil {
+		updates["auto_relist_rounds"] = uint32(*request.Body.AutoRelistRounds)
+	}
+	if request.Body.Carousels != nil {
+		updates["carousels"] = *request.Body.Carousels
+	}
+	if request.Body.StartTime != nil {
+		auction.StartTime = *request.Body.StartTime
+		updates["start_time"] = auction.StartTime
+	}
+	if request.Body.EndTime != nil {
+		auction.EndTime = *request.Body.EndTime
+		updates["end_time"] = auction.EndTime
+	}
+	if len(updates) == 0 {
+		return openapi.PatchAuctionItemItemID200Response{}, nil
+	}
+	// 檢查修改後的拍賣時間是否合法
+	if !validAuctionTime(auction.StartTime, auction.EndTime, now) {
+		return openapi.PatchAuctionItemItemID400JSONResponse{
+			Message: lo.ToPtr("Invalid auction time"),
+		}, nil
+	}
+	//  - 已發布的拍賣物品不能把開始時間改到現在之前，避免跳過排程直接開始
+	if status == openapi.Scheduled && !now.Before(auction.StartTime) {
+		return openapi.PatchAuctionItemItemID400JSONResponse{
+			Message: lo.ToPtr("Start time of a scheduled auction must be in the future"),
+		}, nil
+	}
+	// 更新拍賣物品
+	//  - 只更新仍然是草稿或還沒開始的拍賣物品，避免檢查後拍賣剛好開始
+	//  - 更新成功時以更新後的內容記錄修改事件
+	var updated bool
+	err = impl.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&auction).
+			Where("status = ? OR (status = ? AND start_time > ?)", openapi.Draft, openapi.Scheduled, now).
+			Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("fail to update auction item, err=%w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		updated = true
+		if result := tx.First(&auction); result.Error != nil {
+			return fmt.Errorf("fail to reload auction item, err=%w", result.Error)
+		}
+		return enqueueAuctionEvent(tx, models.OutboxEventAuctionEdited, auction)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	if !updated {
+		//  - 檢查後拍賣物品的狀態已經改變，重新讀取狀態回應對應的訊息
+		if result := impl.db.First(&auction, "id = ?", auction.ID); result.Error != nil {
+			return nil, fmt.Errorf("[%s] Fail to find auction item, err=%w", op, result.Error)
+		}
+		return openapi.PatchAuctionItemItemID409JSONResponse{
+			Message: lo.ToPtr(lo.CoalesceOrEmpty(uneditableAuctionMessage(auction.EffectiveStatus(time.Now())), "Auction has started")),
+		}, nil
+	}
+	return openapi.PatchAuctionItemItemID200Response{}, nil
+}
+
+// uneditableAuctionMessage 回傳拍賣物品在該狀態下不能修改的原因，草稿和還沒開始的拍賣物品可以修改時回傳空字串
+func uneditableAuctionMessage(status openapi.AuctionStatus) string {
+	switch status {
+	case openapi.Draft, openapi.Scheduled:
+		return ""
+	case openapi.Cancelled:
+		return "Auction has been cancelled"
+	case openapi.Ended, openapi.Settled:
+		return "Auction has ended"
+	default:
+		return "Auction has started"
+	}
+}
+
+// Publish a draft auction item
+// (POST /auction/item/{itemID}/publish)
+func (impl *ServerImpl) PostAuctionItemItemIDPublish(ctx context.Context, request openapi.PostAuctionItemItemIDPublishRequestObject) (openapi.PostAuctionItemItemIDPublishResponseObject, error) {
+	const op = "PostAuctionItemItemIDPublish"
+	// 檢查使用者是否有權限發布拍賣物品
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostAuctionItemItemIDPublish401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostAuctionItemItemIDPublish401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PostAuctionItemItemIDPublish403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	// 檢查拍賣物品是否存在
+	auction := models.AuctionItem{ID: request.ItemID}
+	if result := impl.db.First(&auction); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return openapi.PostAuctionItemItemIDPublish404Response{}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to find auction item, err=%w", op, result.Error)
+	}
+	//  - 檢查使用者是否為賣家
+	if auction.UserID.String() != token.Subject {
+		return openapi.PostAuctionItemItemIDPublish403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 只有草稿可以發布
+	if auction.Status != openapi.Draft {
+		return openapi.PostAuctionItemItemIDPublish409Response{}, nil
+	}
+	// 檢查拍賣時間是否合法
+	now := time.Now()
+	if !validAuctionTime(auction.StartTime, auction.EndTime, now) {
+		return openapi.PostAuctionItemItemIDPublish400JSONResponse{
+			Message: lo.ToPtr("Invalid auction time"),
+		}, nil
+	}
+	// 發布拍賣物品
+	auction.Publish(now)
+	var published bool
+	err = impl.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&auction).Where("status = ?", openapi.Draft).Update("status", auction.Status)
+		if result.Error != nil {
+			return fmt.Errorf("fail to publish auction item, err=%w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		published = true
+		return enqueueAuctionEvent(tx, models.OutboxEventAuctionPublished, auction)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	if !published {
+		return openapi.PostAuctionItemItemIDPublish409Response{}, nil
+	}
+	slog.Info("Auction published", slog.String("user", token.Subject), slog.String("auctionID", auction.ID.String()), slog.String("status", string(auction.Status)))
+	return openapi.PostAuctionItemItemIDPublish200JSONResponse{
+		Status: auction.Status,
+	}, nil
+}
+
+// List auction items of current user
+// (GET /user/auction/items)
+func (impl *ServerImpl) GetUserAuctionItems(ctx context.Context, request openapi.GetUserAuctionItemsRequestObject) (openapi.GetUserAuctionItemsResponseObject, error) {
+	const op = "GetUserAuctionItems"
+	// 檢查使用者是否有權限查看拍賣物品
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.GetUserAuctionItems401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.GetUserAuctionItems401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.GetUserAuctionItems403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	// 建立查詢，由新到舊排序(ID為uuid v7，可以直接作為時間順序的cursor)
+	now := time.Now()
+	query := impl.db.Model(&models.AuctionItem{}).Where("user_id = ?", token.Subject).Order("id DESC")
+	//  - status
+	if request.Params.Status != nil {
+		switch *request.Params.Status {
+		case openapi.Draft, openapi.Scheduled, openapi.Live, openapi.Ended, openapi.Settled, openapi.Cancelled:
+			query = query.Scopes(models.WhereEffectiveStatus(*request.Params.Status, now))
+		default:
+			return openapi.GetUserAuctionItems400JSONResponse{
+				Message: lo.ToPtr("Invalid status"),
+			}, nil
+		}
+	}
+	//  - cursor
+	if request.Params.LastItemID != nil {
+		query = query.Where("id < ?", *request.Params.LastItemID)
+	}
+	//  - size
+	size := uint32(20)
+	if request.Params.Size != nil {
+		size = *request.Params.Size
+	}
+	query = query.Limit(int(size))
+	// 查詢拍賣物品
+	var auctions []models.AuctionItem
+	if result := query.Find(&auctions); result.Error != nil {
+		return nil, fmt.Errorf("[%s] Fail to list auction items, err=%w", op, result.Error)
+	}
+	output := make([]struct {
+		EndTime   time.Time             `json:"endTime"`
+		Id        uuid.UUID             `json:"id"`
+		StartTime time.Time             `json:"startTime"`
+		Status    openapi.AuctionStatus `json:"status"`
+		Title     string                `json:"title"`
+	}, len(auctions))
+	for i, auction := range auctions {
+		output[i].Id = auction.ID
+		output[i].Title = auction.Title
+		output[i].StartTime = auction.StartTime
+		output[i].EndTime = auction.EndTime
+		output[i].Status = auction.EffectiveStatus(now)
+	}
+	return openapi.GetUserAuctionItems200JSONResponse{
+		Count: len(output),
+		Items: output,
+	}, nil
+}
+
+// runAuctionLifecycle 定期將拍賣物品的狀態更新為目前的生命週期狀態
+// 同一時間只會有一個實例執行，避免重複更新
+func (impl *ServerImpl) runAuctionLifecycle(ctx context.Context) {
+	logger := slog.Default().With(slog.String("caller", "AuctionLifecycle"))
+	defer logger.Info("Auction lifecycle worker stopped")
+	mutex := impl.newMutex("auction-lifecycle")
+	for {
+		lockCtx, err := mutex.Lock(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("Fail to acquire lock", slog.Any("error", err))
+			continue
+		}
+		logger.Info("Acquire lock, start advancing auction status")
+		ticker := time.NewTicker(impl.config.Auction.LifecycleInterval)
+	LOOP:
+		for {
+			if err := impl.advanceAuctionStatus(time.Now()); err != nil {
+				logger.Error("Fail to advance auction status", slog.Any("error", err))
+			}
+			select {
+			case <-lockCtx.Done():
+				break LOOP
+			case <-ticker.C:
+			}
+		}
+		ticker.Stop()
+		if _, err := mutex.Unlock(); err != nil {
+			logger.Warn("Fail to release lock", slog.Any("error", err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// advanceAuctionStatus 依照拍賣時間更新拍賣物品的狀態
+//   - 排程中的拍賣物品在開始時間後轉為進行中
+//   - 排程中或進行中的拍賣物品在結束時間後轉為已結束
+//   - 已結束的拍賣物品在結算延遲後轉為已結算，延遲是為了等待異步同步的出價紀錄寫入資料庫
+//
+// NOTE: 超過結算延遲才同步的出價(例如等待重試或在 dead-letter 中)不會再更新最高出價，applyBids 會將其交由人工處理
+func (impl *ServerImpl) advanceAuctionStatus(now time.Time) error {
+	return impl.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AuctionItem{}).
+			Where("status = ? AND start_time <= ? AND end_time > ?", openapi.Scheduled, now, now).
+			Update("status", openapi.Live)
+		if result.Error != nil {
+			return fmt.Errorf("fail to start auctions, err=%w", result.Error)
+		}
+		result = tx.Model(&models.AuctionItem{}).
+			Where("status IN ? AND end_time <= ?", []openapi.AuctionStatus{openapi.Scheduled, openapi.Live}, now).
+			Update("status", openapi.Ended)
+		if result.Error != nil {
+			return fmt.Errorf("fail to end auctions, err=%w", result.Error)
+		}
+		// 結算時需要知道最終出價，流標且還有自動重新上架次數的拍賣物品會直接重新上架
+		var settling []models.AuctionItem
+		result = tx.Preload("CurrentBid").
+			Where("status = ? AND end_time <= ?", openapi.Ended, now.Add(-impl.config.Auction.SettleDelay)).
+			Find(&settling)
+		if result.Error != nil {
+			return fmt.Errorf("fail to find auctions to settle, err=%w", result.Error)
+		}
+		for _, auction := range settling {
+			result = tx.Model(&auction).Where("status = ?", openapi.Ended).Update("status", openapi.Settled)
+			if result.Error != nil {
+				return fmt.Errorf("fail to settle auction, id=%s, err=%w", auction.ID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+			auction.Status = openapi.Settled
+			if err := enqueueAuctionEvent(tx, models.OutboxEventAuctionSettled, auction); err != nil {
+				return err
+			}
+			if auction.AutoRelistRounds == 0 || !auction.IsUnsold() {
+				continue
+			}
+			if err := relistAuction(tx, auction, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// relistAuction 以相同的商品資訊和拍賣時長，從現在開始重新上架流標的拍賣物品，並減少一次自動重新上架次數
+func relistAuction(tx *gorm.DB, original models.AuctionItem, now time.Time) error {
+	content := auctionContent(original)
+	content.AutoRelistRounds--
+	relisted := content.newAuctionItem(original.UserID, now, now.Add(original.EndTime.Sub(original.StartTime)), false, now)
+	relisted.RelistedFromID = &original.ID
+	// 賣家可能已經手動重新上架，這時候略過自動重新上架
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&relisted)
+	if result.Error != nil {
+		return fmt.Errorf("fail to relist auction, id=%s, err=%w", original.ID, result.Error)
+	}
+	if result.RowsAffected > 0 {
+		if err := enqueueAuctionEvent(tx, models.OutboxEventAuctionCreated, relisted); err != nil {
+			return err
+		}
+		slog.Info("Auction relisted automatically", slog.String("from", original.ID.String()), slog.String("auctionID", relisted.ID.String()), slog.Int("remainingRounds", int(content.AutoRelistRounds)))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"q4/api/openapi"
+	"q4/models"
+)
+
+func TestPatchAuctionItemItemID(t *testing.T) {
+	impl, user, token := setupImportTest(t)
+	ctx := context.Background()
+	other := models.User{Username: "patch-test-other"}
+	require.NoError(t, impl.db.Create(&other).Error)
+	t.Cleanup(func() {
+		impl.db.Unscoped().Where("uploader_id = ?", other.ID).Delete(&models.Image{})
+		impl.db.Unscoped().Delete(&other)
+	})
+	owned := "https://images/" + uuid.NewString()
+	foreign := "https://images/" + uuid.NewString()
+	require.NoError(t, impl.db.Create(&models.Image{UploaderID: user.ID, Url: owned}).Error)
+	require.NoError(t, impl.db.Create(&models.Image{UploaderID: other.ID, Url: foreign}).Error)
+
+	draft := models.AuctionItem{UserID: user.ID, Title: "patch-test", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour), Status: openapi.Draft}
+	require.NoError(t, impl.db.Create(&draft).Error)
+	patch := func(body openapi.PatchAuctionItemItemIDJSONRequestBody) openapi.PatchAuctionItemItemIDResponseObject {
+		resp, err := impl.PatchAuctionItemItemID(ctx, openapi.PatchAuctionItemItemIDRequestObject{
+			ItemID: draft.ID,
+			Params: openapi.PatchAuctionItemItemIDParams{AccessToken: token},
+			Body:   &body,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	tests := []struct {
+		name string
+		body openapi.PatchAuctionItemItemIDJSONRequestBody
+		want string
+	}{
+		{name: "標題為空白", body: openapi.PatchAuctionItemItemIDJSONRequestBody{Title: lo.ToPtr(" ")}, want: "Title is required"},
+		{name: "圖片由其他使用者上傳", body: openapi.PatchAuctionItemItemIDJSONRequestBody{Carousels: &[]string{owned, foreign}}, want: "Image is not uploaded by user: " + foreign},
+		{name: "結束時間早於開始時間", body: openapi.PatchAuctionItemItemIDJSONRequestBody{EndTime: lo.ToPtr(draft.StartTime.Add(-time.Minute))}, want: "Invalid auction time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, openapi.PatchAuctionItemItemID400JSONResponse{Message: lo.ToPtr(tt.want)}, patch(tt.body))
+		})
+	}
+
+	t.Run("不能修改的狀態回應對應的訊息", func(t *testing.T) {
+		cancelled := models.AuctionItem{UserID: user.ID, Title: "patch-test", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour), Status: openapi.Cancelled}
+		require.NoError(t, impl.db.Create(&cancelled).Error)
+		resp, err := impl.PatchAuctionItemItemID(ctx, openapi.PatchAuctionItemItemIDRequestObject{
+			ItemID: cancelled.ID,
+			Params: openapi.PatchAuctionItemItemIDParams{AccessToken: token},
+			Body:   &openapi.PatchAuctionItemItemIDJSONRequestBody{Title: lo.ToPtr("patched")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, openapi.PatchAuctionItemItemID409JSONResponse{Message: lo.ToPtr("Auction has been cancelled")}, resp)
+	})
+
+	t.Run("使用自己上傳的圖片", func(t *testing.T) {
+		resp := patch(openapi.PatchAuctionItemItemIDJSONRequestBody{Title: lo.ToPtr("patched"), Carousels: &[]string{owned}})
+		require.IsType(t, openapi.PatchAuctionItemItemID200Response{}, resp)
+		var updated models.AuctionItem
+		require.NoError(t, impl.db.First(&updated, "id = ?", draft.ID).Error)
+		assert.Equal(t, "patched", updated.Title)
+		assert.Equal(t, []string{owned}, []string(updated.Carousels))
+	})
+}
+
+func TestUneditableAuctionMessage(t *testing.T) {
+	tests := map[openapi.AuctionStatus]string{
+		openapi.Draft:     "",
+		openapi.Scheduled: "",
+		openapi.Live:      "Auction has started",
+		openapi.Cancelled: "Auction has been cancelled",
+		openapi.Ended:     "Auction has ended",
+		openapi.Settled:   "Auction has ended",
+	}
+	for status, want := range tests {
+		assert.Equal(t, want, uneditableAuctionMessage(status), status)
+	}
+}
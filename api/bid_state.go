@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	redisAdapter "q4/adapters/redis"
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// undrainedScanCount 掃描尚未同步的 stream 紀錄時，每次讀取的筆數
+const undrainedScanCount = 1000
+
+// 最高競價校正的結果，對應 ReconcileBidScript 的返回值
+const (
+	bidStateInSync = iota
+	bidStateMissing
+	bidStateBehind
+	bidStateAhead
+)
+
+// bidStateMetrics 最高競價校正的指標，除了 lastRunAt 以外都是累計的數量，欄位說明參考 bidStateReport
+//   - runs: 校正完成的次數
+//   - lastRunAt: 最後一次校正完成的時間(Unix秒)
+var (
+	bidStateMetrics      = newMetricsMap("bidState")
+	reconcileRuns        = new(expvar.Int)
+	reconcileLastRunAt   = new(expvar.Int)
+	reconcileChecked     = new(expvar.Int)
+	reconcileInitialized = new(expvar.Int)
+	reconcileMissing     = new(expvar.Int)
+	reconcileBehind      = new(expvar.Int)
+	reconcileAhead       = new(expvar.Int)
+)
+
+func init() {
+	bidStateMetrics.Set("runs", reconcileRuns)
+	bidStateMetrics.Set("lastRunAt", reconcileLastRunAt)
+	bidStateMetrics.Set("checked", reconcileChecked)
+	bidStateMetrics.Set("initialized", reconcileInitialized)
+	bidStateMetrics.Set("missing", reconcileMissing)
+	bidStateMetrics.Set("behind", reconcileBehind)
+	bidStateMetrics.Set("ahead", reconcileAhead)
+}
+
+// bidStateReport 代表一次最高競價校正的統計
+type bidStateReport struct {
+	// 檢查的拍賣物品數量
+	Checked int
+	// Redis 沒有最高競價，且拍賣物品還沒有任何出價的數量
+	// 最高競價在第一次出價時才會寫入 Redis，這是正常的情況，不算是不一致
+	Initialized int
+	// Redis 沒有最高競價，但資料庫或 stream 中已經有出價的數量
+	Missing int
+	// Redis 的最高競價低於資料庫和 stream 的數量
+	Behind int
+	// Redis 的最高競價高於資料庫和 stream 的數量
+	Ahead int
+}
+
+// bidStateTTL 計算最高競價在 Redis 中的過期時間，讓鍵在拍賣結束後再保留 afterEnd 的時間
+// NOTE: Lua script 的 EX 只接受整數秒，所以無條件進位並至少保留1秒
+func bidStateTTL(endTime, now time.Time, afterEnd time.Duration) int64 {
+	return max(int64(math.Ceil((endTime.Sub(now) + afterEnd).Seconds())), 1)
+}
+
+// undrainedBids 從 stream 中找出 consumer group 還沒有確認同步的出價，返回每個拍賣物品的最高出價金額
+// NOTE: 從最早的 pending 紀錄開始掃描，可能會包含部分已經同步的出價，但只取最高金額，所以不影響結果
+func undrainedBids(ctx context.Context, client redis.Cmdable, stream, group string) (map[uuid.UUID]uint32, error) {
+	groups, err := client.XInfoGroups(ctx, stream).Result()
+	if err != nil {
+		return nil, fmt.Errorf("fail to get consumer groups, err=%w", err)
+	}
+	var info *redis.XInfoGroup
+	for i := range groups {
+		if groups[i].Name == group {
+			info = &groups[i]
+			break
+		}
+	}
+	if info == nil {
+		return nil, fmt.Errorf("consumer group %s not found", group)
+	}
+	// 決定開始掃描的位置
+	//  - 有 pending 的紀錄時，從最早的 pending 紀錄開始
+	//  - 沒有 pending 的紀錄時，從最後一筆被讀取的紀錄之後開始
+	start := "-"
+	if info.Pending > 0 {
+		pending, err := client.XPending(ctx, stream, group).Result()
+		if err != nil {
+			return nil, fmt.Errorf("fail to get pending entries, err=%w", err)
+		}
+		start = pending.Lower
+	} else if info.LastDeliveredID != "" && info.LastDeliveredID != "0-0" {
+		start = "(" + info.LastDeliveredID
+	}
+	result := map[uuid.UUID]uint32{}
+	for {
+		messages, err := client.XRangeN(ctx, stream, start, "+", undrainedScanCount).Result()
+		if err != nil {
+			return nil, fmt.Errorf("fail to read stream, err=%w", err)
+		}
+		for _, message := range messages {
+			// 重送給其他 consumer group 的出價不會由 group 同步
+			if target, replayed := redisAdapter.ReplayTarget(message.Values); replayed && target != "" && target != group {
+				continue
+			}
+			bid, err := bidInfoSchema.Decode(message.Values)
+			if err != nil {
+				slog.Warn("Skip invalid bid in stream", slog.String("id", message.ID), slog.Any("error", err))
+				continue
+			}
+			result[bid.ItemID] = max(result[bid.ItemID], bid.Amount)
+		}
+		if len(messages) < undrainedScanCount {
+			return result, nil
+		}
+		start = "(" + messages[len(messages)-1].ID
+	}
+}
+
+// runBidStateReconciler 定期校正進行中拍賣物品在 Redis 中的最高競價，取得鎖後會先校正一次
+// NOTE: Redis 被清空時鎖也會消失，其他實例取得鎖後會立即重建最高競價
+func (impl *ServerImpl) runBidStateReconciler(ctx context.Context) {
+	logger := slog.Default().With(slog.String("caller", "BidStateReconciler"))
+	defer logger.Info("Bid state reconciler stopped")
+	mutex := impl.newMutex("bid-state-reconcile")
+	for {
+		lockCtx, err := mutex.Lock(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("Fail to acquire lock", slog.Any("error", err))
+			continue
+		}
+		logger.Info("Acquire lock, start reconciling bid state")
+		ticker := time.NewTicker(impl.config.Redis.ReconcileInterval)
+	LOOP:
+		for {
+			report, err := impl.reconcileBidState(lockCtx, time.Now())
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error("Fail to reconcile bid state", slog.Any("error", err))
+			} else if err == nil {
+				reconcileRuns.Add(1)
+				reconcileLastRunAt.Set(time.Now().Unix())
+				reconcileChecked.Add(int64(report.Checked))
+				reconcileInitialized.Add(int64(report.Initialized))
+				reconcileMissing.Add(int64(report.Missing))
+				reconcileBehind.Add(int64(report.Behind))
+				reconcileAhead.Add(int64(report.Ahead))
+				logger.Info("Bid state reconciled",
+					slog.Int("checked", report.Checked),
+					slog.Int("initialized", report.Initialized),
+					slog.Int("missing", report.Missing),
+					slog.Int("behind", report.Behind),
+					slog.Int("ahead", report.Ahead),
+				)
+			}
+			select {
+			case <-lockCtx.Done():
+				break LOOP
+			case <-ticker.C:
+			}
+		}
+		ticker.Stop()
+		if _, err := mutex.Unlock(); err != nil {
+			logger.Warn("Fail to release lock", slog.Any("error", err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// reconcileBidState 依照資料庫和尚未同步的 stream 重建進行中拍賣物品的最高競價，並回報和 Redis 不一致的情況
+//   - 預期的最高競價是起標價、資料庫的最高出價和 stream 中尚未同步的最高出價三者的最大值
+//   - Redis 的最高競價只會被調高，不會被調低
+//   - 過期時間會重新設定為拍賣結束後再保留 redis-expire-time
+func (impl *ServerImpl) reconcileBidState(ctx context.Context, now time.Time) (bidStateReport, error) {
+	var report bidStateReport
+	// 先讀取 stream 再讀取資料庫，讀取期間同步完成的出價會出現在資料庫中，不會被遺漏
+	pending := map[uuid.UUID]uint32{}
+	for _, stream := range bidStreamKeys(impl.config.Redis) {
+		bids, err := undrainedBids(ctx, impl.redisClient, stream, impl.config.Redis.ConsumerGroup)
+		if err != nil {
+			return report, fmt.Errorf("fail to read undrained bids in %s, err=%w", stream, err)
+		}
+		for itemID, amount := range bids {
+			pending[itemID] = max(pending[itemID], amount)
+		}
+	}
+	var batch []models.AuctionItem
+	result := impl.db.WithContext(ctx).
+		Preload("CurrentBid").
+		Scopes(models.WhereEffectiveStatus(openapi.Live, now)).
+		FindInBatches(&batch, 500, func(tx *gorm.DB, _ int) error {
+			for _, auction := range batch {
+				expected := auction.StartingPrice
+				if auction.CurrentBid != nil {
+					expected = max(expected, auction.CurrentBid.Amount)
+				}
+				expected = max(expected, pending[auction.ID])
+				ttl := bidStateTTL(auction.EndTime, now, impl.config.Redis.ExpireTime)
+				values, err := ReconcileBidScript.Run(ctx, impl.redisClient, []string{impl.auctionKey(auction.ID)}, expected, ttl).Int64Slice()
+				if err != nil {
+					return fmt.Errorf("fail to reconcile auction %s, err=%w", auction.ID, err)
+				}
+				report.Checked++
+				state, previous := values[0], values[1]
+				switch state {
+				case bidStateMissing:
+					// 還沒有任何出價時 Redis 只是被寫入起標價，不記錄為不一致
+					if auction.CurrentBid == nil && pending[auction.ID] == 0 {
+						report.Initialized++
+						continue
+					}
+					report.Missing++
+					slog.Warn("Bid state missing in Redis", slog.String("itemID", auction.ID.String()), slog.Any("expected", expected))
+				case bidStateBehind:
+					report.Behind++
+					slog.Warn("Bid state in Redis is behind", slog.String("itemID", auction.ID.String()), slog.Int64("redis", previous), slog.Any("expected", expected))
+				case bidStateAhead:
+					report.Ahead++
+					slog.Warn("Bid state in Redis is ahead", slog.String("itemID", auction.ID.String()), slog.Int64("redis", previous), slog.Any("expected", expected))
+				}
+			}
+			return nil
+		})
+	if result.Error != nil {
+		return report, fmt.Errorf("fail to find live auctions, err=%w", result.Error)
+	}
+	return report, nil
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"q4/models"
+)
+
+func TestBidStateTTL(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, int64(3600+60), bidStateTTL(now.Add(time.Hour), now, time.Minute))
+	assert.Equal(t, int64(61), bidStateTTL(now.Add(500*time.Millisecond), now, time.Minute))
+	assert.Equal(t, int64(1), bidStateTTL(now.Add(-time.Hour), now, time.Minute))
+}
+
+func TestUndrainedBids(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+	const stream, group = "bid-stream", "bid-group"
+	itemA, itemB := uuid.New(), uuid.New()
+	addBid := func(itemID uuid.UUID, amount uint32) {
+		data, err := msgpack.Marshal(BidInfo{ItemID: itemID, Amount: amount, CreatedAt: time.Now()})
+		require.NoError(t, err)
+		require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{
+			Stream: stream,
+			Values: map[string]any{"data": base64.StdEncoding.EncodeToString(data)},
+		}).Err())
+	}
+	readAndAck := func(count int64, ack bool) {
+		streams, err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: "consumer",
+			Streams:  []string{stream, ">"},
+			Count:    count,
+		}).Result()
+		require.NoError(t, err)
+		if ack {
+			for _, message := range streams[0].Messages {
+				require.NoError(t, client.XAck(ctx, stream, group, message.ID).Err())
+			}
+		}
+	}
+
+	t.Run("consumer group 不存在", func(t *testing.T) {
+		_, err := undrainedBids(ctx, client, stream, group)
+		assert.Error(t, err)
+	})
+
+	require.NoError(t, client.XGroupCreateMkStream(ctx, stream, group, "0").Err())
+
+	t.Run("沒有出價", func(t *testing.T) {
+		got, err := undrainedBids(ctx, client, stream, group)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("只計算尚未讀取的出價", func(t *testing.T) {
+		addBid(itemA, 100)
+		addBid(itemA, 500)
+		readAndAck(2, true)
+		addBid(itemA, 200)
+		addBid(itemB, 300)
+		got, err := undrainedBids(ctx, client, stream, group)
+		assert.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]uint32{itemA: 200, itemB: 300}, got)
+	})
+
+	t.Run("包含已讀取但尚未確認的出價", func(t *testing.T) {
+		readAndAck(1, false)
+		readAndAck(1, true)
+		addBid(itemB, 400)
+		got, err := undrainedBids(ctx, client, stream, group)
+		assert.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]uint32{itemA: 200, itemB: 400}, got)
+	})
+}
+
+func TestReconcileBidState(t *testing.T) {
+	db, user, auction := setupBidSyncDB(t)
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	impl := &ServerImpl{
+		db:          db,
+		redisClient: client,
+		config: ServerConfig{Redis: RedisConfig{
+			KeyPrefix:        "q4:",
+			ConsumerGroup:    "bid-group",
+			StreamPartitions: 2,
+			ExpireTime:       time.Hour,
+		}},
+	}
+	for _, stream := range bidStreamKeys(impl.config.Redis) {
+		require.NoError(t, client.XGroupCreateMkStream(ctx, stream, impl.config.Redis.ConsumerGroup, "0").Err())
+	}
+	key := impl.auctionKey(auction.ID)
+	// 先校正一次，資料庫中其他進行中的拍賣物品不影響之後的統計
+	_, err := impl.reconcileBidState(ctx, time.Now())
+	require.NoError(t, err)
+
+	// 還沒有任何出價時只寫入起標價，不算是不一致
+	require.NoError(t, client.Del(ctx, key).Err())
+	report, err := impl.reconcileBidState(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Initialized)
+	assert.Zero(t, report.Missing)
+	value, err := client.Get(ctx, key).Int()
+	require.NoError(t, err)
+	assert.Equal(t, int(auction.StartingPrice), value)
+
+	// 已經有出價但 Redis 沒有最高競價
+	bid := models.Bid{AuctionItemID: auction.ID, UserID: user.ID, Amount: 50}
+	require.NoError(t, db.Create(&bid).Error)
+	require.NoError(t, db.Model(&auction).Update("current_bid_id", bid.ID).Error)
+	require.NoError(t, client.Del(ctx, key).Err())
+	report, err = impl.reconcileBidState(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Missing)
+	assert.Zero(t, report.Initialized)
+	value, err = client.Get(ctx, key).Int()
+	require.NoError(t, err)
+	assert.Equal(t, 50, value)
+}
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	memoryAdapter "q4/adapters/memory"
+	redisAdapter "q4/adapters/redis"
+)
+
+// bidStore 保存拍賣物品的最高競價，出價成功時將出價寫入出價 stream
+type bidStore interface {
+	// PlaceBid 出價，有提供冪等鍵時以使用者和拍賣物品區分範圍，規則和返回值參考 BidScript
+	//   - dbCurrentBid: 沒有最高競價時使用的預設值
+	//   - endTime: 拍賣結束的時間，最高競價會保留到拍賣結束後一段時間
+	PlaceBid(ctx context.Context, bid BidInfo, dbCurrentBid uint32, endTime time.Time, idempotencyKey *string) (int, string, error)
+	// LookupBid 取得冪等鍵記錄的出價結果，沒有記錄時 found 為 false，金額和記錄的不同時返回-1，規則和 PlaceBid 相同
+	LookupBid(ctx context.Context, userID string, itemID uuid.UUID, idempotencyKey string, amount uint32) (status int, found bool, err error)
+	// RevertBid 在出價紀錄被移除後回退最高競價，規則參考 RevertBidScript
+	RevertBid(ctx context.Context, itemID uuid.UUID, removed, fallback uint32) error
+	// ReplayBids 依序將拍賣物品在 lastID 之後寫入 stream 的出價交給 fn，fn 返回錯誤時停止並返回該錯誤
+	// NOTE: 已經被修剪的出價不會重播
+	ReplayBids(ctx context.Context, itemID uuid.UUID, lastID string, fn func(id string, bid BidInfo) error) error
+}
+
+// bidReplayCount 重播出價時每次從 stream 讀取的消息數量
+const bidReplayCount = 100
+
+// redisBidStore 以 Lua script 在 Redis 中處理出價
+type redisBidStore struct {
+	client redis.UniversalClient
+	config RedisConfig
+}
+
+func (s *redisBidStore) PlaceBid(ctx context.Context, bid BidInfo, dbCurrentBid uint32, endTime time.Time, key *string) (int, string, error) {
+	bidInfoFields, err := bidInfoSchema.Encode(bid)
+	if err != nil {
+		return 0, "", fmt.Errorf("fail to marshal bid info, err=%w", err)
+	}
+	bidInfoData := bidInfoFields[redisAdapter.EnvelopeDataField]
+	delete(bidInfoFields, redisAdapter.EnvelopeDataField)
+	expireTime := bidStateTTL(endTime, time.Now(), s.config.ExpireTime)
+	keys := []string{auctionKey(s.config, bid.ItemID), bidStreamKey(s.config, bidPartition(s.config, bid.ItemID))}
+	args := []any{bid.Amount, bidInfoData, expireTime, dbCurrentBid, int64(s.config.IdempotencyExpireTime.Seconds())}
+	if key != nil {
+		keys = append(keys, idempotencyKey(s.config, bid.User.ID.String(), bid.ItemID, *key))
+	}
+	for _, field := range redisAdapter.FlattenFields(bidInfoFields) {
+		args = append(args, field)
+	}
+	status, id, err := runBidScript(ctx, s.client, keys, args...)
+	if err != nil {
+		return 0, "", fmt.Errorf("fail to place bid, err=%w", err)
+	}
+	return status, id, nil
+}
+
+func (s *redisBidStore) LookupBid(ctx context.Context, userID string, itemID uuid.UUID, key string, amount uint32) (int, bool, error) {
+	record, err := s.client.Get(ctx, idempotencyKey(s.config, userID, itemID, key)).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("fail to get idempotency record, err=%w", err)
+	}
+	// 記錄的格式為「結果:金額」，參考 BidScript
+	statusPart, amountPart, _ := strings.Cut(record, ":")
+	status, err := strconv.Atoi(statusPart)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid idempotency record %q", record)
+	}
+	recorded, err := strconv.ParseUint(amountPart, 10, 32)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid idempotency record %q", record)
+	}
+	if uint32(recorded) != amount {
+		return -1, true, nil
+	}
+	return status, true, nil
+}
+
+func (s *redisBidStore) RevertBid(ctx context.Context, itemID uuid.UUID, removed, fallback uint32) error {
+	if err := RevertBidScript.Run(ctx, s.client, []string{auctionKey(s.config, itemID)}, removed, fallback).Err(); err != nil {
+		return fmt.Errorf("fail to revert current bid in redis, err=%w", err)
+	}
+	return nil
+}
+
+func (s *redisBidStore) ReplayBids(ctx context.Context, itemID uuid.UUID, lastID string, fn func(id string, bid BidInfo) error) error {
+	// 同一個拍賣物品的出價都寫入同一個分區，依照ID的順序讀取該分區的 stream
+	stream := bidStreamKey(s.config, bidPartition(s.config, itemID))
+	start := "(" + lastID
+	for {
+		messages, err := s.client.XRangeN(ctx, stream, start, "+", bidReplayCount).Result()
+		if err != nil {
+			return fmt.Errorf("fail to read bid stream, err=%w", err)
+		}
+		for _, message := range messages {
+			// 重送的 dead-letter 出價已經推播過，不再補送
+			if _, replayed := redisAdapter.ReplayTarget(message.Values); replayed {
+				continue
+			}
+			bid, err := bidInfoSchema.Decode(message.Values)
+			if err != nil {
+				slog.Warn("Fail to parse bid message, skipped", slog.String("stream", stream), slog.String("id", message.ID), slog.Any("error", err))
+				continue
+			}
+			if bid.ItemID != itemID {
+				continue
+			}
+			if err := fn(message.ID, bid); err != nil {
+				return err
+			}
+		}
+		if len(messages) < bidReplayCount {
+			return nil
+		}
+		start = "(" + messages[len(messages)-1].ID
+	}
+}
+
+// memoryBidStoreSweepInterval 清除過期的最高競價和冪等鍵的間隔
+const memoryBidStoreSweepInterval = time.Minute
+
+// memoryBidState 記憶體中的最高競價或冪等鍵的結果
+type memoryBidState struct {
+	status   int
+	amount   uint32
+	expireAt time.Time
+}
+
+// memoryBidStore 在記憶體中處理出價，用於不使用 Redis 的單一程序模式
+// 以同一把鎖保護最高競價、冪等鍵和寫入 stream，效果等同於 Lua script 的原子操作，過期的規則也和 Redis 相同
+type memoryBidStore struct {
+	mu          sync.Mutex
+	stream      *memoryAdapter.Stream[BidInfo]
+	config      RedisConfig
+	bids        map[uuid.UUID]memoryBidState
+	idempotency map[string]memoryBidState
+	lastSweep   time.Time
+}
+
+func newMemoryBidStore(stream *memoryAdapter.Stream[BidInfo], config RedisConfig) *memoryBidStore {
+	return &memoryBidStore{
+		stream:      stream,
+		config:      config,
+		bids:        map[uuid.UUID]memoryBidState{},
+		idempotency: map[string]memoryBidState{},
+	}
+}
+
+func (s *memoryBidStore) PlaceBid(_ context.Context, bid BidInfo, dbCurrentBid uint32, endTime time.Time, key *string) (int, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.sweep(now)
+
+	// 檢查冪等鍵是否已經記錄過結果
+	var idempotencyKey string
+	if key != nil {
+		idempotencyKey = fmt.Sprintf("%s:%s:%s", bid.User.ID, bid.ItemID, *key)
+		if previous, ok := s.idempotency[idempotencyKey]; ok && now.Before(previous.expireAt) {
+			if previous.amount != bid.Amount {
+				return -1, "", nil
+			}
+			return previous.status, "", nil
+		}
+	}
+
+	// 取得當前最高競價，如果不存在則使用預設值
+	current := dbCurrentBid
+	if state, ok := s.bids[bid.ItemID]; ok && now.Before(state.expireAt) {
+		current = state.amount
+	}
+	status, id := 0, ""
+	if bid.Amount > current {
+		var err error
+		if id, err = s.stream.Add(bid); err != nil {
+			return 0, "", fmt.Errorf("fail to add bid to stream, err=%w", err)
+		}
+		ttl := time.Duration(bidStateTTL(endTime, now, s.config.ExpireTime)) * time.Second
+		s.bids[bid.ItemID] = memoryBidState{amount: bid.Amount, expireAt: now.Add(ttl)}
+		status = 1
+	}
+
+	// 記錄結果到冪等鍵
+	if key != nil {
+		s.idempotency[idempotencyKey] = memoryBidState{status: status, amount: bid.Amount, expireAt: now.Add(s.config.IdempotencyExpireTime)}
+	}
+	return status, id, nil
+}
+
+func (s *memoryBidStore) LookupBid(_ context.Context, userID string, itemID uuid.UUID, key string, amount uint32) (int, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.idempotency[fmt.Sprintf("%s:%s:%s", userID, itemID, key)]
+	if !ok || !time.Now().Before(previous.expireAt) {
+		return 0, false, nil
+	}
+	if previous.amount != amount {
+		return -1, true, nil
+	}
+	return previous.status, true, nil
+}
+
+func (s *memoryBidStore) RevertBid(_ context.Context, itemID uuid.UUID, removed, fallback uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// 只有當前最高競價就是被移除的出價時才回退，避免覆蓋之後的新出價
+	state, ok := s.bids[itemID]
+	if !ok || !time.Now().Before(state.expireAt) || state.amount != removed {
+		return nil
+	}
+	state.amount = fallback
+	s.bids[itemID] = state
+	return nil
+}
+
+func (s *memoryBidStore) ReplayBids(_ context.Context, itemID uuid.UUID, lastID string, fn func(id string, bid BidInfo) error) error {
+	entries, err := s.stream.Range(lastID)
+	if err != nil {
+		return fmt.Errorf("fail to read bid stream, err=%w", err)
+	}
+	for _, entry := range entries {
+		if entry.Data.ItemID != itemID {
+			continue
+		}
+		if err := fn(entry.ID, entry.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sweep 定期清除過期的最高競價和冪等鍵，呼叫前需要持有鎖
+func (s *memoryBidStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memoryBidStoreSweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for itemID, state := range s.bids {
+		if !now.Before(state.expireAt) {
+			delete(s.bids, itemID)
+		}
+	}
+	for key, state := range s.idempotency {
+		if !now.Before(state.expireAt) {
+			delete(s.idempotency, key)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	memoryAdapter "q4/adapters/memory"
+)
+
+func TestMemoryBidStore(t *testing.T) {
+	stream, err := memoryAdapter.NewStream[BidInfo]()
+	require.NoError(t, err)
+	store := newMemoryBidStore(stream, RedisConfig{
+		ExpireTime:            time.Hour,
+		IdempotencyExpireTime: time.Hour,
+	})
+	ctx := context.Background()
+	itemID := uuid.New()
+	endTime := time.Now().Add(time.Hour)
+	bid := func(amount uint32) BidInfo {
+		return BidInfo{ItemID: itemID, User: BidInfoUser{ID: uuid.New(), Name: "TestUser"}, Amount: amount, CreatedAt: time.Now()}
+	}
+
+	// 沒有最高競價時和預設值比較
+	status, _, err := store.PlaceBid(ctx, bid(100), 100, endTime, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, status)
+	status, _, err = store.PlaceBid(ctx, bid(150), 100, endTime, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, status)
+	// 已經有最高競價時忽略預設值
+	status, _, err = store.PlaceBid(ctx, bid(120), 0, endTime, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, status)
+	assert.Equal(t, 1, stream.Len())
+
+	// 冪等鍵: 相同金額返回第一次的結果，不同金額返回-1
+	key := "key"
+	idempotent := bid(200)
+	status, _, err = store.PlaceBid(ctx, idempotent, 0, endTime, &key)
+	require.NoError(t, err)
+	assert.Equal(t, 1, status)
+	status, _, err = store.PlaceBid(ctx, idempotent, 0, endTime, &key)
+	require.NoError(t, err)
+	assert.Equal(t, 1, status)
+	idempotent.Amount = 300
+	status, _, err = store.PlaceBid(ctx, idempotent, 0, endTime, &key)
+	require.NoError(t, err)
+	assert.Equal(t, -1, status)
+	assert.Equal(t, 2, stream.Len())
+
+	// 只有最高競價等於被移除的出價時才回退
+	require.NoError(t, store.RevertBid(ctx, itemID, 150, 100))
+	assert.Equal(t, uint32(200), store.bids[itemID].amount)
+	require.NoError(t, store.RevertBid(ctx, itemID, 200, 150))
+	assert.Equal(t, uint32(150), store.bids[itemID].amount)
+	status, _, err = store.PlaceBid(ctx, bid(160), 0, endTime, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, status)
+}
+
+func TestMemoryBidStore_Expire(t *testing.T) {
+	stream, err := memoryAdapter.NewStream[BidInfo]()
+	require.NoError(t, err)
+	store := newMemoryBidStore(stream, RedisConfig{ExpireTime: time.Second, IdempotencyExpireTime: time.Hour})
+	ctx := context.Background()
+	itemID := uuid.New()
+
+	// 過期的最高競價改為使用預設值
+	_, _, err = store.PlaceBid(ctx, BidInfo{ItemID: itemID, Amount: 200}, 0, time.Now().Add(-time.Hour), nil)
+	require.NoError(t, err)
+	state := store.bids[itemID]
+	state.expireAt = time.Now().Add(-time.Second)
+	store.bids[itemID] = state
+	status, _, err := store.PlaceBid(ctx, BidInfo{ItemID: itemID, Amount: 150}, 100, time.Now(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, status)
+
+	// 定期清除過期的狀態
+	state = store.bids[itemID]
+	state.expireAt = time.Now().Add(-time.Second)
+	store.bids[itemID] = state
+	store.sweep(time.Now().Add(memoryBidStoreSweepInterval))
+	assert.Empty(t, store.bids)
+}
+
+func TestBidStore_ReplayBids(t *testing.T) {
+	config := RedisConfig{
+		KeyPrefix:             "q4:",
+		StreamPartitions:      2,
+		ExpireTime:            time.Hour,
+		IdempotencyExpireTime: time.Hour,
+	}
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	stream, err := memoryAdapter.NewStream[BidInfo]()
+	require.NoError(t, err)
+
+	stores := map[string]bidStore{
+		"redis":  &redisBidStore{client: client, config: config},
+		"memory": newMemoryBidStore(stream, config),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			itemID, otherID := uuid.New(), uuid.New()
+			endTime := time.Now().Add(time.Hour)
+			placeBid := func(itemID uuid.UUID, amount uint32) string {
+				status, id, err := store.PlaceBid(ctx, BidInfo{ItemID: itemID, User: BidInfoUser{ID: uuid.New(), Name: "TestUser"}, Amount: amount, CreatedAt: time.Now()}, 0, endTime, nil)
+				require.NoError(t, err)
+				require.Equal(t, 1, status)
+				require.NotEmpty(t, id)
+				return id
+			}
+			first := placeBid(itemID, 100)
+			placeBid(otherID, 100)
+			second := placeBid(itemID, 200)
+			third := placeBid(itemID, 300)
+
+			// 失敗的出價沒有寫入 stream，不會返回ID
+			status, id, err := store.PlaceBid(ctx, BidInfo{ItemID: itemID, Amount: 150}, 0, endTime, nil)
+			require.NoError(t, err)
+			assert.Equal(t, 0, status)
+			assert.Empty(t, id)
+
+			// 只重播同一個拍賣物品在 lastID 之後的出價
+			var ids []string
+			var amounts []uint32
+			err = store.ReplayBids(ctx, itemID, first, func(id string, bid BidInfo) error {
+				ids = append(ids, id)
+				amounts = append(amounts, bid.Amount)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{second, third}, ids)
+			assert.Equal(t, []uint32{200, 300}, amounts)
+
+			ids = nil
+			err = store.ReplayBids(ctx, itemID, third, func(id string, _ BidInfo) error {
+				ids = append(ids, id)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Empty(t, ids)
+		})
+	}
+}
+
+func TestBidStore_LookupBid(t *testing.T) {
+	config := RedisConfig{
+		KeyPrefix:             "q4:",
+		StreamPartitions:      2,
+		ExpireTime:            time.Hour,
+		IdempotencyExpireTime: time.Hour,
+	}
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	stream, err := memoryAdapter.NewStream[BidInfo]()
+	require.NoError(t, err)
+
+	stores := map[string]bidStore{
+		"redis":  &redisBidStore{client: client, config: config},
+		"memory": newMemoryBidStore(stream, config),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			itemID, userID := uuid.New(), uuid.New()
+			endTime := time.Now().Add(time.Hour)
+			placeBid := func(amount uint32, key string) int {
+				status, _, err := store.PlaceBid(ctx, BidInfo{ItemID: itemID, User: BidInfoUser{ID: userID, Name: "TestUser"}, Amount: amount, CreatedAt: time.Now()}, 0, endTime, &key)
+				require.NoError(t, err)
+				return status
+			}
+
+			// 沒有記錄
+			_, found, err := store.LookupBid(ctx, userID.String(), itemID, "key-1", 100)
+			require.NoError(t, err)
+			assert.False(t, found)
+
+			require.Equal(t, 1, placeBid(100, "key-1"))
+			require.Equal(t, 0, placeBid(50, "key-2"))
+
+			status, found, err := store.LookupBid(ctx, userID.String(), itemID, "key-1", 100)
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, 1, status)
+
+			// 失敗的出價也會記錄結果
+			status, found, err = store.LookupBid(ctx, userID.String(), itemID, "key-2", 50)
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, 0, status)
+
+			// 金額不同
+			status, found, err = store.LookupBid(ctx, userID.String(), itemID, "key-1", 200)
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, -1, status)
+
+			// 其他使用者的冪等鍵不共用
+			_, found, err = store.LookupBid(ctx, uuid.NewString(), itemID, "key-1", 100)
+			require.NoError(t, err)
+			assert.False(t, found)
+		})
+	}
+}
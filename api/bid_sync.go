@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	redisAdapter "q4/adapters/redis"
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// runBidSynchronizer 將Redis中的出價紀錄以批次的方式存回資料庫
+//   - 同一批的出價在同一個交易中依序套用，交易提交後才確認消息
+//   - 交易失敗時改為逐筆同步，避免單筆出價的問題讓整批出價都移動到 dead-letter
+//   - 逐筆同步失敗的出價在原地依照退避時間重試，達到最大投遞次數後才移動到 dead-letter，拍賣物品不存在時不重試
+func (impl *ServerImpl) runBidSynchronizer(ctx context.Context) {
+	logger := slog.Default().With(slog.String("caller", "BidSynchronize"))
+	defer logger.Info("Bid synchronization worker stopped")
+	defer impl.groupConsumer.Close()
+	ch := impl.groupConsumer.SubscribeBatch()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case batch, ok := <-ch:
+			if !ok {
+				return
+			}
+			logger.Debug("Receive messages", slog.Int("count", len(batch)))
+			bids := make([]BidInfo, len(batch))
+			for i, msg := range batch {
+				bids[i] = msg.Data
+			}
+			var failures []error
+			err := impl.db.Transaction(func(tx *gorm.DB) error {
+				var err error
+				failures, err = applyBids(tx, bids, logger)
+				return err
+			})
+			if err != nil {
+				logger.Warn("Fail to synchronize bids in batch, retry one by one", slog.Int("count", len(batch)), slog.Any("error", err))
+				failures = make([]error, len(batch))
+				for i, bid := range bids {
+					failures[i] = impl.syncBidWithRetry(ctx, batch[i], bid, logger)
+				}
+			}
+			// 交易提交後才確認消息
+			done := make([]*redisAdapter.Message[BidInfo], 0, len(batch))
+			for i, msg := range batch {
+				if failures[i] == nil {
+					done = append(done, msg)
+					continue
+				}
+				if errors.Is(failures[i], errBidRetried) {
+					continue
+				}
+				logger.Error("Fail to synchronize bid", slog.String("itemID", msg.Data.ItemID.String()), slog.Any("error", failures[i]))
+				if err := msg.Fail(ctx, failures[i]); err != nil {
+					logger.Error("Fail to fail message", slog.Any("error", err))
+				}
+			}
+			// NOTE: 確認失敗的消息會留在 pending 中並在之後重新同步，重複套用的出價不會高於目前的最高出價，所以會被忽略
+			if err := redisAdapter.DoneMessages(ctx, done...); err != nil {
+				logger.Error("Sync success but fail to done messages", slog.Any("error", err))
+				continue
+			}
+			logger.Debug("Synchronize success", slog.Int("count", len(done)))
+		}
+	}
+}
+
+// errBidRetried 表示出價已經交由 Retry 處理(達到最大投遞次數後移動到 dead-letter)，或是在等待重試時中止並留在 pending 中，不需要再次處理
+var errBidRetried = errors.New("bid retried")
+
+// errAuctionSettled 表示出價在拍賣物品結算後才同步，結算結果和自動重新上架已經依照當時的最高出價處理，
+// 不能再更新最高出價，交由呼叫者移動到 dead-letter 人工處理
+var errAuctionSettled = errors.New("auction already settled")
+
+// syncBidWithRetry 在獨立的交易中同步單筆出價
+// NOTE: 出價的 consumer 為嚴格順序模式，Retry 會在原地等待退避時間，重試期間同一個分區後面的出價不會被處理
+// 等待期間失去分區的鎖時停止重試，出價留在 pending 中由取得鎖的實例處理，投遞次數記錄在 Redis 中不會重新計算
+func (impl *ServerImpl) syncBidWithRetry(ctx context.Context, msg *redisAdapter.Message[BidInfo], bid BidInfo, logger *slog.Logger) error {
+	for {
+		err := impl.db.Transaction(func(tx *gorm.DB) error {
+			bidFailures, err := applyBids(tx, []BidInfo{bid}, logger)
+			if err != nil {
+				return err
+			}
+			return bidFailures[0]
+		})
+		// 拍賣物品不存在或已經結算時重試也不會成功，交由呼叫者直接移動到 dead-letter
+		if err == nil || errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, errAuctionSettled) {
+			return err
+		}
+		logger.Warn("Fail to synchronize bid, retry later", slog.String("itemID", bid.ItemID.String()), slog.Int64("attempt", msg.Attempt()), slog.Any("error", err))
+		retry, retryErr := msg.Retry(ctx, err)
+		if errors.Is(retryErr, context.Canceled) {
+			logger.Info("Stop retrying bid, the partition lock is released", slog.String("itemID", bid.ItemID.String()))
+		} else if retryErr != nil {
+			logger.Error("Fail to retry message", slog.Any("error", retryErr))
+		}
+		if !retry {
+			return errBidRetried
+		}
+	}
+}
+
+// applyBids 在交易中依序套用一批出價，只有高於目前最高出價的出價會被記錄並更新拍賣物品的最高出價
+// 返回每一筆出價各自的錯誤(例如拍賣物品不存在或已經結算)，以及造成整個交易失敗的錯誤
+//
+// NOTE: 參考 PostAuctionItemItemIDBids 的實現邏輯，為了避免低機率的邊界條件造成的低金額出價問題，同步出價資料庫時需要再次檢查最高出價金額。
+// NOTE: 分區的鎖過期或重新分配時，可能有兩個 server 短暫處理同一批出價，所以以 SELECT ... FOR UPDATE 鎖定拍賣物品，
+// 後提交的交易會讀到先提交的最高出價，重複的出價不會高於目前的最高出價而被忽略
+func applyBids(tx *gorm.DB, bids []BidInfo, logger *slog.Logger) ([]error, error) {
+	failures := make([]error, len(bids))
+	if len(bids) == 0 {
+		return failures, nil
+	}
+	// 一次查詢這批出價的所有拍賣物品
+	itemIDs := make([]uuid.UUID, 0, len(bids))
+	seen := map[uuid.UUID]bool{}
+	for _, bid := range bids {
+		if !seen[bid.ItemID] {
+			seen[bid.ItemID] = true
+			itemIDs = append(itemIDs, bid.ItemID)
+		}
+	}
+	//  - 依照ID的順序鎖定，避免不同交易以不同的順序鎖定造成死結
+	var auctions []models.AuctionItem
+	if result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("CurrentBid").Where("id IN ?", itemIDs).Order("id").Find(&auctions); result.Error != nil {
+		return nil, fmt.Errorf("fail to find auction items, err=%w", result.Error)
+	}
+	currentBids := make(map[uuid.UUID]uint32, len(auctions))
+	cancelled := map[uuid.UUID]bool{}
+	settled := map[uuid.UUID]bool{}
+	for _, auction := range auctions {
+		if auction.CurrentBid != nil {
+			currentBids[auction.ID] = auction.CurrentBid.Amount
+		} else {
+			currentBids[auction.ID] = auction.StartingPrice
+		}
+		cancelled[auction.ID] = auction.Status == openapi.Cancelled
+		settled[auction.ID] = auction.Status == openapi.Settled
+	}
+	// 依序比較出價，同一個拍賣物品只有最後一筆更高的出價會成為最高出價
+	var records []models.Bid
+	latest := map[uuid.UUID]int{}
+	for i, bid := range bids {
+		currentBid, ok := currentBids[bid.ItemID]
+		if !ok {
+			failures[i] = fmt.Errorf("fail to find auction item, err=%w", gorm.ErrRecordNotFound)
+			continue
+		}
+		// 被下架的拍賣物品不再記錄出價
+		if cancelled[bid.ItemID] {
+			logger.Warn("Ignore bid of cancelled auction", slog.String("itemID", bid.ItemID.String()), slog.Int64("bid", int64(bid.Amount)))
+			continue
+		}
+		// 重複投遞的出價不會高於目前的最高出價，只有結算後才同步的更高出價需要人工處理
+		if settled[bid.ItemID] && currentBid < bid.Amount {
+			failures[i] = fmt.Errorf("fail to apply bid, current=%d, bid=%d, err=%w", currentBid, bid.Amount, errAuctionSettled)
+			continue
+		}
+		if currentBid >= bid.Amount {
+			logger.Warn("Ignore lower bid", slog.String("itemID", bid.ItemID.String()), slog.Int64("current", int64(currentBid)), slog.Int64("new", int64(bid.Amount)))
+			continue
+		}
+		logger.Debug("Update current bid", slog.String("itemID", bid.ItemID.String()), slog.Uint64("from", uint64(currentBid)), slog.Int64("to", int64(bid.Amount)))
+		currentBids[bid.ItemID] = bid.Amount
+		latest[bid.ItemID] = len(records)
+		records = append(records, models.Bid{
+			UserID:        bid.User.ID,
+			Amount:        bid.Amount,
+			AuctionItemID: bid.ItemID,
+		})
+	}
+	if len(records) == 0 {
+		return failures, nil
+	}
+	// 一次寫入所有出價紀錄，再更新每個拍賣物品的最高出價
+	if result := tx.Omit(clause.Associations).Create(&records); result.Error != nil {
+		return nil, fmt.Errorf("fail to create bids, err=%w", result.Error)
+	}
+	for _, itemID := range itemIDs {
+		index, ok := latest[itemID]
+		if !ok {
+			continue
+		}
+		result := tx.Model(&models.AuctionItem{}).Where("id = ?", itemID).Update("current_bid_id", records[index].ID)
+		if result.Error != nil {
+			return nil, fmt.Errorf("fail to update auction item, err=%w", result.Error)
+		}
+	}
+	return failures, nil
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// setupBidSyncDB 連線到 Q4_TEST_DSN 指定的資料庫(需要先套用 migration)，並建立一個進行中的拍賣物品
+// 沒有設定 Q4_TEST_DSN 時略過
+func setupBidSyncDB(tb testing.TB) (*gorm.DB, models.User, models.AuctionItem) {
+	dsn := os.Getenv("Q4_TEST_DSN")
+	if dsn == "" {
+		tb.Skip("Q4_TEST_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true, Logger: logger.Discard})
+	require.NoError(tb, err)
+
+	user := models.User{Username: "bid-sync-test"}
+	require.NoError(tb, db.Create(&user).Error)
+	auction := models.AuctionItem{
+		UserID:        user.ID,
+		Title:         "bid-sync-test",
+		StartingPrice: 10,
+		StartTime:     time.Now().Add(-time.Hour),
+		EndTime:       time.Now().Add(time.Hour),
+		Status:        openapi.Live,
+	}
+	require.NoError(tb, db.Create(&auction).Error)
+	tb.Cleanup(func() {
+		db.Unscoped().Model(&models.AuctionItem{}).Where("id = ?", auction.ID).Update("current_bid_id", nil)
+		db.Unscoped().Where("auction_item_id = ?", auction.ID).Delete(&models.Bid{})
+		db.Unscoped().Delete(&auction)
+		db.Unscoped().Delete(&user)
+	})
+	return db, user, auction
+}
+
+func TestApplyBids(t *testing.T) {
+	db, user, auction := setupBidSyncDB(t)
+	discard := slog.New(slog.NewTextHandler(io.Discard, nil))
+	bid := func(itemID uuid.UUID, amount uint32) BidInfo {
+		return BidInfo{ItemID: itemID, User: BidInfoUser{ID: user.ID}, Amount: amount, CreatedAt: time.Now()}
+	}
+
+	var failures []error
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		failures, err = applyBids(tx, []BidInfo{
+			bid(auction.ID, 5),  // 低於起標價
+			bid(auction.ID, 20), // 更高的出價
+			bid(uuid.New(), 30), // 拍賣物品不存在
+			bid(auction.ID, 15), // 低於前一筆出價
+			bid(auction.ID, 40), // 最高出價
+		}, discard)
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, failures, 5)
+	for i, failure := range failures {
+		if i == 2 {
+			assert.ErrorIs(t, failure, gorm.ErrRecordNotFound)
+		} else {
+			assert.NoError(t, failure)
+		}
+	}
+
+	var bids []models.Bid
+	require.NoError(t, db.Where("auction_item_id = ?", auction.ID).Order("amount").Find(&bids).Error)
+	require.Len(t, bids, 2)
+	assert.Equal(t, []uint32{20, 40}, []uint32{bids[0].Amount, bids[1].Amount})
+	require.NoError(t, db.Preload("CurrentBid").First(&auction, "id = ?", auction.ID).Error)
+	assert.Equal(t, uint32(40), auction.CurrentBid.Amount)
+}
+
+func TestApplyBids_AfterSettlement(t *testing.T) {
+	db, user, auction := setupBidSyncDB(t)
+	discard := slog.New(slog.NewTextHandler(io.Discard, nil))
+	bid := func(amount uint32) BidInfo {
+		return BidInfo{ItemID: auction.ID, User: BidInfoUser{ID: user.ID}, Amount: amount, CreatedAt: time.Now()}
+	}
+	apply := func(bids ...BidInfo) []error {
+		var failures []error
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			failures, err = applyBids(tx, bids, discard)
+			return err
+		})
+		require.NoError(t, err)
+		return failures
+	}
+
+	require.Equal(t, []error{nil}, apply(bid(20)))
+	require.NoError(t, db.Model(&auction).Update("status", openapi.Settled).Error)
+
+	// 結算後才同步的更高出價交由人工處理，重複投遞的出價仍然被忽略
+	failures := apply(bid(30), bid(20))
+	require.Len(t, failures, 2)
+	assert.ErrorIs(t, failures[0], errAuctionSettled)
+	assert.NoError(t, failures[1])
+
+	var count int64
+	require.NoError(t, db.Model(&models.Bid{}).Where("auction_item_id = ?", auction.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+	require.NoError(t, db.Preload("CurrentBid").First(&auction, "id = ?", auction.ID).Error)
+	assert.Equal(t, uint32(20), auction.CurrentBid.Amount)
+}
+
+// benchmarkApplyBids 比較每筆出價各自一個交易和整批出價一個交易的同步耗時
+func benchmarkApplyBids(b *testing.B, batchSize int) {
+	db, user, auction := setupBidSyncDB(b)
+	discard := slog.New(slog.NewTextHandler(io.Discard, nil))
+	const n = 1000
+	amount := uint32(10)
+
+	b.ResetTimer()
+	for range b.N {
+		bids := make([]BidInfo, n)
+		for i := range bids {
+			amount++
+			bids[i] = BidInfo{ItemID: auction.ID, User: BidInfoUser{ID: user.ID}, Amount: amount, CreatedAt: time.Now()}
+		}
+		for start := 0; start < n; start += batchSize {
+			err := db.Transaction(func(tx *gorm.DB) error {
+				_, err := applyBids(tx, bids[start:min(start+batchSize, n)], discard)
+				return err
+			})
+			require.NoError(b, err)
+		}
+	}
+}
+
+func BenchmarkApplyBids_Single(b *testing.B) {
+	benchmarkApplyBids(b, 1)
+}
+
+func BenchmarkApplyBids_Batch(b *testing.B) {
+	benchmarkApplyBids(b, 100)
+}
@@ -8,12 +8,21 @@ import (
 type ServerConfig struct {
 	// 用於識別不同的服務實例
 	ID string
+	// 單一程序模式，不連線到 Redis，以記憶體中的實作處理出價，只適用於本機開發和展示
+	InMemory bool
 
-	Auth  AuthConfig
-	OIDC  OIDCConfig
-	S3    S3Config
-	DB    DBConfig
-	Redis RedisConfig
+	Auth      AuthConfig
+	OIDC      OIDCConfig
+	S3        S3Config
+	DB        DBConfig
+	Redis     RedisConfig
+	RateLimit RateLimitConfig
+	SSE       SSEConfig
+
+	ShillDetection  ShillDetectionConfig
+	Auction         AuctionConfig
+	StreamRetention StreamRetentionConfig
+	Outbox          OutboxConfig
 }
 
 type AuthConfig struct {
@@ -53,17 +62,143 @@ type DBConfig struct {
 }
 
 type RedisConfig struct {
+	// Cluster 和 Sentinel 模式下可以用逗號分隔多個節點，Sentinel 模式下為 Sentinel 的位址
 	Addr     string
 	Password string
 	DB       int
+	// 是否連線到 Redis Cluster
+	Cluster bool
+	// Sentinel 監控的主節點名稱，設定後會透過 Sentinel 連線並在主從切換時自動連到新的主節點
+	MasterName string
+	// Sentinel 本身的密碼
+	SentinelPassword string
+
+	// 以下為通用的連線設定，0 表示使用 go-redis 的預設值
+	// 指令失敗時的最大重試次數，-1 表示不重試
+	MaxRetries   int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	PoolSize     int
+	// 讀取 stream 失敗後重新讀取前的等待時間，避免在主從切換期間大量重試
+	RetryDelay time.Duration
+	// SSE 讀取出價 stream 的位置保存的間隔，重新啟動後從保存的位置繼續讀取，0 表示不保存
+	CheckpointInterval time.Duration
+	// 不需要嚴格順序的 consumer group 中，pending 訊息閒置超過 ClaimMinIdle 後會被其他實例認領重新處理，0 表示不認領
+	ClaimMinIdle time.Duration
+	// 檢查閒置 pending 訊息的間隔
+	ClaimInterval time.Duration
+	// 認領的訊息最多被投遞幾次，超過後移動到 dead-letter stream，0 表示不限制
+	MaxDeliveries int64
+	// 處理失敗的訊息最多投遞幾次，超過後移動到 dead-letter stream，1 表示不重試
+	MaxAttempts int64
+	// 重試前的等待時間，每次重試加倍，最多為 RetryMaxBackoff
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
 
+	// 拍賣結束後最高競價在 Redis 保留的時間
 	ExpireTime time.Duration
+	// 出價冪等鍵的保存時間，超過後相同的冪等鍵會被視為新的出價
+	IdempotencyExpireTime time.Duration
+	// 定期依照資料庫和 stream 校正 Redis 中最高競價的間隔
+	ReconcileInterval time.Duration
 
 	KeyPrefix     string
 	ConsumerGroup string
-	StreamKeys    RedisStreamKeys
+	// 同步出價時每一批最多包含的出價數量，同一批的出價在同一個交易中寫入資料庫
+	SyncBatchSize int
+	// 同步出價的實例更新心跳並重新分配分區的間隔，超過 PartitionInstanceTTL 沒有心跳的實例負責的分區會由其他實例接手
+	PartitionHeartbeatInterval time.Duration
+	PartitionInstanceTTL       time.Duration
+	StreamKeys                 RedisStreamKeys
+	// 出價 stream 的分區數量，每個分區的 stream 鍵為 <BidStream>:{n}
+	StreamPartitions int
 }
 
 type RedisStreamKeys struct {
 	BidStream string
+	// 拍賣物品建立、修改、下架和結算等事件的 stream，由 outbox 的背景工作寫入
+	AuctionEventStream string
+}
+
+type RateLimitConfig struct {
+	// 單一使用者的出價頻率限制
+	BidPerUser RateLimitRule
+	// 單一使用者在單一拍賣物品上的出價頻率限制
+	BidPerUserAuction RateLimitRule
+}
+
+// RateLimitRule 表示在 Window 時間內最多允許 Limit 次請求，Limit 為0時不限制
+type RateLimitRule struct {
+	Limit  int64
+	Window time.Duration
+}
+
+type AuctionConfig struct {
+	// 背景工作更新拍賣物品生命週期狀態的間隔
+	LifecycleInterval time.Duration
+	// 拍賣結束後多久轉為已結算，用於等待異步同步的出價紀錄寫入資料庫
+	SettleDelay time.Duration
+	// 流標的拍賣物品最多可以設定自動重新上架幾次
+	MaxAutoRelistRounds uint32
+	// 批次匯入拍賣物品時，單一檔案最多可以包含幾筆資料
+	ImportMaxRows int
+	// 批次匯入拍賣物品時，每次寫入資料庫的筆數
+	ImportChunkSize int
+}
+
+// StreamRetentionConfig 出價 stream 的保留策略，保留時間和最大筆數為0時表示不限制
+type StreamRetentionConfig struct {
+	// 背景工作修剪 stream 的間隔
+	Interval time.Duration
+	// 出價 stream 保留的時間和最大筆數，尚未被所有 consumer group 確認的出價不會被刪除
+	MaxAge time.Duration
+	MaxLen int64
+	// dead-letter stream 保留的時間和最大筆數
+	DeadLetterMaxAge time.Duration
+	DeadLetterMaxLen int64
+}
+
+type ShillDetectionConfig struct {
+	// 是否啟用可疑出價偵測
+	Enabled bool
+	// 偵測器讀取出價 stream 使用的 consumer group，需要和同步出價的 consumer group 不同
+	ConsumerGroup string
+	// 帳號建立後多久內的出價會被視為新帳號出價，0表示不啟用
+	NewAccountAge time.Duration
+	// 最近幾次出價都由兩個帳號交替出價時視為可疑，0表示不啟用
+	AlternatingBids int
+	// 兩個帳號共同出價同一賣家多少個拍賣物品時視為關聯帳號，0表示不啟用
+	LinkedAuctions int
+}
+
+// rules 轉換成偵測規則
+func (c ShillDetectionConfig) rules() shillRules {
+	return shillRules{
+		NewAccountAge:   c.NewAccountAge,
+		AlternatingBids: c.AlternatingBids,
+		LinkedAuctions:  c.LinkedAuctions,
+	}
+}
+
+// OutboxConfig 發送 outbox 事件的背景工作設定
+type OutboxConfig struct {
+	// 背景工作檢查尚未發送事件的間隔
+	Interval time.Duration
+	// 每一批最多發送的事件數量
+	BatchSize int
+}
+
+// SSEConfig SSE 推播出價事件的設定
+type SSEConfig struct {
+	// 推播出價事件的方式，可以是 redis、postgres 或 memory，參考 SSEBackendRedis、SSEBackendPostgres 和 SSEBackendMemory
+	Backend string
+	// postgres 模式下 LISTEN/NOTIFY 使用的 channel
+	PostgresChannel string
+	// postgres 模式下超過 NOTIFY 長度限制的出價事件保留在資料表中的時間
+	PostgresSpillTTL time.Duration
+	// 每個連線尚未送出的出價事件最多保留的數量
+	QueueSize int
+	// 連線的佇列已滿時的處理方式，可以是 drop-oldest、coalesce 或 disconnect，參考 SSEOverflowDropOldest、SSEOverflowCoalesce 和 SSEOverflowDisconnect
+	OverflowPolicy string
 }
@@ -0,0 +1,297 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	redisAdapter "q4/adapters/redis"
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// deadLetterActionMaxIDs 單次重送或刪除的 dead-letter 訊息上限
+const deadLetterActionMaxIDs = 100
+
+// deadLetterCheckTimeout 重送或刪除失敗後，確認哪些訊息還在 dead-letter 中的逾時時間
+const deadLetterCheckTimeout = 5 * time.Second
+
+// List dead-letter bids
+// (GET /admin/dead-letters)
+func (impl *ServerImpl) GetAdminDeadLetters(ctx context.Context, request openapi.GetAdminDeadLettersRequestObject) (openapi.GetAdminDeadLettersResponseObject, error) {
+	const op = "GetAdminDeadLetters"
+	// 檢查使用者是否有權限查看 dead-letter 訊息
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.GetAdminDeadLetters401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.GetAdminDeadLetters401Response{}, nil
+	}
+	//  - 檢查使用者是否為管理員
+	if admin, err := impl.isActiveAdmin(token); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user role, err=%w", op, err)
+	} else if !admin {
+		return openapi.GetAdminDeadLetters403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	//  - partition
+	partition := 0
+	if request.Params.Partition != nil {
+		partition = *request.Params.Partition
+	}
+	if partition < 0 || partition >= len(impl.deadLetterQueues) {
+		return openapi.GetAdminDeadLetters400JSONResponse{
+			Message: lo.ToPtr("Invalid partition"),
+		}, nil
+	}
+	//  - cursor
+	lastID := ""
+	if request.Params.LastEntryID != nil {
+		lastID = *request.Params.LastEntryID
+	}
+	//  - size
+	size := uint32(20)
+	if request.Params.Size != nil {
+		size = *request.Params.Size
+	}
+	// 查詢 dead-letter 訊息，由新到舊排序
+	entries, err := impl.deadLetterQueues[partition].List(ctx, lastID, int64(size))
+	if err != nil {
+		if errors.Is(err, redisAdapter.ErrInvalidStreamID) {
+			return openapi.GetAdminDeadLetters400JSONResponse{
+				Message: lo.ToPtr("Invalid last entry ID"),
+			}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to list dead letters, err=%w", op, err)
+	}
+	output := make([]openapi.DeadLetterEntry, len(entries))
+	for i, entry := range entries {
+		output[i] = openapi.DeadLetterEntry{
+			Id:        entry.ID,
+			Partition: partition,
+			Error:     entry.Error,
+		}
+		if entry.Group != "" {
+			output[i].Group = lo.ToPtr(entry.Group)
+		}
+		if entry.ParseError != nil {
+			output[i].ParseError = lo.ToPtr(entry.ParseError.Error())
+			continue
+		}
+		output[i].Bid = &openapi.DeadLetterBid{
+			ItemID:    entry.Data.ItemID,
+			UserID:    entry.Data.User.ID,
+			Username:  entry.Data.User.Name,
+			Amount:    entry.Data.Amount,
+			CreatedAt: entry.Data.CreatedAt,
+		}
+	}
+	return openapi.GetAdminDeadLetters200JSONResponse{
+		Count:      len(output),
+		Entries:    output,
+		Partitions: len(impl.deadLetterQueues),
+	}, nil
+}
+
+// Replay dead-letter bids
+// (POST /admin/dead-letters/replay)
+func (impl *ServerImpl) PostAdminDeadLettersReplay(ctx context.Context, request openapi.PostAdminDeadLettersReplayRequestObject) (openapi.PostAdminDeadLettersReplayResponseObject, error) {
+	const op = "PostAdminDeadLettersReplay"
+	// 檢查使用者是否有權限重送 dead-letter 訊息
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostAdminDeadLettersReplay401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostAdminDeadLettersReplay401Response{}, nil
+	}
+	//  - 檢查使用者是否為管理員
+	if admin, err := impl.isActiveAdmin(token); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user role, err=%w", op, err)
+	} else if !admin {
+		return openapi.PostAdminDeadLettersReplay403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 重送訊息並記錄稽核紀錄
+	result, message, err := impl.processDeadLetters(ctx, token, *request.Body, models.AuditActionReplayDeadLetter)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	if message != "" {
+		return openapi.PostAdminDeadLettersReplay400JSONResponse{
+			Message: lo.ToPtr(message),
+		}, nil
+	}
+	return openapi.PostAdminDeadLettersReplay200JSONResponse(result), nil
+}
+
+// Discard dead-letter bids
+// (POST /admin/dead-letters/discard)
+func (impl *ServerImpl) PostAdminDeadLettersDiscard(ctx context.Context, request openapi.PostAdminDeadLettersDiscardRequestObject) (openapi.PostAdminDeadLettersDiscardResponseObject, error) {
+	const op = "PostAdminDeadLettersDiscard"
+	// 檢查使用者是否有權限刪除 dead-letter 訊息
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostAdminDeadLettersDiscard401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostAdminDeadLettersDiscard401Response{}, nil
+	}
+	//  - 檢查使用者是否為管理員
+	if admin, err := impl.isActiveAdmin(token); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user role, err=%w", op, err)
+	} else if !admin {
+		return openapi.PostAdminDeadLettersDiscard403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 刪除訊息並記錄稽核紀錄
+	result, message, err := impl.processDeadLetters(ctx, token, *request.Body, models.AuditActionDiscardDeadLetter)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	if message != "" {
+		return openapi.PostAdminDeadLettersDiscard400JSONResponse{
+			Message: lo.ToPtr(message),
+		}, nil
+	}
+	return openapi.PostAdminDeadLettersDiscard200JSONResponse(result), nil
+}
+
+// processDeadLetters 重送或刪除 dead-letter 訊息，並為每一筆成功處理的訊息記錄稽核紀錄
+// 請求不合法時返回錯誤訊息(message)，由呼叫者轉換成400的回應
+//
+// NOTE: Redis 的操作無法和資料庫的交易一起回滾，所以先寫入稽核紀錄再操作 Redis，不會有處理過卻沒有稽核紀錄的訊息。
+// 訊息已經被其他請求處理時，刪除對應的稽核紀錄，刪除失敗時只能記錄錯誤，由管理員依照日誌清除。
+// Redis 操作返回錯誤時(例如逾時)script 可能已經執行，所以重新讀取 dead-letter，只刪除確定還沒有被處理的訊息的稽核紀錄，
+// 無法確認時保留所有稽核紀錄
+func (impl *ServerImpl) processDeadLetters(ctx context.Context, token *openapi.JWT, body openapi.DeadLetterAction, action models.AuditAction) (result openapi.DeadLetterActionResult, message string, err error) {
+	// 檢查請求內容
+	if body.Partition < 0 || body.Partition >= len(impl.deadLetterQueues) {
+		return result, "Invalid partition", nil
+	}
+	ids := lo.Uniq(body.Ids)
+	if len(ids) == 0 {
+		return result, "IDs are required", nil
+	}
+	if len(ids) > deadLetterActionMaxIDs {
+		return result, fmt.Sprintf("At most %d IDs can be processed at once", deadLetterActionMaxIDs), nil
+	}
+	reason := strings.TrimSpace(body.Reason)
+	if len(reason) == 0 {
+		return result, "Reason is required", nil
+	}
+	queue := impl.deadLetterQueues[body.Partition]
+	stream := redisAdapter.DeadLetterStream(bidStreamKey(impl.config.Redis, body.Partition))
+	// 先讀取訊息，稽核紀錄以出價的拍賣物品作為操作對象
+	entries, err := queue.Get(ctx, ids...)
+	if err != nil {
+		if errors.Is(err, redisAdapter.ErrInvalidStreamID) {
+			return result, "Invalid entry ID", nil
+		}
+		return result, "", fmt.Errorf("fail to get dead letters, err=%w", err)
+	}
+	if action != models.AuditActionReplayDeadLetter && action != models.AuditActionDiscardDeadLetter {
+		return result, "", fmt.Errorf("unsupported dead letter action %s", action)
+	}
+	// 先記錄稽核紀錄
+	actorID := uuid.MustParse(token.Subject)
+	auditLogs := make(map[string]*models.AuditLog, len(entries))
+	existing := make([]string, 0, len(entries))
+	if len(entries) > 0 {
+		logs := make([]models.AuditLog, len(entries))
+		for i, entry := range entries {
+			logs[i] = models.AuditLog{
+				ActorID:   actorID,
+				Action:    action,
+				TargetRef: stream + "/" + entry.ID,
+				Reason:    reason,
+			}
+			if entry.ParseError == nil {
+				logs[i].TargetID = entry.Data.ItemID
+			}
+			auditLogs[entry.ID] = &logs[i]
+			existing = append(existing, entry.ID)
+		}
+		if err := impl.db.Create(&logs).Error; err != nil {
+			return result, "", fmt.Errorf("fail to create audit logs, err=%w", err)
+		}
+	}
+	// 重送或刪除訊息
+	var processed []string
+	if len(existing) > 0 {
+		if action == models.AuditActionReplayDeadLetter {
+			processed, err = queue.Replay(ctx, existing...)
+		} else {
+			processed, err = queue.Discard(ctx, existing...)
+		}
+		if err != nil {
+			impl.deleteRemainingDeadLetterAuditLogs(ctx, queue, token, action, stream, reason, auditLogs, existing)
+			return result, "", fmt.Errorf("fail to %s, err=%w", action, err)
+		}
+	}
+	//  - 讀取之後被其他請求處理的訊息沒有被這次請求處理，不保留稽核紀錄
+	if unprocessed, _ := lo.Difference(existing, processed); len(unprocessed) > 0 {
+		impl.deleteDeadLetterAuditLogs(token, action, stream, reason, auditLogs, unprocessed)
+	}
+	slog.Info("Dead letters processed", slog.String("admin", token.Subject), slog.String("action", string(action)), slog.String("stream", stream), slog.Any("ids", processed), slog.String("reason", reason))
+	result.Processed = lo.Ternary(processed == nil, []string{}, processed)
+	result.Missing, _ = lo.Difference(ids, result.Processed)
+	return result, "", nil
+}
+
+// deleteRemainingDeadLetterAuditLogs 在重送或刪除失敗後確認仍然在 dead-letter 中的訊息，只刪除這些訊息的稽核紀錄
+// 已經不在 dead-letter 中的訊息可能已經被處理，保留稽核紀錄；確認失敗時保留所有稽核紀錄並記錄錯誤
+func (impl *ServerImpl) deleteRemainingDeadLetterAuditLogs(ctx context.Context, queue redisAdapter.IDeadLetterQueue[BidInfo], token *openapi.JWT, action models.AuditAction, stream, reason string, auditLogs map[string]*models.AuditLog, ids []string) {
+	//  - 請求的 context 可能已經逾時，以獨立的逾時時間確認
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadLetterCheckTimeout)
+	defer cancel()
+	remaining, err := queue.Get(checkCtx, ids...)
+	if err != nil {
+		slog.Error("Fail to check dead letters after failed action, keep audit logs",
+			slog.String("admin", token.Subject),
+			slog.String("action", string(action)),
+			slog.String("stream", stream),
+			slog.Any("ids", ids),
+			slog.Any("error", err),
+		)
+		return
+	}
+	if len(remaining) > 0 {
+		impl.deleteDeadLetterAuditLogs(token, action, stream, reason, auditLogs, lo.Map(remaining, func(entry redisAdapter.DeadLetterEntry[BidInfo], _ int) string { return entry.ID }))
+	}
+}
+
+// deleteDeadLetterAuditLogs 刪除沒有實際處理的 dead-letter 訊息的稽核紀錄，刪除失敗時只記錄錯誤
+func (impl *ServerImpl) deleteDeadLetterAuditLogs(token *openapi.JWT, action models.AuditAction, stream, reason string, auditLogs map[string]*models.AuditLog, ids []string) {
+	logIDs := lo.Map(ids, func(id string, _ int) uuid.UUID { return auditLogs[id].ID })
+	if err := impl.db.Unscoped().Where("id IN ?", logIDs).Delete(&models.AuditLog{}).Error; err != nil {
+		slog.Error("Fail to delete audit logs of unprocessed dead letters",
+			slog.String("admin", token.Subject),
+			slog.String("action", string(action)),
+			slog.String("stream", stream),
+			slog.Any("ids", ids),
+			slog.Any("auditLogIDs", logIDs),
+			slog.String("reason", reason),
+			slog.Any("error", err),
+		)
+	}
+}
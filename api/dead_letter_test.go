@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	redisAdapter "q4/adapters/redis"
+	"q4/api/openapi"
+	"q4/models"
+)
+
+func TestProcessDeadLetters_UncertainFailure(t *testing.T) {
+	db, user, auction := setupBidSyncDB(t)
+	ctx := context.Background()
+	token := &openapi.JWT{Role: openapi.Admin}
+	token.Subject = user.ID.String()
+	t.Cleanup(func() {
+		db.Unscoped().Where("actor_id = ?", user.ID).Delete(&models.AuditLog{})
+	})
+	entry := func(id string) redisAdapter.DeadLetterEntry[BidInfo] {
+		return redisAdapter.DeadLetterEntry[BidInfo]{ID: id, Data: BidInfo{ItemID: auction.ID}}
+	}
+	auditRefs := func() []string {
+		var refs []string
+		require.NoError(t, db.Model(&models.AuditLog{}).Where("actor_id = ?", user.ID).Order("target_ref").Pluck("target_ref", &refs).Error)
+		return refs
+	}
+
+	t.Run("只刪除仍然在 dead-letter 中的訊息的稽核紀錄", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		queue := redisAdapter.NewMockIDeadLetterQueue[BidInfo](ctrl)
+		impl := &ServerImpl{db: db, deadLetterQueues: []redisAdapter.IDeadLetterQueue[BidInfo]{queue}}
+		stream := redisAdapter.DeadLetterStream(bidStreamKey(impl.config.Redis, 0))
+		gomock.InOrder(
+			queue.EXPECT().Get(gomock.Any(), "1-0", "2-0").Return([]redisAdapter.DeadLetterEntry[BidInfo]{entry("1-0"), entry("2-0")}, nil),
+			// 逾時時 script 可能已經刪除部分訊息
+			queue.EXPECT().Discard(gomock.Any(), "1-0", "2-0").Return(nil, context.DeadlineExceeded),
+			queue.EXPECT().Get(gomock.Any(), "1-0", "2-0").Return([]redisAdapter.DeadLetterEntry[BidInfo]{entry("2-0")}, nil),
+		)
+
+		_, _, err := impl.processDeadLetters(ctx, token, openapi.DeadLetterAction{Ids: []string{"1-0", "2-0"}, Reason: "test"}, models.AuditActionDiscardDeadLetter)
+		assert.Error(t, err)
+		assert.Equal(t, []string{stream + "/1-0"}, auditRefs())
+	})
+
+	t.Run("無法確認時保留所有稽核紀錄", func(t *testing.T) {
+		db.Unscoped().Where("actor_id = ?", user.ID).Delete(&models.AuditLog{})
+		ctrl := gomock.NewController(t)
+		queue := redisAdapter.NewMockIDeadLetterQueue[BidInfo](ctrl)
+		impl := &ServerImpl{db: db, deadLetterQueues: []redisAdapter.IDeadLetterQueue[BidInfo]{queue}}
+		stream := redisAdapter.DeadLetterStream(bidStreamKey(impl.config.Redis, 0))
+		gomock.InOrder(
+			queue.EXPECT().Get(gomock.Any(), "1-0", "2-0").Return([]redisAdapter.DeadLetterEntry[BidInfo]{entry("1-0"), entry("2-0")}, nil),
+			queue.EXPECT().Replay(gomock.Any(), "1-0", "2-0").Return(nil, context.DeadlineExceeded),
+			queue.EXPECT().Get(gomock.Any(), "1-0", "2-0").Return(nil, errors.New("connection refused")),
+		)
+
+		_, _, err := impl.processDeadLetters(ctx, token, openapi.DeadLetterAction{Ids: []string{"1-0", "2-0"}, Reason: "test"}, models.AuditActionReplayDeadLetter)
+		assert.Error(t, err)
+		assert.Equal(t, []string{stream + "/1-0", stream + "/2-0"}, auditRefs())
+	})
+}
@@ -0,0 +1,368 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// exportFlushRows 串流匯出時，每寫入幾筆資料就送出一次給客戶端
+const exportFlushRows = 100
+
+// exportRecord 代表可以匯出的一筆資料，NDJSON 使用 json 標籤，CSV 使用 csvRecord 的欄位順序
+type exportRecord interface {
+	csvRecord() []string
+}
+
+// auctionItemExport 代表匯出的拍賣物品
+type auctionItemExport struct {
+	ID            uuid.UUID             `gorm:"column:id" json:"id"`
+	SellerID      uuid.UUID             `gorm:"column:seller_id" json:"sellerID"`
+	Title         string                `gorm:"column:title" json:"title"`
+	Status        openapi.AuctionStatus `gorm:"column:status" json:"status"`
+	StartingPrice uint32                `gorm:"column:starting_price" json:"startingPrice"`
+	ReservePrice  uint32                `gorm:"column:reserve_price" json:"reservePrice"`
+	FinalBid      *uint32               `gorm:"column:final_bid" json:"finalBid"`
+	FinalBidderID *uuid.UUID            `gorm:"column:final_bidder_id" json:"finalBidderID"`
+	StartTime     time.Time             `gorm:"column:start_time" json:"startTime"`
+	EndTime       time.Time             `gorm:"column:end_time" json:"endTime"`
+	CreatedAt     time.Time             `gorm:"column:created_at" json:"createdAt"`
+}
+
+var auctionItemExportHeader = []string{"id", "sellerID", "title", "status", "startingPrice", "reservePrice", "finalBid", "finalBidderID", "startTime", "endTime", "createdAt"}
+
+func (r auctionItemExport) csvRecord() []string {
+	return []string{
+		r.ID.String(),
+		r.SellerID.String(),
+		r.Title,
+		string(r.Status),
+		strconv.FormatUint(uint64(r.StartingPrice), 10),
+		strconv.FormatUint(uint64(r.ReservePrice), 10),
+		lo.TernaryF(r.FinalBid != nil, func() string { return strconv.FormatUint(uint64(*r.FinalBid), 10) }, lo.Empty[string]),
+		lo.TernaryF(r.FinalBidderID != nil, func() string { return r.FinalBidderID.String() }, lo.Empty[string]),
+		r.StartTime.Format(time.RFC3339),
+		r.EndTime.Format(time.RFC3339),
+		r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// bidExport 代表匯出的出價紀錄
+type bidExport struct {
+	ID            uuid.UUID `gorm:"column:id" json:"id"`
+	AuctionItemID uuid.UUID `gorm:"column:auction_item_id" json:"auctionItemID"`
+	AuctionTitle  string    `gorm:"column:auction_title" json:"auctionTitle"`
+	UserID        uuid.UUID `gorm:"column:user_id" json:"userID"`
+	Username      string    `gorm:"column:username" json:"username"`
+	Amount        uint32    `gorm:"column:amount" json:"amount"`
+	CreatedAt     time.Time `gorm:"column:created_at" json:"createdAt"`
+}
+
+var bidExportHeader = []string{"id", "auctionItemID", "auctionTitle", "userID", "username", "amount", "createdAt"}
+
+func (r bidExport) csvRecord() []string {
+	return []string{
+		r.ID.String(),
+		r.AuctionItemID.String(),
+		r.AuctionTitle,
+		r.UserID.String(),
+		r.Username,
+		strconv.FormatUint(uint64(r.Amount), 10),
+		r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// exportEncoder 將匯出的資料依照格式寫入
+type exportEncoder interface {
+	Encode(record exportRecord) error
+	Flush() error
+}
+
+// newExportEncoder 依照檔案格式建立 exportEncoder，CSV 會先寫入標題列
+func newExportEncoder(format openapi.FileFormat, w io.Writer, header []string) (exportEncoder, error) {
+	switch format {
+	case openapi.Csv:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(header); err != nil {
+			return nil, err
+		}
+		return csvExportEncoder{writer}, nil
+	case openapi.Ndjson:
+		return ndjsonExportEncoder{json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+type csvExportEncoder struct {
+	writer *csv.Writer
+}
+
+func (e csvExportEncoder) Encode(record exportRecord) error {
+	cells := record.csvRecord()
+	for i, cell := range cells {
+		cells[i] = escapeCSVFormula(cell)
+	}
+	return e.writer.Write(cells)
+}
+
+// escapeCSVFormula 在可能被試算表當作公式的欄位前加上單引號，避免使用者輸入的標題或名稱在開啟檔案時被執行
+func escapeCSVFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+func (e csvExportEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type ndjsonExportEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e ndjsonExportEncoder) Encode(record exportRecord) error {
+	return e.encoder.Encode(record)
+}
+
+func (e ndjsonExportEncoder) Flush() error {
+	return nil
+}
+
+// exportContentType 取得檔案格式對應的 Content-Type
+func exportContentType(format openapi.FileFormat) string {
+	if format == openapi.Ndjson {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// streamExport 使用資料庫游標逐筆讀取查詢結果並串流寫入回應，不會把所有資料載入記憶體
+// NOTE: 開始寫入回應前發生錯誤時回傳錯誤，由呼叫端回應錯誤；開始寫入回應後發生錯誤時只能中斷串流，錯誤只會記錄在日誌
+func streamExport[T exportRecord](c *gin.Context, query *gorm.DB, format openapi.FileFormat, name string, header []string, transform func(*T)) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return fmt.Errorf("fail to query %s, err=%w", name, err)
+	}
+	defer rows.Close()
+
+	w := c.Writer
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102T150405Z"), format)
+	w.Header().Set("Content-Type", exportContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(200)
+	logger := slog.Default().With(slog.String("caller", "StreamExport"), slog.String("name", name))
+	encoder, err := newExportEncoder(format, w, header)
+	if err != nil {
+		logger.Error("Fail to create encoder", slog.Any("error", err))
+		return nil
+	}
+	count := 0
+	for rows.Next() {
+		var record T
+		if err := query.ScanRows(rows, &record); err != nil {
+			logger.Error("Fail to scan row", slog.Int("count", count), slog.Any("error", err))
+			return nil
+		}
+		if transform != nil {
+			transform(&record)
+		}
+		if err := encoder.Encode(record); err != nil {
+			logger.Error("Fail to write row", slog.Int("count", count), slog.Any("error", err))
+			return nil
+		}
+		count++
+		if count%exportFlushRows == 0 {
+			if err := encoder.Flush(); err != nil {
+				logger.Error("Fail to flush rows", slog.Int("count", count), slog.Any("error", err))
+				return nil
+			}
+			w.Flush()
+		}
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Fail to iterate rows", slog.Int("count", count), slog.Any("error", err))
+		return nil
+	}
+	if err := encoder.Flush(); err != nil {
+		logger.Error("Fail to flush rows", slog.Int("count", count), slog.Any("error", err))
+		return nil
+	}
+	w.Flush()
+	logger.Info("Export finished", slog.Int("count", count))
+	return nil
+}
+
+// exportScope 檢查匯出參數並回傳可以匯出的賣家範圍，賣家 ID 為 nil 表示不限制賣家，參數不合法時回傳錯誤訊息
+// 管理員可以匯出所有資料，一般使用者只能匯出自己的資料，指定其他賣家時 forbidden 為 true
+func (impl *ServerImpl) exportScope(token *openapi.JWT, format openapi.FileFormat, from, to *time.Time, sellerID *uuid.UUID) (scope *uuid.UUID, msg string, forbidden bool, err error) {
+	if format != openapi.Csv && format != openapi.Ndjson {
+		return nil, "Invalid format", false, nil
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, "Invalid time range", false, nil
+	}
+	admin, err := impl.isActiveAdmin(token)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("fail to check user role, err=%w", err)
+	}
+	if admin {
+		return sellerID, "", false, nil
+	}
+	userID := uuid.MustParse(token.Subject)
+	if sellerID != nil && *sellerID != userID {
+		return nil, "Cannot export data of other sellers", true, nil
+	}
+	return &userID, "", false, nil
+}
+
+// Export auction items
+// (GET /auction/items/export)
+func (impl *ServerImpl) GetAuctionItemsExport(ctx context.Context, request openapi.GetAuctionItemsExportRequestObject) (openapi.GetAuctionItemsExportResponseObject, error) {
+	const op = "GetAuctionItemsExport"
+	// 檢查使用者是否有權限匯出資料
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.GetAuctionItemsExport401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.GetAuctionItemsExport401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.GetAuctionItemsExport403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	//  - 決定可以匯出的範圍
+	sellerID, msg, forbidden, err := impl.exportScope(token, request.Params.Format, request.Params.From, request.Params.To, request.Params.SellerID)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	if forbidden {
+		return openapi.GetAuctionItemsExport403JSONResponse{
+			Message: lo.ToPtr(msg),
+		}, nil
+	}
+	if msg != "" {
+		return openapi.GetAuctionItemsExport400JSONResponse{
+			Message: lo.ToPtr(msg),
+		}, nil
+	}
+	// 建立查詢
+	query := impl.db.WithContext(ctx).Table("auction_items").
+		Select("auction_items.id, auction_items.user_id AS seller_id, auction_items.title, auction_items.status, " +
+			"auction_items.starting_price, auction_items.reserve_price, bids.amount AS final_bid, bids.user_id AS final_bidder_id, " +
+			"auction_items.start_time, auction_items.end_time, auction_items.created_at").
+		Joins("LEFT JOIN bids ON bids.id = auction_items.current_bid_id").
+		Where("auction_items.deleted_at IS NULL")
+	if sellerID != nil {
+		query = query.Where("auction_items.user_id = ?", *sellerID)
+	}
+	if request.Params.ItemID != nil {
+		query = query.Where("auction_items.id = ?", *request.Params.ItemID)
+	}
+	if request.Params.From != nil {
+		query = query.Where("auction_items.end_time >= ?", *request.Params.From)
+	}
+	if request.Params.To != nil {
+		query = query.Where("auction_items.end_time < ?", *request.Params.To)
+	}
+	query = query.Order("auction_items.id")
+	// 串流匯出
+	//  - 資料庫的狀態由背景工作更新，匯出時依照拍賣時間換算成當下的狀態
+	now := time.Now()
+	err = streamExport(ctx.(*gin.Context), query, request.Params.Format, "auction-items", auctionItemExportHeader, func(r *auctionItemExport) {
+		r.Status = (&models.AuctionItem{Status: r.Status, StartTime: r.StartTime, EndTime: r.EndTime}).EffectiveStatus(now)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] Fail to export auction items, err=%w", op, err)
+	}
+	return openapi.GetAuctionItemsExport200Response{}, nil
+}
+
+// Export bid history
+// (GET /auction/bids/export)
+func (impl *ServerImpl) GetAuctionBidsExport(ctx context.Context, request openapi.GetAuctionBidsExportRequestObject) (openapi.GetAuctionBidsExportResponseObject, error) {
+	const op = "GetAuctionBidsExport"
+	// 檢查使用者是否有權限匯出資料
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.GetAuctionBidsExport401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.GetAuctionBidsExport401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.GetAuctionBidsExport403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	//  - 決定可以匯出的範圍
+	sellerID, msg, forbidden, err := impl.exportScope(token, request.Params.Format, request.Params.From, request.Params.To, request.Params.SellerID)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
+	if forbidden {
+		return openapi.GetAuctionBidsExport403JSONResponse{
+			Message: lo.ToPtr(msg),
+		}, nil
+	}
+	if msg != "" {
+		return openapi.GetAuctionBidsExport400JSONResponse{
+			Message: lo.ToPtr(msg),
+		}, nil
+	}
+	// 建立查詢
+	query := impl.db.WithContext(ctx).Table("bids").
+		Select("bids.id, bids.auction_item_id, auction_items.title AS auction_title, bids.user_id, users.username, bids.amount, bids.created_at").
+		Joins("JOIN auction_items ON auction_items.id = bids.auction_item_id").
+		Joins("JOIN users ON users.id = bids.user_id").
+		Where("bids.deleted_at IS NULL")
+	if sellerID != nil {
+		query = query.Where("auction_items.user_id = ?", *sellerID)
+	}
+	if request.Params.ItemID != nil {
+		query = query.Where("bids.auction_item_id = ?", *request.Params.ItemID)
+	}
+	if request.Params.From != nil {
+		query = query.Where("bids.created_at >= ?", *request.Params.From)
+	}
+	if request.Params.To != nil {
+		query = query.Where("bids.created_at < ?", *request.Params.To)
+	}
+	query = query.Order("bids.id")
+	// 串流匯出
+	if err := streamExport[bidExport](ctx.(*gin.Context), query, request.Params.Format, "bids", bidExportHeader, nil); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to export bids, err=%w", op, err)
+	}
+	return openapi.GetAuctionBidsExport200Response{}, nil
+}
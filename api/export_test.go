@@ -0,0 +1,230 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"q4/api/openapi"
+	"q4/models"
+)
+
+func TestExportEncoder(t *testing.T) {
+	itemID := uuid.MustParse("01900000-0000-7000-8000-000000000001")
+	sellerID := uuid.MustParse("01900000-0000-7000-8000-000000000002")
+	bidderID := uuid.MustParse("01900000-0000-7000-8000-000000000003")
+	at := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	records := []auctionItemExport{
+		{ID: itemID, SellerID: sellerID, Title: "Item, \"1\"", Status: openapi.Settled, StartingPrice: 10, ReservePrice: 20, FinalBid: lo.ToPtr(uint32(30)), FinalBidderID: &bidderID, StartTime: at, EndTime: at, CreatedAt: at},
+		{ID: itemID, SellerID: sellerID, Title: "Item 2", Status: openapi.Live, StartTime: at, EndTime: at, CreatedAt: at},
+		{ID: itemID, SellerID: sellerID, Title: "=HYPERLINK(\"http://example.com\")", Status: openapi.Live, StartTime: at, EndTime: at, CreatedAt: at},
+	}
+
+	tests := []struct {
+		name    string
+		format  openapi.FileFormat
+		records []auctionItemExport
+		want    string
+	}{
+		{
+			name:    "CSV 沒有資料時只有標題列",
+			format:  openapi.Csv,
+			records: nil,
+			want:    "id,sellerID,title,status,startingPrice,reservePrice,finalBid,finalBidderID,startTime,endTime,createdAt\n",
+		},
+		{
+			name:    "CSV 逐筆寫入並跳脫特殊字元",
+			format:  openapi.Csv,
+			records: records[:2],
+			want: "id,sellerID,title,status,startingPrice,reservePrice,finalBid,finalBidderID,startTime,endTime,createdAt\n" +
+				"01900000-0000-7000-8000-000000000001,01900000-0000-7000-8000-000000000002,\"Item, \"\"1\"\"\",settled,10,20,30,01900000-0000-7000-8000-000000000003,2030-01-02T03:04:05Z,2030-01-02T03:04:05Z,2030-01-02T03:04:05Z\n" +
+				"01900000-0000-7000-8000-000000000001,01900000-0000-7000-8000-000000000002,Item 2,live,0,0,,,2030-01-02T03:04:05Z,2030-01-02T03:04:05Z,2030-01-02T03:04:05Z\n",
+		},
+		{
+			name:    "CSV 在可能被當作公式的欄位前加上單引號",
+			format:  openapi.Csv,
+			records: records[2:],
+			want: "id,sellerID,title,status,startingPrice,reservePrice,finalBid,finalBidderID,startTime,endTime,createdAt\n" +
+				"01900000-0000-7000-8000-000000000001,01900000-0000-7000-8000-000000000002,\"'=HYPERLINK(\"\"http://example.com\"\")\",live,0,0,,,2030-01-02T03:04:05Z,2030-01-02T03:04:05Z,2030-01-02T03:04:05Z\n",
+		},
+		{
+			name:    "NDJSON 不需要跳脫公式",
+			format:  openapi.Ndjson,
+			records: records[2:],
+			want:    `{"id":"01900000-0000-7000-8000-000000000001","sellerID":"01900000-0000-7000-8000-000000000002","title":"=HYPERLINK(\"http://example.com\")","status":"live","startingPrice":0,"reservePrice":0,"finalBid":null,"finalBidderID":null,"startTime":"2030-01-02T03:04:05Z","endTime":"2030-01-02T03:04:05Z","createdAt":"2030-01-02T03:04:05Z"}` + "\n",
+		},
+		{
+			name:    "NDJSON 每筆資料一行",
+			format:  openapi.Ndjson,
+			records: records[1:2],
+			want:    `{"id":"01900000-0000-7000-8000-000000000001","sellerID":"01900000-0000-7000-8000-000000000002","title":"Item 2","status":"live","startingPrice":0,"reservePrice":0,"finalBid":null,"finalBidderID":null,"startTime":"2030-01-02T03:04:05Z","endTime":"2030-01-02T03:04:05Z","createdAt":"2030-01-02T03:04:05Z"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder, err := newExportEncoder(tt.format, &buf, auctionItemExportHeader)
+			require.NoError(t, err)
+			for _, record := range tt.records {
+				require.NoError(t, encoder.Encode(record))
+			}
+			require.NoError(t, encoder.Flush())
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	t.Run("不支援的格式", func(t *testing.T) {
+		_, err := newExportEncoder("xml", &bytes.Buffer{}, auctionItemExportHeader)
+		assert.Error(t, err)
+	})
+}
+
+func TestExportScope(t *testing.T) {
+	impl := &ServerImpl{}
+	userID, otherID := uuid.New(), uuid.New()
+	token := &openapi.JWT{Role: openapi.User}
+	token.Subject = userID.String()
+	from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		format    openapi.FileFormat
+		from, to  *time.Time
+		sellerID  *uuid.UUID
+		want      *uuid.UUID
+		msg       string
+		forbidden bool
+	}{
+		{name: "不支援的格式", format: "xml", msg: "Invalid format"},
+		{name: "開始時間等於結束時間", format: openapi.Csv, from: &from, to: &from, msg: "Invalid time range"},
+		{name: "開始時間晚於結束時間", format: openapi.Csv, from: lo.ToPtr(from.Add(time.Hour)), to: &from, msg: "Invalid time range"},
+		{name: "只有開始時間", format: openapi.Ndjson, from: &from, want: &userID},
+		{name: "一般使用者只能匯出自己的資料", format: openapi.Csv, want: &userID},
+		{name: "一般使用者指定自己", format: openapi.Csv, sellerID: &userID, want: &userID},
+		{name: "一般使用者不能指定其他賣家", format: openapi.Csv, sellerID: &otherID, msg: "Cannot export data of other sellers", forbidden: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sellerID, msg, forbidden, err := impl.exportScope(token, tt.format, tt.from, tt.to, tt.sellerID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.msg, msg)
+			assert.Equal(t, tt.forbidden, forbidden)
+			assert.Equal(t, tt.want, sellerID)
+		})
+	}
+}
+
+// exportItems 呼叫 GetAuctionItemsExport 並解析 NDJSON 格式的回應，回應不是200時返回 nil
+func exportItems(t *testing.T, impl *ServerImpl, params openapi.GetAuctionItemsExportParams) (openapi.GetAuctionItemsExportResponseObject, []auctionItemExport) {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	params.Format = openapi.Ndjson
+	resp, err := impl.GetAuctionItemsExport(c, openapi.GetAuctionItemsExportRequestObject{Params: params})
+	require.NoError(t, err)
+	if _, ok := resp.(openapi.GetAuctionItemsExport200Response); !ok {
+		return resp, nil
+	}
+	var records []auctionItemExport
+	scanner := bufio.NewScanner(recorder.Body)
+	for scanner.Scan() {
+		var record auctionItemExport
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	return resp, records
+}
+
+func TestGetAuctionItemsExport(t *testing.T) {
+	db, seller, auction := setupBidSyncDB(t)
+	impl := newTestListingServer(t)
+	impl.db = db
+
+	other := models.User{Username: "export-test-other"}
+	admin := models.User{Username: "export-test-admin", Role: openapi.Admin}
+	require.NoError(t, db.Create(&other).Error)
+	require.NoError(t, db.Create(&admin).Error)
+	later := models.AuctionItem{UserID: seller.ID, Title: "export-test-later", StartTime: time.Now(), EndTime: time.Now().Add(3 * time.Hour), Status: openapi.Live}
+	otherAuction := models.AuctionItem{UserID: other.ID, Title: "export-test-other", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour), Status: openapi.Live}
+	require.NoError(t, db.Create(&later).Error)
+	require.NoError(t, db.Create(&otherAuction).Error)
+	t.Cleanup(func() {
+		db.Unscoped().Delete(&later)
+		db.Unscoped().Delete(&otherAuction)
+		db.Unscoped().Delete(&other)
+		db.Unscoped().Delete(&admin)
+	})
+	sellerToken := newTestAccessToken(t, impl, seller)
+	adminToken := newTestAccessToken(t, impl, admin)
+	ids := func(records []auctionItemExport) []uuid.UUID {
+		return lo.Map(records, func(r auctionItemExport, _ int) uuid.UUID { return r.ID })
+	}
+
+	t.Run("沒有 access token", func(t *testing.T) {
+		resp, _ := exportItems(t, impl, openapi.GetAuctionItemsExportParams{})
+		assert.IsType(t, openapi.GetAuctionItemsExport401Response{}, resp)
+	})
+
+	t.Run("賣家只能匯出自己的資料", func(t *testing.T) {
+		_, records := exportItems(t, impl, openapi.GetAuctionItemsExportParams{AccessToken: sellerToken})
+		assert.ElementsMatch(t, []uuid.UUID{auction.ID, later.ID}, ids(records))
+
+		// 指定其他賣家時拒絕匯出
+		resp, _ := exportItems(t, impl, openapi.GetAuctionItemsExportParams{AccessToken: sellerToken, SellerID: &other.ID})
+		assert.IsType(t, openapi.GetAuctionItemsExport403JSONResponse{}, resp)
+	})
+
+	t.Run("角色不是管理員時不能匯出其他賣家的資料", func(t *testing.T) {
+		// token 宣稱是管理員，但資料庫中的角色不是
+		forged := seller
+		forged.Role = openapi.Admin
+		resp, _ := exportItems(t, impl, openapi.GetAuctionItemsExportParams{AccessToken: newTestAccessToken(t, impl, forged), SellerID: &other.ID})
+		assert.IsType(t, openapi.GetAuctionItemsExport403JSONResponse{}, resp)
+	})
+
+	t.Run("管理員可以指定賣家", func(t *testing.T) {
+		_, records := exportItems(t, impl, openapi.GetAuctionItemsExportParams{AccessToken: adminToken, SellerID: &other.ID})
+		assert.Equal(t, []uuid.UUID{otherAuction.ID}, ids(records))
+		assert.Equal(t, other.ID, records[0].SellerID)
+
+		// 沒有指定賣家時匯出所有賣家的資料
+		_, records = exportItems(t, impl, openapi.GetAuctionItemsExportParams{AccessToken: adminToken})
+		assert.Subset(t, ids(records), []uuid.UUID{auction.ID, later.ID, otherAuction.ID})
+	})
+
+	t.Run("依照結束時間篩選", func(t *testing.T) {
+		_, records := exportItems(t, impl, openapi.GetAuctionItemsExportParams{AccessToken: sellerToken, From: lo.ToPtr(time.Now().Add(2 * time.Hour))})
+		assert.Equal(t, []uuid.UUID{later.ID}, ids(records))
+
+		_, records = exportItems(t, impl, openapi.GetAuctionItemsExportParams{AccessToken: sellerToken, To: lo.ToPtr(time.Now().Add(2 * time.Hour))})
+		assert.Equal(t, []uuid.UUID{auction.ID}, ids(records))
+
+		resp, _ := exportItems(t, impl, openapi.GetAuctionItemsExportParams{AccessToken: sellerToken, From: lo.ToPtr(time.Now()), To: lo.ToPtr(time.Now().Add(-time.Hour))})
+		assert.Equal(t, openapi.GetAuctionItemsExport400JSONResponse{Message: lo.ToPtr("Invalid time range")}, resp)
+	})
+
+	t.Run("依照拍賣物品篩選", func(t *testing.T) {
+		_, records := exportItems(t, impl, openapi.GetAuctionItemsExportParams{AccessToken: sellerToken, ItemID: &later.ID})
+		assert.Equal(t, []uuid.UUID{later.ID}, ids(records))
+
+		// 其他賣家的拍賣物品不在範圍內
+		_, records = exportItems(t, impl, openapi.GetAuctionItemsExportParams{AccessToken: sellerToken, ItemID: &otherAuction.ID})
+		assert.Empty(t, records)
+	})
+
+	t.Run("不支援的格式", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		resp, err := impl.GetAuctionItemsExport(c, openapi.GetAuctionItemsExportRequestObject{Params: openapi.GetAuctionItemsExportParams{AccessToken: sellerToken, Format: "xml"}})
+		require.NoError(t, err)
+		assert.Equal(t, openapi.GetAuctionItemsExport400JSONResponse{Message: lo.ToPtr("Invalid format")}, resp)
+	})
+}
@@ -0,0 +1,444 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// listingContent 代表建立拍賣物品需要的商品資訊，重新上架和範本建立拍賣物品時共用
+type listingContent struct {
+	Title            string
+	Description      string
+	StartingPrice    uint32
+	ReservePrice     uint32
+	Carousels        []string
+	AutoRelistRounds uint32
+}
+
+// newAuctionItem 依照商品資訊和拍賣時間建立拍賣物品，不是草稿時會直接發布
+func (c listingContent) newAuctionItem(userID uuid.UUID, startTime, endTime time.Time, draft bool, now time.Time) models.AuctionItem {
+	auction := models.AuctionItem{
+		UserID:           userID,
+		Title:            c.Title,
+		Description:      c.Description,
+		StartingPrice:    c.StartingPrice,
+		ReservePrice:     c.ReservePrice,
+		StartTime:        startTime,
+		EndTime:          endTime,
+		Carousels:        pq.StringArray(c.Carousels),
+		AutoRelistRounds: c.AutoRelistRounds,
+		Status:           openapi.Draft,
+	}
+	if !draft {
+		auction.Publish(now)
+	}
+	return auction
+}
+
+// auctionContent 取得拍賣物品的商品資訊
+func auctionContent(auction models.AuctionItem) listingContent {
+	return listingContent{
+		Title:            auction.Title,
+		Description:      auction.Description,
+		StartingPrice:    auction.StartingPrice,
+		ReservePrice:     auction.ReservePrice,
+		Carousels:        auction.Carousels,
+		AutoRelistRounds: auction.AutoRelistRounds,
+	}
+}
+
+// templateContent 取得範本的商品資訊
+func templateContent(template models.ListingTemplate) listingContent {
+	return listingContent{
+		Title:            template.Title,
+		Description:      template.Description,
+		StartingPrice:    template.StartingPrice,
+		ReservePrice:     template.ReservePrice,
+		Carousels:        template.Carousels,
+		AutoRelistRounds: template.AutoRelistRounds,
+	}
+}
+
+// validAuctionTime 檢查拍賣時間是否合法，開始時間不能晚於結束時間，且結束時間不能早於現在
+func validAuctionTime(startTime, endTime, now time.Time) bool {
+	return !startTime.After(endTime) && !endTime.Before(now)
+}
+
+// validateListingPrice 檢查價格和自動重新上架次數是否合法，不合法時回傳錯誤訊息
+func (impl *ServerImpl) validateListingPrice(startingPrice, reservePrice, autoRelistRounds *int64) string {
+	if startingPrice != nil && (*startingPrice < 0 || *startingPrice > math.MaxUint32) {
+		return "Invalid starting price"
+	}
+	if reservePrice != nil && (*reservePrice < 0 || *reservePrice > math.MaxUint32) {
+		return "Invalid reserve price"
+	}
+	if autoRelistRounds != nil && (*autoRelistRounds < 0 || *autoRelistRounds > int64(impl.config.Auction.MaxAutoRelistRounds)) {
+		return fmt.Sprintf("Auto relist rounds must be between 0 and %d", impl.config.Auction.MaxAutoRelistRounds)
+	}
+	return ""
+}
+
+// validateListingContent 檢查標題、價格和自動重新上架次數是否合法，不合法時回傳錯誤訊息
+// 新增拍賣物品和儲存範本共用相同的規則，範本建立的拍賣物品不需要再次檢查
+func (impl *ServerImpl) validateListingContent(title string, startingPrice, reservePrice, autoRelistRounds *int64) string {
+	if strings.TrimSpace(title) == "" {
+		return "Title is required"
+	}
+	return impl.validateListingPrice(startingPrice, reservePrice, autoRelistRounds)
+}
+
+// uploadedImages 從圖片網址中找出由使用者上傳的圖片
+func (impl *ServerImpl) uploadedImages(userID uuid.UUID, urls []string) (map[string]struct{}, error) {
+	var uploaded []string
+	result := impl.db.Model(&models.Image{}).
+		Where("uploader_id = ? AND url IN ?", userID, urls).
+		Pluck("url", &uploaded)
+	if result.Error != nil {
+		return nil, fmt.Errorf("fail to find uploaded images, err=%w", result.Error)
+	}
+	return lo.SliceToMap(uploaded, func(url string) (string, struct{}) { return url, struct{}{} }), nil
+}
+
+// checkCarousels 檢查圖片網址是否都由使用者上傳，不合法時回傳錯誤訊息
+func (impl *ServerImpl) checkCarousels(userID uuid.UUID, carousels []string) (string, error) {
+	if len(carousels) == 0 {
+		return "", nil
+	}
+	uploaded, err := impl.uploadedImages(userID, lo.Uniq(carousels))
+	if err != nil {
+		return "", err
+	}
+	for _, url := range carousels {
+		if _, ok := uploaded[url]; !ok {
+			return fmt.Sprintf("Image is not uploaded by user: %s", url), nil
+		}
+	}
+	return "", nil
+}
+
+// auctionItemFromRequest 依照新增拍賣物品的請求內容建立拍賣物品，會檢查欄位、處理預設值和過濾描述的 HTML，
+// 不合法時回傳錯誤訊息，單筆新增和批次匯入共用相同的規則
+func (impl *ServerImpl) auctionItemFromRequest(userID uuid.UUID, body openapi.PostAuctionItemJSONRequestBody, now time.Time) (models.AuctionItem, string) {
+	if msg := impl.validateListingContent(body.Title, body.StartingPrice, body.ReservePrice, body.AutoRelistRounds); msg != "" {
+		return models.AuctionItem{}, msg
+	}
+	startTime := lo.FromPtrOr(body.StartTime, now)
+	if !validAuctionTime(startTime, body.EndTime, now) {
+		return models.AuctionItem{}, "Invalid auction time"
+	}
+	content := listingContent{
+		Title:            body.Title,
+		Description:      impl.htmlChecker.Sanitize(lo.FromPtr(body.Description)),
+		StartingPrice:    uint32(lo.FromPtr(body.StartingPrice)),
+		ReservePrice:     uint32(lo.FromPtr(body.ReservePrice)),
+		Carousels:        lo.FromPtrOr(body.Carousels, []string{}),
+		AutoRelistRounds: uint32(lo.FromPtr(body.AutoRelistRounds)),
+	}
+	//  - 草稿只有賣家看得到，發布後才會依照開始時間進入排程或進行中
+	return content.newAuctionItem(userID, startTime, body.EndTime, lo.FromPtr(body.Draft), now), ""
+}
+
+// Relist an unsold auction item
+// (POST /auction/item/{itemID}/relist)
+func (impl *ServerImpl) PostAuctionItemItemIDRelist(ctx context.Context, request openapi.PostAuctionItemItemIDRelistRequestObject) (openapi.PostAuctionItemItemIDRelistResponseObject, error) {
+	const op = "PostAuctionItemItemIDRelist"
+	// 檢查使用者是否有權限重新上架拍賣物品
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostAuctionItemItemIDRelist401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostAuctionItemItemIDRelist401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PostAuctionItemItemIDRelist403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	// 檢查拍賣物品是否存在
+	original := models.AuctionItem{ID: request.ItemID}
+	if result := impl.db.Preload("CurrentBid").First(&original); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return openapi.PostAuctionItemItemIDRelist404Response{}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to find auction item, err=%w", op, result.Error)
+	}
+	//  - 檢查使用者是否為賣家
+	if original.UserID.String() != token.Subject {
+		return openapi.PostAuctionItemItemIDRelist403JSONResponse{
+			Message: lo.ToPtr("Permission denied"),
+		}, nil
+	}
+	// 檢查拍賣物品是否可以重新上架
+	//  - 只有已結算的拍賣物品才能確定最終的出價結果
+	if original.Status != openapi.Settled {
+		return openapi.PostAuctionItemItemIDRelist409JSONResponse{
+			Message: lo.ToPtr("Auction has not been settled"),
+		}, nil
+	}
+	//  - 只有流標的拍賣物品可以重新上架
+	if !original.IsUnsold() {
+		return openapi.PostAuctionItemItemIDRelist409JSONResponse{
+			Message: lo.ToPtr("Auction has been sold"),
+		}, nil
+	}
+	// 檢查拍賣時間是否合法
+	now := time.Now()
+	startTime := lo.FromPtrOr(request.Body.StartTime, now)
+	if !validAuctionTime(startTime, request.Body.EndTime, now) {
+		return openapi.PostAuctionItemItemIDRelist400JSONResponse{
+			Message: lo.ToPtr("Invalid auction time"),
+		}, nil
+	}
+	// 建立新的拍賣物品
+	//  - 每個拍賣物品只能重新上架一次，由 relisted_from_id 的唯一索引保證
+	auction := auctionContent(original).newAuctionItem(original.UserID, startTime, request.Body.EndTime, lo.FromPtr(request.Body.Draft), now)
+	auction.RelistedFromID = &original.ID
+	err = impl.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&auction).Error; err != nil {
+			return err
+		}
+		return enqueueAuctionEvent(tx, models.OutboxEventAuctionCreated, auction)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return openapi.PostAuctionItemItemIDRelist409JSONResponse{
+				Message: lo.ToPtr("Auction has been relisted"),
+			}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to create auction item, err=%w", op, err)
+	}
+	slog.Info("Auction relisted", slog.String("user", token.Subject), slog.String("from", original.ID.String()), slog.String("auctionID", auction.ID.String()))
+	return openapi.PostAuctionItemItemIDRelist201Response{
+		Headers: openapi.PostAuctionItemItemIDRelist201ResponseHeaders{
+			Location: auction.ID.String(),
+		},
+	}, nil
+}
+
+// List listing templates
+// (GET /user/listing/templates)
+func (impl *ServerImpl) GetUserListingTemplates(ctx context.Context, request openapi.GetUserListingTemplatesRequestObject) (openapi.GetUserListingTemplatesResponseObject, error) {
+	const op = "GetUserListingTemplates"
+	// 檢查使用者是否有權限查看範本
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.GetUserListingTemplates401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.GetUserListingTemplates401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.GetUserListingTemplates403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	// 查詢範本
+	var templates []models.ListingTemplate
+	if result := impl.db.Where("user_id = ?", token.Subject).Order("name").Find(&templates); result.Error != nil {
+		return nil, fmt.Errorf("[%s] Fail to list listing templates, err=%w", op, result.Error)
+	}
+	output := make([]openapi.ListingTemplate, len(templates))
+	for i, template := range templates {
+		output[i] = openapi.ListingTemplate{
+			Id:               template.ID,
+			Name:             template.Name,
+			Title:            template.Title,
+			Description:      template.Description,
+			StartingPrice:    int64(template.StartingPrice),
+			ReservePrice:     int64(template.ReservePrice),
+			Carousels:        template.Carousels,
+			AutoRelistRounds: int64(template.AutoRelistRounds),
+			CreatedAt:        template.CreatedAt,
+		}
+	}
+	return openapi.GetUserListingTemplates200JSONResponse{
+		Count:     len(output),
+		Templates: output,
+	}, nil
+}
+
+// Save a listing template
+// (POST /user/listing/templates)
+func (impl *ServerImpl) PostUserListingTemplates(ctx context.Context, request openapi.PostUserListingTemplatesRequestObject) (openapi.PostUserListingTemplatesResponseObject, error) {
+	const op = "PostUserListingTemplates"
+	// 檢查使用者是否有權限建立範本
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostUserListingTemplates401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostUserListingTemplates401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PostUserListingTemplates403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	// 檢查範本資訊
+	name := strings.TrimSpace(request.Body.Name)
+	if len(name) == 0 || len(name) > 255 {
+		return openapi.PostUserListingTemplates400JSONResponse{
+			Message: lo.ToPtr("Invalid template name"),
+		}, nil
+	}
+	if msg := impl.validateListingContent(request.Body.Title, request.Body.StartingPrice, request.Body.ReservePrice, request.Body.AutoRelistRounds); msg != "" {
+		return openapi.PostUserListingTemplates400JSONResponse{
+			Message: lo.ToPtr(msg),
+		}, nil
+	}
+	//  - 圖片必須由使用者上傳
+	userID := uuid.MustParse(token.Subject)
+	carousels := lo.FromPtrOr(request.Body.Carousels, []string{})
+	if msg, err := impl.checkCarousels(userID, carousels); err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	} else if msg != "" {
+		return openapi.PostUserListingTemplates400JSONResponse{
+			Message: lo.ToPtr(msg),
+		}, nil
+	}
+	// 儲存範本
+	template := models.ListingTemplate{
+		UserID:           userID,
+		Name:             name,
+		Title:            request.Body.Title,
+		Description:      impl.htmlChecker.Sanitize(lo.FromPtr(request.Body.Description)),
+		StartingPrice:    uint32(lo.FromPtr(request.Body.StartingPrice)),
+		ReservePrice:     uint32(lo.FromPtr(request.Body.ReservePrice)),
+		Carousels:        carousels,
+		AutoRelistRounds: uint32(lo.FromPtr(request.Body.AutoRelistRounds)),
+	}
+	if result := impl.db.Create(&template); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return openapi.PostUserListingTemplates409Response{}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to create listing template, err=%w", op, result.Error)
+	}
+	return openapi.PostUserListingTemplates201Response{
+		Headers: openapi.PostUserListingTemplates201ResponseHeaders{
+			Location: template.ID.String(),
+		},
+	}, nil
+}
+
+// Delete a listing template
+// (DELETE /user/listing/templates/{templateID})
+func (impl *ServerImpl) DeleteUserListingTemplatesTemplateID(ctx context.Context, request openapi.DeleteUserListingTemplatesTemplateIDRequestObject) (openapi.DeleteUserListingTemplatesTemplateIDResponseObject, error) {
+	const op = "DeleteUserListingTemplatesTemplateID"
+	// 檢查使用者是否有權限刪除範本
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.DeleteUserListingTemplatesTemplateID401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.DeleteUserListingTemplatesTemplateID401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.DeleteUserListingTemplatesTemplateID403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	// 刪除範本，只能刪除自己的範本
+	result := impl.db.Where("id = ? AND user_id = ?", request.TemplateID, token.Subject).Delete(&models.ListingTemplate{})
+	if result.Error != nil {
+		return nil, fmt.Errorf("[%s] Fail to delete listing template, err=%w", op, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return openapi.DeleteUserListingTemplatesTemplateID404Response{}, nil
+	}
+	return openapi.DeleteUserListingTemplatesTemplateID200Response{}, nil
+}
+
+// Create an auction item from a template
+// (POST /user/listing/templates/{templateID}/item)
+func (impl *ServerImpl) PostUserListingTemplatesTemplateIDItem(ctx context.Context, request openapi.PostUserListingTemplatesTemplateIDItemRequestObject) (openapi.PostUserListingTemplatesTemplateIDItemResponseObject, error) {
+	const op = "PostUserListingTemplatesTemplateIDItem"
+	// 檢查使用者是否有權限新增拍賣物品
+	//  - 檢查是否有提供access token
+	if request.Params.AccessToken == nil {
+		return openapi.PostUserListingTemplatesTemplateIDItem401Response{}, nil
+	}
+	//  - 解析並驗證access token
+	token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+	if err != nil {
+		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
+		return openapi.PostUserListingTemplatesTemplateIDItem401Response{}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PostUserListingTemplatesTemplateIDItem403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
+	// 檢查範本是否存在
+	var template models.ListingTemplate
+	if result := impl.db.Where("id = ? AND user_id = ?", request.TemplateID, token.Subject).First(&template); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return openapi.PostUserListingTemplatesTemplateIDItem404Response{}, nil
+		}
+		return nil, fmt.Errorf("[%s] Fail to find listing template, err=%w", op, result.Error)
+	}
+	// 檢查拍賣時間是否合法
+	now := time.Now()
+	startTime := lo.FromPtrOr(request.Body.StartTime, now)
+	if !validAuctionTime(startTime, request.Body.EndTime, now) {
+		return openapi.PostUserListingTemplatesTemplateIDItem400JSONResponse{
+			Message: lo.ToPtr("Invalid auction time"),
+		}, nil
+	}
+	// 建立拍賣物品
+	auction := templateContent(template).newAuctionItem(template.UserID, startTime, request.Body.EndTime, lo.FromPtr(request.Body.Draft), now)
+	err = impl.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&auction).Error; err != nil {
+			return err
+		}
+		return enqueueAuctionEvent(tx, models.OutboxEventAuctionCreated, auction)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] Fail to create auction item, err=%w", op, err)
+	}
+	return openapi.PostUserListingTemplatesTemplateIDItem201Response{
+		Headers: openapi.PostUserListingTemplatesTemplateIDItem201ResponseHeaders{
+			Location: auction.ID.String(),
+		},
+	}, nil
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"q4/api/openapi"
+	"q4/models"
+)
+
+func TestPostUserListingTemplates(t *testing.T) {
+	impl, user, token := setupImportTest(t)
+	ctx := context.Background()
+	other := models.User{Username: "template-test-other"}
+	require.NoError(t, impl.db.Create(&other).Error)
+	t.Cleanup(func() {
+		impl.db.Unscoped().Where("user_id = ?", user.ID).Delete(&models.ListingTemplate{})
+		impl.db.Unscoped().Where("uploader_id = ?", other.ID).Delete(&models.Image{})
+		impl.db.Unscoped().Delete(&other)
+	})
+	owned := "https://images/" + uuid.NewString()
+	foreign := "https://images/" + uuid.NewString()
+	require.NoError(t, impl.db.Create(&models.Image{UploaderID: user.ID, Url: owned}).Error)
+	require.NoError(t, impl.db.Create(&models.Image{UploaderID: other.ID, Url: foreign}).Error)
+
+	save := func(body openapi.ListingTemplateRequest) openapi.PostUserListingTemplatesResponseObject {
+		resp, err := impl.PostUserListingTemplates(ctx, openapi.PostUserListingTemplatesRequestObject{
+			Params: openapi.PostUserListingTemplatesParams{AccessToken: token},
+			Body:   &body,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	tests := []struct {
+		name string
+		body openapi.ListingTemplateRequest
+		want string
+	}{
+		{name: "沒有標題", body: openapi.ListingTemplateRequest{Name: "template", Title: " "}, want: "Title is required"},
+		{name: "起標價為負數", body: openapi.ListingTemplateRequest{Name: "template", Title: "Item", StartingPrice: lo.ToPtr(int64(-1))}, want: "Invalid starting price"},
+		{name: "圖片由其他使用者上傳", body: openapi.ListingTemplateRequest{Name: "template", Title: "Item", Carousels: &[]string{owned, foreign}}, want: "Image is not uploaded by user: " + foreign},
+		{name: "圖片不存在", body: openapi.ListingTemplateRequest{Name: "template", Title: "Item", Carousels: &[]string{"https://images/unknown"}}, want: "Image is not uploaded by user: https://images/unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, openapi.PostUserListingTemplates400JSONResponse{Message: lo.ToPtr(tt.want)}, save(tt.body))
+		})
+	}
+
+	t.Run("使用自己上傳的圖片", func(t *testing.T) {
+		resp := save(openapi.ListingTemplateRequest{Name: "template", Title: "Item", Carousels: &[]string{owned}})
+		require.IsType(t, openapi.PostUserListingTemplates201Response{}, resp)
+		var template models.ListingTemplate
+		require.NoError(t, impl.db.Where("id = ?", resp.(openapi.PostUserListingTemplates201Response).Headers.Location).First(&template).Error)
+		assert.Equal(t, []string{owned}, []string(template.Carousels))
+	})
+}
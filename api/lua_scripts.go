@@ -1,10 +1,15 @@
 package api
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"github.com/samber/lo"
+
+	redisAdapter "q4/adapters/redis"
 )
 
 // BidInfoUser represents a user
@@ -21,41 +26,226 @@ type BidInfo struct {
 	CreatedAt time.Time
 }
 
+// bidInfoSchema 出價資訊寫入 stream 時的 Schema
+// NOTE: 修改 BidInfo 的欄位時需要遞增版本，並以 redisAdapter.WithSchemaUpcaster 將舊版本的出價轉換成新的欄位，
+// 避免 stream 中還沒有同步的出價無法解析
+var bidInfoSchema = lo.Must(redisAdapter.NewSchema[BidInfo]("bid", 1))
+
 // BidScript 用於執行競價腳本
 //
 //	KEYS[1] - 競價商品鍵
 //	KEYS[2] - 競價的 stream
+//	KEYS[3] - (可選)冪等鍵，用於對重送的出價去重
 //	ARGV[1] - 競價金額
-//	ARGV[2] - 競價資訊(結構參考BidInfo，會進行msgpack和base64的處理)
+//	ARGV[2] - 競價資訊的 envelope 中 data 欄位的內容(參考 bidInfoSchema)
 //	ARGV[3] - 過期時間(秒)
 //	ARGV[4] - 預設最高競價金額
+//	ARGV[5] - (可選)冪等鍵的過期時間(秒)，提供 KEYS[3] 或 ARGV[6] 之後的參數時必填，沒有冪等鍵時不會使用
+//	ARGV[6...] - (可選)競價資訊的 envelope 中其他的欄位，以 field, value, ... 的形式展開，沒有提供時寫入的消息視為第1版
 //
-// 返回值:
+// 返回值: {結果, 寫入 stream 的消息ID}，沒有寫入 stream 時ID為空字串
 //
-//	1 - 競價成功
-//	0 - 競價失敗
+//	 1 - 競價成功
+//	 0 - 競價失敗
+//	-1 - 冪等鍵已經被不同金額的出價使用過
 //
 // 流程:
+//   - 0. 如果有提供冪等鍵且已記錄過結果，金額相同時直接返回原本的結果，金額不同時返回-1
 //   - 1. 取得當前最高競價，如果不存在則使用預設值
 //   - 2a. 如果新競價金額不高於當前最高競價，返回0
 //   - 2b. 如果新競價金額高於當前最高競價，更新最高競價金額
 //   - 3. 將出價資訊寫入stream
-//   - 4. 返回1
+//   - 4. 返回1和消息ID
+//
+// 有提供冪等鍵時，會以「結果:金額」的格式記錄結果，讓重送的出價返回相同的結果而不會重複寫入stream
 var BidScript = redis.NewScript(`
+local new_bid = tonumber(ARGV[1])
+
+-- 檢查冪等鍵是否已經記錄過結果
+if KEYS[3] then
+    local previous = redis.call('GET', KEYS[3])
+    if previous then
+        local status, amount = string.match(previous, '^(-?%d+):(%d+)$')
+        if tonumber(amount) ~= new_bid then
+            return {-1, ''}
+        end
+        return {tonumber(status), ''}
+    end
+end
+
+-- 記錄結果到冪等鍵並返回
+local function finish(status, id)
+    if KEYS[3] then
+        redis.call('SET', KEYS[3], status .. ':' .. ARGV[1], 'EX', ARGV[5])
+    end
+    return {status, id}
+end
+
 -- 取得當前最高競價，如果不存在則使用預設值
 local current_bid = tonumber(redis.call('GET', KEYS[1])) or tonumber(ARGV[4])
-local new_bid = tonumber(ARGV[1])
 
 -- 檢查新競價是否高於當前最高價
 if new_bid <= current_bid then
-    return 0
+    return finish(0, '')
 end
 
 -- 更新最高競價
 redis.call('SET', KEYS[1], new_bid, 'EX', ARGV[3])
 
 -- 將競價記錄寫入 stream
-redis.call('XADD', KEYS[2], '*', 'data', ARGV[2])
+local id = redis.call('XADD', KEYS[2], '*', 'data', ARGV[2], unpack(ARGV, 6))
+
+return finish(1, id)
+`)
+
+// runBidScript 執行 BidScript，返回競價的結果和寫入 stream 的消息ID
+func runBidScript(ctx context.Context, client redis.Scripter, keys []string, args ...any) (int, string, error) {
+	result, err := BidScript.Run(ctx, client, keys, args...).Slice()
+	if err != nil {
+		return 0, "", err
+	}
+	if len(result) != 2 {
+		return 0, "", fmt.Errorf("unexpected bid script result %v", result)
+	}
+	status, ok := result[0].(int64)
+	if !ok {
+		return 0, "", fmt.Errorf("unexpected bid script status %v", result[0])
+	}
+	id, ok := result[1].(string)
+	if !ok {
+		return 0, "", fmt.Errorf("unexpected bid script message id %v", result[1])
+	}
+	return int(status), id, nil
+}
+
+// RevertBidScript 用於在出價紀錄被移除後回退最高競價
+//
+//	KEYS[1] - 競價商品鍵
+//	ARGV[1] - 被移除的出價金額
+//	ARGV[2] - 回退後的最高競價金額
+//
+// 返回值:
+//
+//	1 - 回退成功
+//	0 - 目前最高競價不是被移除的出價(已有更高的出價或鍵不存在)，不做任何處理
+//
+// 流程:
+//   - 1. 取得當前最高競價，如果不存在則返回0
+//   - 2a. 如果當前最高競價不等於被移除的出價金額，返回0
+//   - 2b. 如果當前最高競價等於被移除的出價金額，在保留過期時間的情況下更新最高競價金額
+//   - 3. 返回1
+var RevertBidScript = redis.NewScript(`
+-- 取得當前最高競價
+local current_bid = tonumber(redis.call('GET', KEYS[1]))
+if current_bid == nil then
+    return 0
+end
+
+-- 只有當前最高競價就是被移除的出價時才回退，避免覆蓋之後的新出價
+if current_bid ~= tonumber(ARGV[1]) then
+    return 0
+end
+
+redis.call('SET', KEYS[1], ARGV[2], 'KEEPTTL')
 
 return 1
 `)
+
+// ReconcileBidScript 用於校正 Redis 中的最高競價
+//
+//	KEYS[1] - 競價商品鍵
+//	ARGV[1] - 從資料庫和尚未同步的 stream 計算出的最高競價金額
+//	ARGV[2] - 過期時間(秒)
+//
+// 返回值: {狀態, 校正前的最高競價金額}
+//
+//	0 - Redis 的最高競價和預期相同
+//	1 - Redis 沒有最高競價，已寫入預期的金額
+//	2 - Redis 的最高競價低於預期，已更新為預期的金額
+//	3 - Redis 的最高競價高於預期，保留 Redis 的金額
+//
+// 流程:
+//   - 1. 取得當前最高競價
+//   - 2a. 如果不存在或低於預期的金額，寫入預期的金額
+//   - 2b. 如果不低於預期的金額，保留當前最高競價，只更新過期時間
+//   - 3. 返回狀態和校正前的金額
+//
+// 只會調高最高競價，避免校正期間剛寫入的新出價被覆蓋成較低的金額
+var ReconcileBidScript = redis.NewScript(`
+local expected = tonumber(ARGV[1])
+local current = tonumber(redis.call('GET', KEYS[1]))
+
+if current == nil then
+    redis.call('SET', KEYS[1], expected, 'EX', ARGV[2])
+    return {1, 0}
+end
+
+if current < expected then
+    redis.call('SET', KEYS[1], expected, 'EX', ARGV[2])
+    return {2, current}
+end
+
+redis.call('EXPIRE', KEYS[1], ARGV[2])
+if current > expected then
+    return {3, current}
+end
+return {0, current}
+`)
+
+// TrimStreamScript 用於依照保留策略修剪 stream，不會刪除還沒有被 consumer group 確認的訊息
+//
+//	KEYS[1] - stream
+//	ARGV[1] - 可以安全刪除的上限ID，小於這個ID的訊息都已經被所有 consumer group 確認，"+" 表示沒有限制
+//	ARGV[2] - 依照保留時間計算的ID，小於這個ID的訊息已經超過保留時間，"0-0" 表示不限制保留時間
+//	ARGV[3] - 保留的最大筆數，0表示不限制筆數
+//
+// 返回值: 被刪除的訊息數量
+//
+// 流程:
+//   - 1. 以保留時間計算的ID作為修剪的位置
+//   - 2. 如果超過最大筆數，取得保留最新 ARGV[3] 筆訊息需要的位置，和步驟1取較大者
+//     從超出筆數和保留筆數中較少的一端讀取，一次最多讀取 ARGV[3] 筆，積壓大量訊息時不會在 script 中載入所有要刪除的訊息而阻塞 Redis
+//   - 3. 修剪的位置不能超過可以安全刪除的上限
+//   - 4. 以 MINID 修剪 stream 並返回刪除的數量
+var TrimStreamScript = redis.NewScript(`
+local function parse_id(id)
+    local ms, seq = string.match(id, '^(%d+)-(%d+)$')
+    return tonumber(ms), tonumber(seq)
+end
+
+local function less(a, b)
+    local a_ms, a_seq = parse_id(a)
+    local b_ms, b_seq = parse_id(b)
+    return a_ms < b_ms or (a_ms == b_ms and a_seq < b_seq)
+end
+
+local cutoff = ARGV[2]
+
+-- 超過最大筆數時，第 len - max_len + 1 筆(也就是倒數第 max_len 筆)是需要保留的最舊訊息
+local max_len = tonumber(ARGV[3])
+if max_len > 0 then
+    local len = redis.call('XLEN', KEYS[1])
+    if len > max_len then
+        local entries
+        if len - max_len + 1 <= max_len then
+            entries = redis.call('XRANGE', KEYS[1], '-', '+', 'COUNT', len - max_len + 1)
+        else
+            entries = redis.call('XREVRANGE', KEYS[1], '+', '-', 'COUNT', max_len)
+        end
+        local keep = entries[#entries][1]
+        if less(cutoff, keep) then
+            cutoff = keep
+        end
+    end
+end
+
+-- 不能刪除還沒有被確認的訊息
+if ARGV[1] ~= '+' and less(ARGV[1], cutoff) then
+    cutoff = ARGV[1]
+end
+
+if cutoff == '0-0' then
+    return 0
+end
+return redis.call('XTRIM', KEYS[1], 'MINID', cutoff)
+`)
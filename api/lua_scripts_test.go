@@ -3,13 +3,16 @@ package api
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vmihailenco/msgpack/v5"
 
 	redisAdapter "q4/adapters/redis"
@@ -129,14 +132,17 @@ func TestBidScript(t *testing.T) {
 			bidInfo := base64.StdEncoding.EncodeToString(bidInfoBytes)
 
 			// 執行腳本
-			result, err := BidScript.Run(ctx, client,
+			result, id, err := runBidScript(ctx, client,
 				[]string{tt.itemKey, tt.streamKey},
 				tt.bidAmount, bidInfo, tt.expireTime, tt.defaultMaxBid,
-			).Int()
+			)
 
 			// 驗證結果
 			assert.NoError(t, err)
 			assert.Equal(t, tt.want, result)
+			if result != 1 {
+				assert.Empty(t, id)
+			}
 
 			// 如果需要檢查stream
 			if tt.checkStream && result == 1 {
@@ -154,6 +160,7 @@ func TestBidScript(t *testing.T) {
 				streams, err := client.XRange(ctx, tt.streamKey, "-", "+").Result()
 				assert.NoError(t, err)
 				assert.Equal(t, 1, len(streams))
+				assert.Equal(t, streams[0].ID, id)
 
 				// 解析stream中的競價資訊
 				var streamBidInfo BidInfo
@@ -164,3 +171,350 @@ func TestBidScript(t *testing.T) {
 		})
 	}
 }
+
+func TestBidScript_IdempotencyKey(t *testing.T) {
+	// 設置 miniredis
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	// 建立 Redis 客戶端
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+	const (
+		itemKey        = "item:1"
+		streamKey      = "stream:bids"
+		idempotencyKey = "idempotency:bid:1"
+	)
+	placeBid := func(key, amount string) (int, error) {
+		result, _, err := runBidScript(ctx, client,
+			[]string{itemKey, streamKey, key},
+			amount, "bid-info", "3600", "50", "86400",
+		)
+		return result, err
+	}
+
+	tests := []struct {
+		name          string
+		setupFunc     func()
+		bids          []string
+		want          []int
+		wantStreamLen int
+		wantRecord    string
+	}{
+		{
+			name:          "重送成功的出價應返回1且不重複寫入stream",
+			setupFunc:     func() {},
+			bids:          []string{"200", "200"},
+			want:          []int{1, 1},
+			wantStreamLen: 1,
+			wantRecord:    "1:200",
+		},
+		{
+			name: "重送失敗的出價應返回原本的結果",
+			setupFunc: func() {
+				mr.Set(itemKey, "300")
+			},
+			bids:          []string{"200", "200"},
+			want:          []int{0, 0},
+			wantStreamLen: 0,
+			wantRecord:    "0:200",
+		},
+		{
+			name:          "冪等鍵被不同金額使用時應返回-1",
+			setupFunc:     func() {},
+			bids:          []string{"200", "300"},
+			want:          []int{1, -1},
+			wantStreamLen: 1,
+			wantRecord:    "1:200",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 重置 Redis
+			mr.FlushAll()
+
+			// 設置測試資料
+			tt.setupFunc()
+
+			for i, bid := range tt.bids {
+				result, err := placeBid(idempotencyKey, bid)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want[i], result)
+				// 第一次出價後讓最高價變動，確認重送不受影響
+				if i == 0 {
+					mr.Set(itemKey, "1000")
+				}
+			}
+
+			// 檢查stream記錄
+			streams, err := client.XRange(ctx, streamKey, "-", "+").Result()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStreamLen, len(streams))
+
+			// 檢查冪等鍵的記錄和過期時間
+			val, err := client.Get(ctx, idempotencyKey).Result()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRecord, val)
+			assert.Equal(t, 24*time.Hour, mr.TTL(idempotencyKey))
+		})
+	}
+
+	t.Run("不同的冪等鍵應視為不同的出價", func(t *testing.T) {
+		mr.FlushAll()
+
+		result, err := placeBid("idempotency:bid:1", "200")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result)
+		result, err = placeBid("idempotency:bid:2", "300")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result)
+
+		streams, err := client.XRange(ctx, streamKey, "-", "+").Result()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(streams))
+	})
+}
+
+func TestBidScript_Envelope(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	bidInfo := BidInfo{
+		ItemID:    uuid.New(),
+		User:      BidInfoUser{ID: uuid.New(), Name: "test-user"},
+		Amount:    200,
+		CreatedAt: time.Now(),
+	}
+	fields, err := bidInfoSchema.Encode(bidInfo)
+	require.NoError(t, err)
+	data := fields[redisAdapter.EnvelopeDataField]
+	delete(fields, redisAdapter.EnvelopeDataField)
+	args := []any{"200", data, "3600", "100", "86400"}
+	for _, field := range redisAdapter.FlattenFields(fields) {
+		args = append(args, field)
+	}
+
+	result, id, err := runBidScript(ctx, client, []string{"item:1", "stream:bids"}, args...)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result)
+
+	streams, err := client.XRange(ctx, "stream:bids", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, streams, 1)
+	assert.Equal(t, streams[0].ID, id)
+	assert.Equal(t, "bid", streams[0].Values[redisAdapter.EnvelopeTypeField])
+	assert.Equal(t, "1", streams[0].Values[redisAdapter.EnvelopeVersionField])
+	assert.Equal(t, redisAdapter.CodecMsgpack, streams[0].Values[redisAdapter.EnvelopeCodecField])
+	streamBidInfo, err := bidInfoSchema.Decode(streams[0].Values)
+	require.NoError(t, err)
+	compareBidInfo(t, bidInfo, streamBidInfo)
+}
+
+func TestRevertBidScript(t *testing.T) {
+	// 設置 miniredis
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	// 建立 Redis 客戶端
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		setupFunc   func()
+		removedBid  string
+		fallbackBid string
+		want        int
+		wantValue   string
+		wantExists  bool
+	}{
+		{
+			name:        "商品不存在時不做任何處理",
+			setupFunc:   func() {},
+			removedBid:  "200",
+			fallbackBid: "100",
+			want:        0,
+			wantExists:  false,
+		},
+		{
+			name: "已有更高出價時不做任何處理",
+			setupFunc: func() {
+				mr.Set("item:1", "300")
+			},
+			removedBid:  "200",
+			fallbackBid: "100",
+			want:        0,
+			wantValue:   "300",
+			wantExists:  true,
+		},
+		{
+			name: "最高出價被移除時應回退並保留過期時間",
+			setupFunc: func() {
+				mr.Set("item:1", "200")
+				mr.SetTTL("item:1", time.Hour)
+			},
+			removedBid:  "200",
+			fallbackBid: "100",
+			want:        1,
+			wantValue:   "100",
+			wantExists:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 重置 Redis
+			mr.FlushAll()
+
+			// 設置測試資料
+			tt.setupFunc()
+
+			// 執行腳本
+			result, err := RevertBidScript.Run(ctx, client, []string{"item:1"}, tt.removedBid, tt.fallbackBid).Int()
+
+			// 驗證結果
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+			assert.Equal(t, tt.wantExists, mr.Exists("item:1"))
+			if tt.wantExists {
+				val, err := client.Get(ctx, "item:1").Result()
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantValue, val)
+			}
+			if tt.want == 1 {
+				assert.Equal(t, time.Hour, mr.TTL("item:1"))
+			}
+		})
+	}
+}
+
+func TestReconcileBidScript(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		current  string
+		expected int
+		want     []int64
+		wantBid  string
+	}{
+		{name: "鍵不存在時寫入預期的金額", current: "", expected: 300, want: []int64{1, 0}, wantBid: "300"},
+		{name: "Redis 低於預期時調高", current: "200", expected: 300, want: []int64{2, 200}, wantBid: "300"},
+		{name: "Redis 高於預期時保留", current: "400", expected: 300, want: []int64{3, 400}, wantBid: "400"},
+		{name: "Redis 和預期相同", current: "300", expected: 300, want: []int64{0, 300}, wantBid: "300"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr.FlushAll()
+			key := "auction:" + uuid.NewString()
+			if tt.current != "" {
+				mr.Set(key, tt.current)
+			}
+
+			got, err := ReconcileBidScript.Run(ctx, client, []string{key}, tt.expected, 60).Int64Slice()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			bid, err := mr.Get(key)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantBid, bid)
+			assert.Equal(t, 60*time.Second, mr.TTL(key))
+		})
+	}
+}
+
+func TestTrimStreamScript(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+	const stream = "bid-stream"
+	tests := []struct {
+		name    string
+		safeID  string
+		ageID   string
+		maxLen  int
+		want    int64
+		wantIDs []string
+	}{
+		{name: "不限制時不刪除", safeID: "+", ageID: "0-0", maxLen: 0, want: 0, wantIDs: []string{"1-0", "2-0", "3-0", "4-0", "5-0"}},
+		{name: "刪除超過保留時間的訊息", safeID: "+", ageID: "3-0", maxLen: 0, want: 2, wantIDs: []string{"3-0", "4-0", "5-0"}},
+		{name: "刪除超過最大筆數的訊息", safeID: "+", ageID: "0-0", maxLen: 2, want: 3, wantIDs: []string{"4-0", "5-0"}},
+		{name: "超過最大筆數的訊息較少時從最舊的訊息找位置", safeID: "+", ageID: "0-0", maxLen: 4, want: 1, wantIDs: []string{"2-0", "3-0", "4-0", "5-0"}},
+		{name: "保留時間和最大筆數取刪除較多者", safeID: "+", ageID: "2-0", maxLen: 2, want: 3, wantIDs: []string{"4-0", "5-0"}},
+		{name: "不刪除尚未確認的訊息", safeID: "2-0", ageID: "5-0", maxLen: 1, want: 1, wantIDs: []string{"2-0", "3-0", "4-0", "5-0"}},
+		{name: "沒有可以安全刪除的訊息", safeID: "0-0", ageID: "5-0", maxLen: 0, want: 0, wantIDs: []string{"1-0", "2-0", "3-0", "4-0", "5-0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr.FlushAll()
+			for _, id := range []string{"1-0", "2-0", "3-0", "4-0", "5-0"} {
+				require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: stream, ID: id, Values: map[string]any{"data": id}}).Err())
+			}
+
+			got, err := TrimStreamScript.Run(ctx, client, []string{stream}, tt.safeID, tt.ageID, tt.maxLen).Int64()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			messages, err := client.XRange(ctx, stream, "-", "+").Result()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, lo.Map(messages, func(m redis.XMessage, _ int) string { return m.ID }))
+		})
+	}
+
+	t.Run("積壓的訊息遠多於最大筆數", func(t *testing.T) {
+		mr.FlushAll()
+		const total, maxLen = 20000, 10
+		pipe := client.Pipeline()
+		for i := 1; i <= total; i++ {
+			pipe.XAdd(ctx, &redis.XAddArgs{Stream: stream, ID: fmt.Sprintf("%d-0", i), Values: map[string]any{"data": i}})
+		}
+		_, err := pipe.Exec(ctx)
+		require.NoError(t, err)
+
+		got, err := TrimStreamScript.Run(ctx, client, []string{stream}, "+", "0-0", maxLen).Int64()
+		require.NoError(t, err)
+		assert.Equal(t, int64(total-maxLen), got)
+		messages, err := client.XRange(ctx, stream, "-", "+").Result()
+		require.NoError(t, err)
+		require.Len(t, messages, maxLen)
+		assert.Equal(t, fmt.Sprintf("%d-0", total-maxLen+1), messages[0].ID)
+	})
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+
+	"gorm.io/gorm"
+
+	memoryAdapter "q4/adapters/memory"
+	redisAdapter "q4/adapters/redis"
+)
+
+// newMemoryBidBackend 建立單一程序模式下處理出價的元件，以記憶體中的實作取代 Redis
+//   - 出價寫入記憶體中的 stream，同步出價和偵測可疑出價的 consumer group 各自讀取
+//   - 背景工作的鎖只在程序內互斥
+//   - 限流只在程序內計數
+//
+// NOTE: 只適用於單一實例，多個實例之間不會共用最高競價和限流，程序結束時還沒有同步到資料庫的出價會遺失
+func newMemoryBidBackend(config ServerConfig, db *gorm.DB, dsn string) (*bidBackend, error) {
+	var streamOptions []memoryAdapter.StreamOption
+	if config.StreamRetention.MaxLen > 0 {
+		streamOptions = append(streamOptions, memoryAdapter.WithStreamMaxLen(int(config.StreamRetention.MaxLen)))
+	}
+	bidStream, err := memoryAdapter.NewStream[BidInfo](streamOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create bid stream, err=%w", err)
+	}
+
+	// 初始化SSE的出價事件來源
+	source, publisher, err := newBidEventSource(config, nil, db, dsn, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// 初始化 dead-letter queue，用於管理員查看、重送或刪除同步失敗的出價
+	deadLetterQueue, err := memoryAdapter.NewDeadLetterQueue(bidStream, slog.Default())
+	if err != nil {
+		return nil, fmt.Errorf("fail to create dead letter queue, err=%w", err)
+	}
+
+	// 初始化group consumer
+	//  - 以嚴格順序模式依序同步出價，每一批在同一個交易中同步
+	groupConsumer, err := memoryAdapter.NewBatchGroupConsumer(
+		bidStream,
+		config.Redis.ConsumerGroup,
+		memoryAdapter.WithGroupConsumerLogger[BidInfo](slog.Default()),
+		memoryAdapter.WithGroupConsumerStrictOrdering[BidInfo](true),
+		memoryAdapter.WithGroupConsumerBatchSize[BidInfo](config.Redis.SyncBatchSize),
+		memoryAdapter.WithGroupConsumerDeadLetterQueue(deadLetterQueue),
+		memoryAdapter.WithGroupConsumerMaxAttempts[BidInfo](config.Redis.MaxAttempts),
+		memoryAdapter.WithGroupConsumerBackoff[BidInfo](config.Redis.RetryBackoff, config.Redis.RetryMaxBackoff),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create group consumer, err=%w", err)
+	}
+
+	// 初始化可疑出價偵測使用的group consumer
+	var shillConsumer redisAdapter.IGroupConsumer[BidInfo]
+	if config.ShillDetection.Enabled {
+		shillConsumer, err = memoryAdapter.NewGroupConsumer(
+			bidStream,
+			config.ShillDetection.ConsumerGroup,
+			memoryAdapter.WithGroupConsumerLogger[BidInfo](slog.Default()),
+			memoryAdapter.WithGroupConsumerMaxAttempts[BidInfo](config.Redis.MaxAttempts),
+			memoryAdapter.WithGroupConsumerBackoff[BidInfo](config.Redis.RetryBackoff, config.Redis.RetryMaxBackoff),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("fail to create shill detection group consumer, err=%w", err)
+		}
+	}
+
+	// 初始化發送拍賣事件的producer
+	// NOTE: 單一程序模式下沒有其他服務可以讀取拍賣事件，事件只保留在記憶體中
+	outboxProducer, err := memoryAdapter.NewStream[AuctionEvent](streamOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create auction event stream, err=%w", err)
+	}
+
+	// 初始化出價限流器
+	bidLimiters, err := newBidLimiters(config, func(_ string, rule RateLimitRule) (redisAdapter.IRateLimiter, error) {
+		return memoryAdapter.NewRateLimiter(rule.Limit, rule.Window)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &bidBackend{
+		bidStore:         newMemoryBidStore(bidStream, config.Redis),
+		source:           source,
+		publisher:        publisher,
+		groupConsumer:    groupConsumer,
+		shillConsumer:    shillConsumer,
+		bidLimiters:      bidLimiters,
+		deadLetterQueues: []redisAdapter.IDeadLetterQueue[BidInfo]{deadLetterQueue},
+		outboxProducer:   outboxProducer,
+	}, nil
+}
+
+// newMutex 建立背景工作使用的鎖，單一程序模式下使用程序內的鎖
+func (impl *ServerImpl) newMutex(name string) redisAdapter.IAutoRenewMutex {
+	key := impl.config.Redis.KeyPrefix + "lock:" + name
+	if impl.config.InMemory {
+		return memoryAdapter.NewMutex(key)
+	}
+	return redisAdapter.NewAutoRenewMutex(
+		impl.redisClient,
+		key,
+		redisAdapter.WithAutoRenewMutexSkipLockError(true),
+	)
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"expvar"
+	"net/http"
+)
+
+// metrics 服務的運行指標，以 expvar 的 JSON 格式輸出
+// NOTE: 只輸出服務自己的指標，不使用 expvar 預設的 /debug/vars，避免輸出包含密碼的啟動參數
+var metrics = expvar.NewMap("q4")
+
+// newMetricsMap 建立一組指標並掛在 metrics 下
+func newMetricsMap(name string) *expvar.Map {
+	m := new(expvar.Map)
+	metrics.Set(name, m)
+	return m
+}
+
+// MetricsHandler 輸出服務的運行指標
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(metrics.String()))
+	})
+}
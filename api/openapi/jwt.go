@@ -8,7 +8,8 @@ import (
 )
 
 type JWT struct {
-	Username string `json:"username"`
+	Username string   `json:"username"`
+	Role     UserRole `json:"role"`
 	jwt.RegisteredClaims
 }
 
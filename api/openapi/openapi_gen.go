@@ -28,6 +28,22 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for AuctionStatus.
+const (
+	Cancelled AuctionStatus = "cancelled"
+	Draft     AuctionStatus = "draft"
+	Ended     AuctionStatus = "ended"
+	Live      AuctionStatus = "live"
+	Scheduled AuctionStatus = "scheduled"
+	Settled   AuctionStatus = "settled"
+)
+
+// Defines values for FileFormat.
+const (
+	Csv    FileFormat = "csv"
+	Ndjson FileFormat = "ndjson"
+)
+
 // Defines values for SSOProvider.
 const (
 	GitHub    SSOProvider = "GitHub"
@@ -36,6 +52,26 @@ const (
 	Microsoft SSOProvider = "Microsoft"
 )
 
+// Defines values for ShillFindingStatus.
+const (
+	Confirmed ShillFindingStatus = "confirmed"
+	Dismissed ShillFindingStatus = "dismissed"
+	Pending   ShillFindingStatus = "pending"
+)
+
+// Defines values for ShillRule.
+const (
+	AlternatingBidders ShillRule = "alternating_bidders"
+	LinkedAccounts     ShillRule = "linked_accounts"
+	NewAccount         ShillRule = "new_account"
+)
+
+// Defines values for UserRole.
+const (
+	Admin UserRole = "admin"
+	User  UserRole = "user"
+)
+
 // Defines values for GetAuctionItemsParamsSortKey.
 const (
 	CurrentBid GetAuctionItemsParamsSortKey = "currentBid"
@@ -57,6 +93,29 @@ type ApiResponse struct {
 	Message *string `json:"message,omitempty"`
 }
 
+// AuctionItemImportReport defines model for AuctionItemImportReport.
+type AuctionItemImportReport struct {
+	Created int                       `json:"created"`
+	Failed  int                       `json:"failed"`
+	Results []AuctionItemImportResult `json:"results"`
+	Total   int                       `json:"total"`
+}
+
+// AuctionItemImportResult defines model for AuctionItemImportResult.
+type AuctionItemImportResult struct {
+	// Id ID of the created item, only present when the row is imported.
+	Id *openapi_types.UUID `json:"id,omitempty"`
+
+	// Message Reason why the row is rejected, only present when the row fails.
+	Message *string `json:"message,omitempty"`
+
+	// Row Row number in the uploaded file, starting from 1 and excluding the CSV header.
+	Row int `json:"row"`
+}
+
+// AuctionStatus defines model for AuctionStatus.
+type AuctionStatus string
+
 // BidEvent defines model for BidEvent.
 type BidEvent struct {
 	Bid  uint32    `json:"bid"`
@@ -64,6 +123,90 @@ type BidEvent struct {
 	User string    `json:"user"`
 }
 
+// DeadLetterAction defines model for DeadLetterAction.
+type DeadLetterAction struct {
+	Ids       []string `json:"ids"`
+	Partition int      `json:"partition"`
+	Reason    string   `json:"reason"`
+}
+
+// DeadLetterActionResult defines model for DeadLetterActionResult.
+type DeadLetterActionResult struct {
+	// Missing Entry IDs that no longer exist in the dead-letter stream.
+	Missing []string `json:"missing"`
+
+	// Processed Entry IDs that were replayed or discarded.
+	Processed []string `json:"processed"`
+}
+
+// DeadLetterBid The decoded bid information of a dead letter.
+type DeadLetterBid struct {
+	Amount    uint32             `json:"amount"`
+	CreatedAt time.Time          `json:"createdAt"`
+	ItemID    openapi_types.UUID `json:"itemID"`
+	UserID    openapi_types.UUID `json:"userID"`
+	Username  string             `json:"username"`
+}
+
+// DeadLetterEntry defines model for DeadLetterEntry.
+type DeadLetterEntry struct {
+	// Bid The decoded bid information of a dead letter.
+	Bid *DeadLetterBid `json:"bid,omitempty"`
+
+	// Error The reason why the bid failed to synchronize, empty when the entry was moved because it cannot be decoded.
+	Error string `json:"error"`
+
+	// Group The consumer group that failed to process the bid. Replayed bids are delivered to this group only. Absent for entries moved before the group was recorded, which are replayed to every group.
+	Group *string `json:"group,omitempty"`
+
+	// Id The entry ID in the dead-letter stream.
+	Id string `json:"id"`
+
+	// ParseError The reason why the entry cannot be decoded, present only when bid is absent.
+	ParseError *string `json:"parseError,omitempty"`
+	Partition  int     `json:"partition"`
+}
+
+// FileFormat defines model for FileFormat.
+type FileFormat string
+
+// ListingSchedule defines model for ListingSchedule.
+type ListingSchedule struct {
+	// Draft Save the item as a draft, which is only visible to the seller until published.
+	Draft     *bool      `json:"draft,omitempty"`
+	EndTime   time.Time  `json:"endTime"`
+	StartTime *time.Time `json:"startTime,omitempty"`
+}
+
+// ListingTemplate defines model for ListingTemplate.
+type ListingTemplate struct {
+	AutoRelistRounds int64              `json:"autoRelistRounds"`
+	Carousels        []string           `json:"carousels"`
+	CreatedAt        time.Time          `json:"createdAt"`
+	Description      string             `json:"description"`
+	Id               openapi_types.UUID `json:"id"`
+	Name             string             `json:"name"`
+	ReservePrice     int64              `json:"reservePrice"`
+	StartingPrice    int64              `json:"startingPrice"`
+	Title            string             `json:"title"`
+}
+
+// ListingTemplateRequest defines model for ListingTemplateRequest.
+type ListingTemplateRequest struct {
+	AutoRelistRounds *int64    `json:"autoRelistRounds,omitempty"`
+	Carousels        *[]string `json:"carousels,omitempty"`
+	Description      *string   `json:"description,omitempty"`
+	Name             string    `json:"name"`
+	ReservePrice     *int64    `json:"reservePrice,omitempty"`
+	StartingPrice    *int64    `json:"startingPrice,omitempty"`
+	Title            string    `json:"title"`
+}
+
+// ModerationRequest defines model for ModerationRequest.
+type ModerationRequest struct {
+	Reason string `json:"reason"`
+}
+
 // SSOProvider defines model for SSOProvider.
 type SSOProvider string
 
@@ -75,11 +218,143 @@ type SSOProviderConnectStatus struct {
 	Microsoft bool `json:"Microsoft"`
 }
 
+// ShillFinding defines model for ShillFinding.
+type ShillFinding struct {
+	CreatedAt      time.Time            `json:"createdAt"`
+	Detail         string               `json:"detail"`
+	Id             openapi_types.UUID   `json:"id"`
+	ItemID         openapi_types.UUID   `json:"itemID"`
+	RelatedUserIDs []openapi_types.UUID `json:"relatedUserIDs"`
+	ReviewNote     *string              `json:"reviewNote,omitempty"`
+	ReviewedAt     *time.Time           `json:"reviewedAt,omitempty"`
+	ReviewerID     *openapi_types.UUID  `json:"reviewerID,omitempty"`
+	Rule           ShillRule            `json:"rule"`
+	Status         ShillFindingStatus   `json:"status"`
+	UserID         openapi_types.UUID   `json:"userID"`
+	Username       string               `json:"username"`
+}
+
+// ShillFindingResolution defines model for ShillFindingResolution.
+type ShillFindingResolution struct {
+	Reason string             `json:"reason"`
+	Status ShillFindingStatus `json:"status"`
+}
+
+// ShillFindingStatus defines model for ShillFindingStatus.
+type ShillFindingStatus string
+
+// ShillRule defines model for ShillRule.
+type ShillRule string
+
+// UserRole defines model for UserRole.
+type UserRole string
+
+// PostAdminAuctionBidBidIDRemoveParams defines parameters for PostAdminAuctionBidBidIDRemove.
+type PostAdminAuctionBidBidIDRemoveParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostAdminAuctionItemItemIDCancelParams defines parameters for PostAdminAuctionItemItemIDCancel.
+type PostAdminAuctionItemItemIDCancelParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// GetAdminDeadLettersParams defines parameters for GetAdminDeadLetters.
+type GetAdminDeadLettersParams struct {
+	// Partition The bid stream partition.
+	Partition *int `form:"partition,omitempty" json:"partition,omitempty"`
+
+	// LastEntryID The last entry ID of the previous page.
+	LastEntryID *string `form:"lastEntryID,omitempty" json:"lastEntryID,omitempty"`
+
+	// Size The maximum number of entries to return.
+	Size *uint32 `form:"size,omitempty" json:"size,omitempty"`
+
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostAdminDeadLettersDiscardParams defines parameters for PostAdminDeadLettersDiscard.
+type PostAdminDeadLettersDiscardParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostAdminDeadLettersReplayParams defines parameters for PostAdminDeadLettersReplay.
+type PostAdminDeadLettersReplayParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// GetAdminShillFindingsParams defines parameters for GetAdminShillFindings.
+type GetAdminShillFindingsParams struct {
+	// Status Filter findings by review status.
+	Status *ShillFindingStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// LastFindingID The last finding ID of the previous page.
+	LastFindingID *openapi_types.UUID `form:"lastFindingID,omitempty" json:"lastFindingID,omitempty"`
+
+	// Size The maximum number of findings to return.
+	Size *uint32 `form:"size,omitempty" json:"size,omitempty"`
+
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostAdminShillFindingsFindingIDResolveParams defines parameters for PostAdminShillFindingsFindingIDResolve.
+type PostAdminShillFindingsFindingIDResolveParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostAdminUserUserIDSuspendParams defines parameters for PostAdminUserUserIDSuspend.
+type PostAdminUserUserIDSuspendParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostAdminUserUserIDUnsuspendParams defines parameters for PostAdminUserUserIDUnsuspend.
+type PostAdminUserUserIDUnsuspendParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// GetAuctionBidsExportParams defines parameters for GetAuctionBidsExport.
+type GetAuctionBidsExportParams struct {
+	// Format Format of the exported file.
+	Format FileFormat `form:"format" json:"format"`
+
+	// From Only export records on or after this time.
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only export records before this time.
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// ItemID Only export records of the auction item.
+	ItemID *openapi_types.UUID `form:"itemID,omitempty" json:"itemID,omitempty"`
+
+	// SellerID Only export records of the seller, non-admin users can only specify themselves.
+	SellerID *openapi_types.UUID `form:"sellerID,omitempty" json:"sellerID,omitempty"`
+
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
 // PostAuctionItemJSONBody defines parameters for PostAuctionItem.
 type PostAuctionItemJSONBody struct {
-	Carousels     *[]string  `json:"carousels,omitempty"`
-	Description   *string    `json:"description,omitempty"`
-	EndTime       time.Time  `json:"endTime"`
+	// AutoRelistRounds Number of times the item is relisted automatically when it ends unsold.
+	AutoRelistRounds *int64    `json:"autoRelistRounds,omitempty"`
+	Carousels        *[]string `json:"carousels,omitempty"`
+	Description      *string   `json:"description,omitempty"`
+
+	// Draft Save the item as a draft, which is only visible to the seller until published.
+	Draft   *bool     `json:"draft,omitempty"`
+	EndTime time.Time `json:"endTime"`
+
+	// ReservePrice Minimum final bid for the item to be sold, 0 means no reserve.
+	ReservePrice  *int64     `json:"reservePrice,omitempty"`
 	StartTime     *time.Time `json:"startTime,omitempty"`
 	StartingPrice *int64     `json:"startingPrice,omitempty"`
 	Title         string     `json:"title"`
@@ -91,6 +366,33 @@ type PostAuctionItemParams struct {
 	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
 }
 
+// GetAuctionItemItemIDParams defines parameters for GetAuctionItemItemID.
+type GetAuctionItemItemIDParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PatchAuctionItemItemIDJSONBody defines parameters for PatchAuctionItemItemID.
+type PatchAuctionItemItemIDJSONBody struct {
+	// AutoRelistRounds Number of times the item is relisted automatically when it ends unsold.
+	AutoRelistRounds *int64     `json:"autoRelistRounds,omitempty"`
+	Carousels        *[]string  `json:"carousels,omitempty"`
+	Description      *string    `json:"description,omitempty"`
+	EndTime          *time.Time `json:"endTime,omitempty"`
+
+	// ReservePrice Minimum final bid for the item to be sold, 0 means no reserve.
+	ReservePrice  *int64     `json:"reservePrice,omitempty"`
+	StartTime     *time.Time `json:"startTime,omitempty"`
+	StartingPrice *int64     `json:"startingPrice,omitempty"`
+	Title         *string    `json:"title,omitempty"`
+}
+
+// PatchAuctionItemItemIDParams defines parameters for PatchAuctionItemItemID.
+type PatchAuctionItemItemIDParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
 // PostAuctionItemItemIDBidsJSONBody defines parameters for PostAuctionItemItemIDBids.
 type PostAuctionItemItemIDBidsJSONBody struct {
 	Bid uint32 `json:"bid"`
@@ -98,6 +400,27 @@ type PostAuctionItemItemIDBidsJSONBody struct {
 
 // PostAuctionItemItemIDBidsParams defines parameters for PostAuctionItemItemIDBids.
 type PostAuctionItemItemIDBidsParams struct {
+	// IdempotencyKey Client generated key for deduplicating retried bids, a replay returns the original outcome.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// GetAuctionItemItemIDEventsParams defines parameters for GetAuctionItemItemIDEvents.
+type GetAuctionItemItemIDEventsParams struct {
+	// LastEventID ID of the last received event, the bids placed after it are replayed before live delivery.
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// PostAuctionItemItemIDPublishParams defines parameters for PostAuctionItemItemIDPublish.
+type PostAuctionItemItemIDPublishParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostAuctionItemItemIDRelistParams defines parameters for PostAuctionItemItemIDRelist.
+type PostAuctionItemItemIDRelistParams struct {
 	// AccessToken access token for current user.
 	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
 }
@@ -153,6 +476,39 @@ type GetAuctionItemsParamsSortKey string
 // GetAuctionItemsParamsSortOrder defines parameters for GetAuctionItems.
 type GetAuctionItemsParamsSortOrder string
 
+// GetAuctionItemsExportParams defines parameters for GetAuctionItemsExport.
+type GetAuctionItemsExportParams struct {
+	// Format Format of the exported file.
+	Format FileFormat `form:"format" json:"format"`
+
+	// From Only export records on or after this time.
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only export records before this time.
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// ItemID Only export records of the auction item.
+	ItemID *openapi_types.UUID `form:"itemID,omitempty" json:"itemID,omitempty"`
+
+	// SellerID Only export records of the seller, non-admin users can only specify themselves.
+	SellerID *openapi_types.UUID `form:"sellerID,omitempty" json:"sellerID,omitempty"`
+
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostAuctionItemsImportParams defines parameters for PostAuctionItemsImport.
+type PostAuctionItemsImportParams struct {
+	// Format Format of the uploaded file.
+	Format FileFormat `form:"format" json:"format"`
+
+	// Atomic Create all rows in one transaction, or skip invalid rows.
+	Atomic *bool `form:"atomic,omitempty" json:"atomic,omitempty"`
+
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
 // GetAuthLogoutParams defines parameters for GetAuthLogout.
 type GetAuthLogoutParams struct {
 	// AccessToken access token for current user.
@@ -219,6 +575,21 @@ type PostImageParams struct {
 	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
 }
 
+// GetUserAuctionItemsParams defines parameters for GetUserAuctionItems.
+type GetUserAuctionItemsParams struct {
+	// Status Filter items by lifecycle status.
+	Status *AuctionStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// LastItemID The last item ID of the previous page.
+	LastItemID *openapi_types.UUID `form:"lastItemID,omitempty" json:"lastItemID,omitempty"`
+
+	// Size The maximum number of items to return.
+	Size *uint32 `form:"size,omitempty" json:"size,omitempty"`
+
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
 // GetUserInfoParams defines parameters for GetUserInfo.
 type GetUserInfoParams struct {
 	// AccessToken access token for current user.
@@ -236,12 +607,63 @@ type PatchUserInfoParams struct {
 	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
 }
 
+// GetUserListingTemplatesParams defines parameters for GetUserListingTemplates.
+type GetUserListingTemplatesParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostUserListingTemplatesParams defines parameters for PostUserListingTemplates.
+type PostUserListingTemplatesParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// DeleteUserListingTemplatesTemplateIDParams defines parameters for DeleteUserListingTemplatesTemplateID.
+type DeleteUserListingTemplatesTemplateIDParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostUserListingTemplatesTemplateIDItemParams defines parameters for PostUserListingTemplatesTemplateIDItem.
+type PostUserListingTemplatesTemplateIDItemParams struct {
+	// AccessToken access token for current user.
+	AccessToken *string `form:"accessToken,omitempty" json:"accessToken,omitempty"`
+}
+
+// PostAdminAuctionBidBidIDRemoveJSONRequestBody defines body for PostAdminAuctionBidBidIDRemove for application/json ContentType.
+type PostAdminAuctionBidBidIDRemoveJSONRequestBody = ModerationRequest
+
+// PostAdminAuctionItemItemIDCancelJSONRequestBody defines body for PostAdminAuctionItemItemIDCancel for application/json ContentType.
+type PostAdminAuctionItemItemIDCancelJSONRequestBody = ModerationRequest
+
+// PostAdminDeadLettersDiscardJSONRequestBody defines body for PostAdminDeadLettersDiscard for application/json ContentType.
+type PostAdminDeadLettersDiscardJSONRequestBody = DeadLetterAction
+
+// PostAdminDeadLettersReplayJSONRequestBody defines body for PostAdminDeadLettersReplay for application/json ContentType.
+type PostAdminDeadLettersReplayJSONRequestBody = DeadLetterAction
+
+// PostAdminShillFindingsFindingIDResolveJSONRequestBody defines body for PostAdminShillFindingsFindingIDResolve for application/json ContentType.
+type PostAdminShillFindingsFindingIDResolveJSONRequestBody = ShillFindingResolution
+
+// PostAdminUserUserIDSuspendJSONRequestBody defines body for PostAdminUserUserIDSuspend for application/json ContentType.
+type PostAdminUserUserIDSuspendJSONRequestBody = ModerationRequest
+
+// PostAdminUserUserIDUnsuspendJSONRequestBody defines body for PostAdminUserUserIDUnsuspend for application/json ContentType.
+type PostAdminUserUserIDUnsuspendJSONRequestBody = ModerationRequest
+
 // PostAuctionItemJSONRequestBody defines body for PostAuctionItem for application/json ContentType.
 type PostAuctionItemJSONRequestBody PostAuctionItemJSONBody
 
+// PatchAuctionItemItemIDJSONRequestBody defines body for PatchAuctionItemItemID for application/json ContentType.
+type PatchAuctionItemItemIDJSONRequestBody PatchAuctionItemItemIDJSONBody
+
 // PostAuctionItemItemIDBidsJSONRequestBody defines body for PostAuctionItemItemIDBids for application/json ContentType.
 type PostAuctionItemItemIDBidsJSONRequestBody PostAuctionItemItemIDBidsJSONBody
 
+// PostAuctionItemItemIDRelistJSONRequestBody defines body for PostAuctionItemItemIDRelist for application/json ContentType.
+type PostAuctionItemItemIDRelistJSONRequestBody = ListingSchedule
+
 // PostAuthSsoProviderCallbackJSONRequestBody defines body for PostAuthSsoProviderCallback for application/json ContentType.
 type PostAuthSsoProviderCallbackJSONRequestBody PostAuthSsoProviderCallbackJSONBody
 
@@ -251,23 +673,74 @@ type PostAuthSsoProviderLinkJSONRequestBody PostAuthSsoProviderLinkJSONBody
 // PatchUserInfoJSONRequestBody defines body for PatchUserInfo for application/json ContentType.
 type PatchUserInfoJSONRequestBody PatchUserInfoJSONBody
 
+// PostUserListingTemplatesJSONRequestBody defines body for PostUserListingTemplates for application/json ContentType.
+type PostUserListingTemplatesJSONRequestBody = ListingTemplateRequest
+
+// PostUserListingTemplatesTemplateIDItemJSONRequestBody defines body for PostUserListingTemplatesTemplateIDItem for application/json ContentType.
+type PostUserListingTemplatesTemplateIDItemJSONRequestBody = ListingSchedule
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Remove a bid
+	// (POST /admin/auction/bid/{bidID}/remove)
+	PostAdminAuctionBidBidIDRemove(c *gin.Context, bidID openapi_types.UUID, params PostAdminAuctionBidBidIDRemoveParams)
+	// Force-cancel an auction
+	// (POST /admin/auction/item/{itemID}/cancel)
+	PostAdminAuctionItemItemIDCancel(c *gin.Context, itemID openapi_types.UUID, params PostAdminAuctionItemItemIDCancelParams)
+	// List dead letters
+	// (GET /admin/dead-letters)
+	GetAdminDeadLetters(c *gin.Context, params GetAdminDeadLettersParams)
+	// Discard dead letters
+	// (POST /admin/dead-letters/discard)
+	PostAdminDeadLettersDiscard(c *gin.Context, params PostAdminDeadLettersDiscardParams)
+	// Replay dead letters
+	// (POST /admin/dead-letters/replay)
+	PostAdminDeadLettersReplay(c *gin.Context, params PostAdminDeadLettersReplayParams)
+	// List shill bidding findings
+	// (GET /admin/shill/findings)
+	GetAdminShillFindings(c *gin.Context, params GetAdminShillFindingsParams)
+	// Resolve a shill bidding finding
+	// (POST /admin/shill/findings/{findingID}/resolve)
+	PostAdminShillFindingsFindingIDResolve(c *gin.Context, findingID openapi_types.UUID, params PostAdminShillFindingsFindingIDResolveParams)
+	// Suspend a user
+	// (POST /admin/user/{userID}/suspend)
+	PostAdminUserUserIDSuspend(c *gin.Context, userID openapi_types.UUID, params PostAdminUserUserIDSuspendParams)
+	// Unsuspend a user
+	// (POST /admin/user/{userID}/unsuspend)
+	PostAdminUserUserIDUnsuspend(c *gin.Context, userID openapi_types.UUID, params PostAdminUserUserIDUnsuspendParams)
+	// Export bid history
+	// (GET /auction/bids/export)
+	GetAuctionBidsExport(c *gin.Context, params GetAuctionBidsExportParams)
 	// Add a new auction item
 	// (POST /auction/item)
 	PostAuctionItem(c *gin.Context, params PostAuctionItemParams)
 	// Get auction item details
 	// (GET /auction/item/{itemID})
-	GetAuctionItemItemID(c *gin.Context, itemID openapi_types.UUID)
+	GetAuctionItemItemID(c *gin.Context, itemID openapi_types.UUID, params GetAuctionItemItemIDParams)
+	// Update an auction item
+	// (PATCH /auction/item/{itemID})
+	PatchAuctionItemItemID(c *gin.Context, itemID openapi_types.UUID, params PatchAuctionItemItemIDParams)
 	// Place a bid on an auction item
 	// (POST /auction/item/{itemID}/bids)
 	PostAuctionItemItemIDBids(c *gin.Context, itemID openapi_types.UUID, params PostAuctionItemItemIDBidsParams)
 	// Track auction item events
 	// (GET /auction/item/{itemID}/events)
-	GetAuctionItemItemIDEvents(c *gin.Context, itemID openapi_types.UUID)
+	GetAuctionItemItemIDEvents(c *gin.Context, itemID openapi_types.UUID, params GetAuctionItemItemIDEventsParams)
+	// Publish a draft auction item
+	// (POST /auction/item/{itemID}/publish)
+	PostAuctionItemItemIDPublish(c *gin.Context, itemID openapi_types.UUID, params PostAuctionItemItemIDPublishParams)
+	// Relist an unsold auction item
+	// (POST /auction/item/{itemID}/relist)
+	PostAuctionItemItemIDRelist(c *gin.Context, itemID openapi_types.UUID, params PostAuctionItemItemIDRelistParams)
 	// List auction items
 	// (GET /auction/items)
 	GetAuctionItems(c *gin.Context, params GetAuctionItemsParams)
+	// Export auction items
+	// (GET /auction/items/export)
+	GetAuctionItemsExport(c *gin.Context, params GetAuctionItemsExportParams)
+	// Import auction items in bulk
+	// (POST /auction/items/import)
+	PostAuctionItemsImport(c *gin.Context, params PostAuctionItemsImportParams)
 	// Revoke authentication token
 	// (GET /auth/logout)
 	GetAuthLogout(c *gin.Context, params GetAuthLogoutParams)
@@ -286,12 +759,27 @@ type ServerInterface interface {
 	// Upload an image
 	// (POST /image)
 	PostImage(c *gin.Context, params PostImageParams)
+	// List auction items of current user
+	// (GET /user/auction/items)
+	GetUserAuctionItems(c *gin.Context, params GetUserAuctionItemsParams)
 	// Get user information
 	// (GET /user/info)
 	GetUserInfo(c *gin.Context, params GetUserInfoParams)
 	// Update user information
 	// (PATCH /user/info)
 	PatchUserInfo(c *gin.Context, params PatchUserInfoParams)
+	// List listing templates
+	// (GET /user/listing/templates)
+	GetUserListingTemplates(c *gin.Context, params GetUserListingTemplatesParams)
+	// Save a listing template
+	// (POST /user/listing/templates)
+	PostUserListingTemplates(c *gin.Context, params PostUserListingTemplatesParams)
+	// Delete a listing template
+	// (DELETE /user/listing/templates/{templateID})
+	DeleteUserListingTemplatesTemplateID(c *gin.Context, templateID openapi_types.UUID, params DeleteUserListingTemplatesTemplateIDParams)
+	// Create an auction item from a template
+	// (POST /user/listing/templates/{templateID}/item)
+	PostUserListingTemplatesTemplateIDItem(c *gin.Context, templateID openapi_types.UUID, params PostUserListingTemplatesTemplateIDItemParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -303,13 +791,22 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
-// PostAuctionItem operation middleware
-func (siw *ServerInterfaceWrapper) PostAuctionItem(c *gin.Context) {
+// PostAdminAuctionBidBidIDRemove operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminAuctionBidBidIDRemove(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "bidID" -------------
+	var bidID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "bidID", c.Param("bidID"), &bidID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter bidID: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostAuctionItemParams
+	var params PostAdminAuctionBidBidIDRemoveParams
 
 	{
 		var cookie string
@@ -333,11 +830,11 @@ func (siw *ServerInterfaceWrapper) PostAuctionItem(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostAuctionItem(c, params)
+	siw.Handler.PostAdminAuctionBidBidIDRemove(c, bidID, params)
 }
 
-// GetAuctionItemItemID operation middleware
-func (siw *ServerInterfaceWrapper) GetAuctionItemItemID(c *gin.Context) {
+// PostAdminAuctionItemItemIDCancel operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminAuctionItemItemIDCancel(c *gin.Context) {
 
 	var err error
 
@@ -350,6 +847,24 @@ func (siw *ServerInterfaceWrapper) GetAuctionItemItemID(c *gin.Context) {
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAdminAuctionItemItemIDCancelParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -357,27 +872,40 @@ func (siw *ServerInterfaceWrapper) GetAuctionItemItemID(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetAuctionItemItemID(c, itemID)
+	siw.Handler.PostAdminAuctionItemItemIDCancel(c, itemID, params)
 }
 
-// PostAuctionItemItemIDBids operation middleware
-func (siw *ServerInterfaceWrapper) PostAuctionItemItemIDBids(c *gin.Context) {
+// GetAdminDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminDeadLetters(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "itemID" -------------
-	var itemID openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminDeadLettersParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "itemID", c.Param("itemID"), &itemID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	// ------------- Optional query parameter "partition" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "partition", c.Request.URL.Query(), &params.Partition)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemID: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter partition: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	c.Set(BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "lastEntryID" -------------
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PostAuctionItemItemIDBidsParams
+	err = runtime.BindQueryParameter("form", true, false, "lastEntryID", c.Request.URL.Query(), &params.LastEntryID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter lastEntryID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter size: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	{
 		var cookie string
@@ -401,94 +929,96 @@ func (siw *ServerInterfaceWrapper) PostAuctionItemItemIDBids(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostAuctionItemItemIDBids(c, itemID, params)
+	siw.Handler.GetAdminDeadLetters(c, params)
 }
 
-// GetAuctionItemItemIDEvents operation middleware
-func (siw *ServerInterfaceWrapper) GetAuctionItemItemIDEvents(c *gin.Context) {
+// PostAdminDeadLettersDiscard operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminDeadLettersDiscard(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "itemID" -------------
-	var itemID openapi_types.UUID
-
-	err = runtime.BindStyledParameterWithOptions("simple", "itemID", c.Param("itemID"), &itemID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemID: %w", err), http.StatusBadRequest)
-		return
-	}
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAdminDeadLettersDiscardParams
 
-	siw.Handler.GetAuctionItemItemIDEvents(c, itemID)
-}
+	{
+		var cookie string
 
-// GetAuctionItems operation middleware
-func (siw *ServerInterfaceWrapper) GetAuctionItems(c *gin.Context) {
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAdminDeadLettersDiscard(c, params)
+}
+
+// PostAdminDeadLettersReplay operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminDeadLettersReplay(c *gin.Context) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAuctionItemsParams
+	var params PostAdminDeadLettersReplayParams
 
-	// ------------- Optional query parameter "title" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "title", c.Request.URL.Query(), &params.Title)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter title: %w", err), http.StatusBadRequest)
-		return
-	}
+	{
+		var cookie string
 
-	// ------------- Optional query parameter "startPrice" -------------
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
 
-	err = runtime.BindQueryParameter("deepObject", true, false, "startPrice", c.Request.URL.Query(), &params.StartPrice)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startPrice: %w", err), http.StatusBadRequest)
-		return
+		}
 	}
 
-	// ------------- Optional query parameter "currentBid" -------------
-
-	err = runtime.BindQueryParameter("deepObject", true, false, "currentBid", c.Request.URL.Query(), &params.CurrentBid)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter currentBid: %w", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	// ------------- Optional query parameter "startTime" -------------
+	siw.Handler.PostAdminDeadLettersReplay(c, params)
+}
 
-	err = runtime.BindQueryParameter("deepObject", true, false, "startTime", c.Request.URL.Query(), &params.StartTime)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startTime: %w", err), http.StatusBadRequest)
-		return
-	}
+// GetAdminShillFindings operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminShillFindings(c *gin.Context) {
 
-	// ------------- Optional query parameter "endTime" -------------
+	var err error
 
-	err = runtime.BindQueryParameter("deepObject", true, false, "endTime", c.Request.URL.Query(), &params.EndTime)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter endTime: %w", err), http.StatusBadRequest)
-		return
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminShillFindingsParams
 
-	// ------------- Optional query parameter "sort" -------------
+	// ------------- Optional query parameter "status" -------------
 
-	err = runtime.BindQueryParameter("deepObject", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "lastItemID" -------------
+	// ------------- Optional query parameter "lastFindingID" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "lastItemID", c.Request.URL.Query(), &params.LastItemID)
+	err = runtime.BindQueryParameter("form", true, false, "lastFindingID", c.Request.URL.Query(), &params.LastFindingID)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter lastItemID: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter lastFindingID: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -500,12 +1030,19 @@ func (siw *ServerInterfaceWrapper) GetAuctionItems(c *gin.Context) {
 		return
 	}
 
-	// ------------- Optional query parameter "excludeEnded" -------------
+	{
+		var cookie string
 
-	err = runtime.BindQueryParameter("form", true, false, "excludeEnded", c.Request.URL.Query(), &params.ExcludeEnded)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter excludeEnded: %w", err), http.StatusBadRequest)
-		return
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -515,16 +1052,25 @@ func (siw *ServerInterfaceWrapper) GetAuctionItems(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetAuctionItems(c, params)
+	siw.Handler.GetAdminShillFindings(c, params)
 }
 
-// GetAuthLogout operation middleware
-func (siw *ServerInterfaceWrapper) GetAuthLogout(c *gin.Context) {
+// PostAdminShillFindingsFindingIDResolve operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminShillFindingsFindingIDResolve(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "findingID" -------------
+	var findingID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "findingID", c.Param("findingID"), &findingID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter findingID: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAuthLogoutParams
+	var params PostAdminShillFindingsFindingIDResolveParams
 
 	{
 		var cookie string
@@ -541,21 +1087,6 @@ func (siw *ServerInterfaceWrapper) GetAuthLogout(c *gin.Context) {
 		}
 	}
 
-	{
-		var cookie string
-
-		if cookie, err = c.Cookie("username"); err == nil {
-			var value string
-			err = runtime.BindStyledParameterWithOptions("simple", "username", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
-			if err != nil {
-				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter username: %w", err), http.StatusBadRequest)
-				return
-			}
-			params.Username = &value
-
-		}
-	}
-
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -563,67 +1094,37 @@ func (siw *ServerInterfaceWrapper) GetAuthLogout(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetAuthLogout(c, params)
+	siw.Handler.PostAdminShillFindingsFindingIDResolve(c, findingID, params)
 }
 
-// PostAuthSsoProviderCallback operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthSsoProviderCallback(c *gin.Context) {
+// PostAdminUserUserIDSuspend operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUserUserIDSuspend(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "provider" -------------
-	var provider SSOProvider
+	// ------------- Path parameter "userID" -------------
+	var userID openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", c.Param("userID"), &userID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userID: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostAuthSsoProviderCallbackParams
+	var params PostAdminUserUserIDSuspendParams
 
 	{
 		var cookie string
 
-		if cookie, err = c.Cookie("requestState"); err == nil {
-			var value string
-			err = runtime.BindStyledParameterWithOptions("simple", "requestState", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
-			if err != nil {
-				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestState: %w", err), http.StatusBadRequest)
-				return
-			}
-			params.RequestState = &value
-
-		}
-	}
-
-	{
-		var cookie string
-
-		if cookie, err = c.Cookie("requestNonce"); err == nil {
-			var value string
-			err = runtime.BindStyledParameterWithOptions("simple", "requestNonce", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
-			if err != nil {
-				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestNonce: %w", err), http.StatusBadRequest)
-				return
-			}
-			params.RequestNonce = &value
-
-		}
-	}
-
-	{
-		var cookie string
-
-		if cookie, err = c.Cookie("requestRedirectUrl"); err == nil {
+		if cookie, err = c.Cookie("accessToken"); err == nil {
 			var value string
-			err = runtime.BindStyledParameterWithOptions("simple", "requestRedirectUrl", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
 			if err != nil {
-				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestRedirectUrl: %w", err), http.StatusBadRequest)
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
 				return
 			}
-			params.RequestRedirectUrl = &value
+			params.AccessToken = &value
 
 		}
 	}
@@ -635,25 +1136,25 @@ func (siw *ServerInterfaceWrapper) PostAuthSsoProviderCallback(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostAuthSsoProviderCallback(c, provider, params)
+	siw.Handler.PostAdminUserUserIDSuspend(c, userID, params)
 }
 
-// DeleteAuthSsoProviderLink operation middleware
-func (siw *ServerInterfaceWrapper) DeleteAuthSsoProviderLink(c *gin.Context) {
+// PostAdminUserUserIDUnsuspend operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUserUserIDUnsuspend(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "provider" -------------
-	var provider SSOProvider
+	// ------------- Path parameter "userID" -------------
+	var userID openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", c.Param("userID"), &userID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userID: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params DeleteAuthSsoProviderLinkParams
+	var params PostAdminUserUserIDUnsuspendParams
 
 	{
 		var cookie string
@@ -677,71 +1178,97 @@ func (siw *ServerInterfaceWrapper) DeleteAuthSsoProviderLink(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteAuthSsoProviderLink(c, provider, params)
+	siw.Handler.PostAdminUserUserIDUnsuspend(c, userID, params)
 }
 
-// PostAuthSsoProviderLink operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthSsoProviderLink(c *gin.Context) {
+// GetAuctionBidsExport operation middleware
+func (siw *ServerInterfaceWrapper) GetAuctionBidsExport(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "provider" -------------
-	var provider SSOProvider
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuctionBidsExportParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	// ------------- Required query parameter "format" -------------
+
+	if paramValue := c.Query("format"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument format is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "format", c.Request.URL.Query(), &params.Format)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PostAuthSsoProviderLinkParams
+	// ------------- Optional query parameter "from" -------------
 
-	{
-		var cookie string
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
 
-		if cookie, err = c.Cookie("requestState"); err == nil {
-			var value string
-			err = runtime.BindStyledParameterWithOptions("simple", "requestState", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
-			if err != nil {
-				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestState: %w", err), http.StatusBadRequest)
-				return
-			}
-			params.RequestState = &value
+	// ------------- Optional query parameter "to" -------------
 
-		}
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	{
-		var cookie string
+	// ------------- Optional query parameter "itemID" -------------
 
-		if cookie, err = c.Cookie("requestNonce"); err == nil {
-			var value string
-			err = runtime.BindStyledParameterWithOptions("simple", "requestNonce", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
-			if err != nil {
-				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestNonce: %w", err), http.StatusBadRequest)
-				return
-			}
-			params.RequestNonce = &value
+	err = runtime.BindQueryParameter("form", true, false, "itemID", c.Request.URL.Query(), &params.ItemID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-		}
+	// ------------- Optional query parameter "sellerID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sellerID", c.Request.URL.Query(), &params.SellerID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sellerID: %w", err), http.StatusBadRequest)
+		return
 	}
 
 	{
 		var cookie string
 
-		if cookie, err = c.Cookie("requestRedirectUrl"); err == nil {
+		if cookie, err = c.Cookie("accessToken"); err == nil {
 			var value string
-			err = runtime.BindStyledParameterWithOptions("simple", "requestRedirectUrl", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
 			if err != nil {
-				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestRedirectUrl: %w", err), http.StatusBadRequest)
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
 				return
 			}
-			params.RequestRedirectUrl = &value
+			params.AccessToken = &value
 
 		}
 	}
 
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAuctionBidsExport(c, params)
+}
+
+// PostAuctionItem operation middleware
+func (siw *ServerInterfaceWrapper) PostAuctionItem(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAuctionItemParams
+
 	{
 		var cookie string
 
@@ -764,39 +1291,39 @@ func (siw *ServerInterfaceWrapper) PostAuthSsoProviderLink(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostAuthSsoProviderLink(c, provider, params)
+	siw.Handler.PostAuctionItem(c, params)
 }
 
-// GetAuthSsoProviderLogin operation middleware
-func (siw *ServerInterfaceWrapper) GetAuthSsoProviderLogin(c *gin.Context) {
+// GetAuctionItemItemID operation middleware
+func (siw *ServerInterfaceWrapper) GetAuctionItemItemID(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "provider" -------------
-	var provider SSOProvider
+	// ------------- Path parameter "itemID" -------------
+	var itemID openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "itemID", c.Param("itemID"), &itemID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemID: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAuthSsoProviderLoginParams
+	var params GetAuctionItemItemIDParams
 
-	// ------------- Required query parameter "redirectUrl" -------------
-
-	if paramValue := c.Query("redirectUrl"); paramValue != "" {
+	{
+		var cookie string
 
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument redirectUrl is required, but not found"), http.StatusBadRequest)
-		return
-	}
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
 
-	err = runtime.BindQueryParameter("form", true, true, "redirectUrl", c.Request.URL.Query(), &params.RedirectUrl)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter redirectUrl: %w", err), http.StatusBadRequest)
-		return
+		}
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -806,16 +1333,25 @@ func (siw *ServerInterfaceWrapper) GetAuthSsoProviderLogin(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetAuthSsoProviderLogin(c, provider, params)
+	siw.Handler.GetAuctionItemItemID(c, itemID, params)
 }
 
-// PostImage operation middleware
-func (siw *ServerInterfaceWrapper) PostImage(c *gin.Context) {
+// PatchAuctionItemItemID operation middleware
+func (siw *ServerInterfaceWrapper) PatchAuctionItemItemID(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "itemID" -------------
+	var itemID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "itemID", c.Param("itemID"), &itemID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemID: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostImageParams
+	var params PatchAuctionItemItemIDParams
 
 	{
 		var cookie string
@@ -839,16 +1375,48 @@ func (siw *ServerInterfaceWrapper) PostImage(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostImage(c, params)
+	siw.Handler.PatchAuctionItemItemID(c, itemID, params)
 }
 
-// GetUserInfo operation middleware
-func (siw *ServerInterfaceWrapper) GetUserInfo(c *gin.Context) {
+// PostAuctionItemItemIDBids operation middleware
+func (siw *ServerInterfaceWrapper) PostAuctionItemItemIDBids(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "itemID" -------------
+	var itemID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "itemID", c.Param("itemID"), &itemID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUserInfoParams
+	var params PostAuctionItemItemIDBidsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
 
 	{
 		var cookie string
@@ -872,16 +1440,73 @@ func (siw *ServerInterfaceWrapper) GetUserInfo(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUserInfo(c, params)
+	siw.Handler.PostAuctionItemItemIDBids(c, itemID, params)
 }
 
-// PatchUserInfo operation middleware
-func (siw *ServerInterfaceWrapper) PatchUserInfo(c *gin.Context) {
+// GetAuctionItemItemIDEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetAuctionItemItemIDEvents(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "itemID" -------------
+	var itemID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "itemID", c.Param("itemID"), &itemID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemID: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PatchUserInfoParams
+	var params GetAuctionItemItemIDEventsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Last-Event-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Last-Event-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAuctionItemItemIDEvents(c, itemID, params)
+}
+
+// PostAuctionItemItemIDPublish operation middleware
+func (siw *ServerInterfaceWrapper) PostAuctionItemItemIDPublish(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "itemID" -------------
+	var itemID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "itemID", c.Param("itemID"), &itemID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAuctionItemItemIDPublishParams
 
 	{
 		var cookie string
@@ -905,116 +1530,1537 @@ func (siw *ServerInterfaceWrapper) PatchUserInfo(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PatchUserInfo(c, params)
+	siw.Handler.PostAuctionItemItemIDPublish(c, itemID, params)
 }
 
-// GinServerOptions provides options for the Gin server.
-type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
-}
+// PostAuctionItemItemIDRelist operation middleware
+func (siw *ServerInterfaceWrapper) PostAuctionItemItemIDRelist(c *gin.Context) {
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router gin.IRouter, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, GinServerOptions{})
-}
+	var err error
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
-		}
-	}
+	// ------------- Path parameter "itemID" -------------
+	var itemID openapi_types.UUID
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
+	err = runtime.BindStyledParameterWithOptions("simple", "itemID", c.Param("itemID"), &itemID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemID: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	router.POST(options.BaseURL+"/auction/item", wrapper.PostAuctionItem)
-	router.GET(options.BaseURL+"/auction/item/:itemID", wrapper.GetAuctionItemItemID)
-	router.POST(options.BaseURL+"/auction/item/:itemID/bids", wrapper.PostAuctionItemItemIDBids)
-	router.GET(options.BaseURL+"/auction/item/:itemID/events", wrapper.GetAuctionItemItemIDEvents)
-	router.GET(options.BaseURL+"/auction/items", wrapper.GetAuctionItems)
-	router.GET(options.BaseURL+"/auth/logout", wrapper.GetAuthLogout)
-	router.POST(options.BaseURL+"/auth/sso/:provider/callback", wrapper.PostAuthSsoProviderCallback)
-	router.DELETE(options.BaseURL+"/auth/sso/:provider/link", wrapper.DeleteAuthSsoProviderLink)
-	router.POST(options.BaseURL+"/auth/sso/:provider/link", wrapper.PostAuthSsoProviderLink)
-	router.GET(options.BaseURL+"/auth/sso/:provider/login", wrapper.GetAuthSsoProviderLogin)
-	router.POST(options.BaseURL+"/image", wrapper.PostImage)
-	router.GET(options.BaseURL+"/user/info", wrapper.GetUserInfo)
-	router.PATCH(options.BaseURL+"/user/info", wrapper.PatchUserInfo)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAuctionItemItemIDRelistParams
 
-type PostAuctionItemRequestObject struct {
-	Params PostAuctionItemParams
-	Body   *PostAuctionItemJSONRequestBody
-}
+	{
+		var cookie string
 
-type PostAuctionItemResponseObject interface {
-	VisitPostAuctionItemResponse(w http.ResponseWriter) error
-}
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
 
-type PostAuctionItem201ResponseHeaders struct {
-	Location string
-}
+		}
+	}
 
-type PostAuctionItem201Response struct {
-	Headers PostAuctionItem201ResponseHeaders
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuctionItemItemIDRelist(c, itemID, params)
 }
 
-func (response PostAuctionItem201Response) VisitPostAuctionItemResponse(w http.ResponseWriter) error {
-	w.Header().Set("Location", response.Headers.Location)
+// GetAuctionItems operation middleware
+func (siw *ServerInterfaceWrapper) GetAuctionItems(c *gin.Context) {
 
-	w.WriteHeader(201)
-	return nil
-}
+	var err error
 
-type PostAuctionItem400JSONResponse ApiResponse
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuctionItemsParams
 
-func (response PostAuctionItem400JSONResponse) VisitPostAuctionItemResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Optional query parameter "title" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "title", c.Request.URL.Query(), &params.Title)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter title: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type PostAuctionItem401Response struct {
-}
+	// ------------- Optional query parameter "startPrice" -------------
 
-func (response PostAuctionItem401Response) VisitPostAuctionItemResponse(w http.ResponseWriter) error {
-	w.WriteHeader(401)
-	return nil
-}
+	err = runtime.BindQueryParameter("deepObject", true, false, "startPrice", c.Request.URL.Query(), &params.StartPrice)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startPrice: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type GetAuctionItemItemIDRequestObject struct {
-	ItemID openapi_types.UUID `json:"itemID"`
-}
+	// ------------- Optional query parameter "currentBid" -------------
 
-type GetAuctionItemItemIDResponseObject interface {
-	VisitGetAuctionItemItemIDResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("deepObject", true, false, "currentBid", c.Request.URL.Query(), &params.CurrentBid)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter currentBid: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type GetAuctionItemItemID200JSONResponse struct {
-	BidRecords  []BidEvent `json:"bidRecords"`
-	Carousels   []string   `json:"carousels"`
-	Description string     `json:"description"`
-	EndTime     time.Time  `json:"endTime"`
-	StartPrice  int64      `json:"startPrice"`
-	StartTime   time.Time  `json:"startTime"`
-	Title       string     `json:"title"`
-}
+	// ------------- Optional query parameter "startTime" -------------
 
-func (response GetAuctionItemItemID200JSONResponse) VisitGetAuctionItemItemIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindQueryParameter("deepObject", true, false, "startTime", c.Request.URL.Query(), &params.StartTime)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startTime: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "endTime" -------------
+
+	err = runtime.BindQueryParameter("deepObject", true, false, "endTime", c.Request.URL.Query(), &params.EndTime)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter endTime: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("deepObject", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "lastItemID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lastItemID", c.Request.URL.Query(), &params.LastItemID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter lastItemID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter size: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "excludeEnded" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "excludeEnded", c.Request.URL.Query(), &params.ExcludeEnded)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter excludeEnded: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAuctionItems(c, params)
+}
+
+// GetAuctionItemsExport operation middleware
+func (siw *ServerInterfaceWrapper) GetAuctionItemsExport(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuctionItemsExportParams
+
+	// ------------- Required query parameter "format" -------------
+
+	if paramValue := c.Query("format"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument format is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "itemID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "itemID", c.Request.URL.Query(), &params.ItemID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sellerID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sellerID", c.Request.URL.Query(), &params.SellerID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sellerID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAuctionItemsExport(c, params)
+}
+
+// PostAuctionItemsImport operation middleware
+func (siw *ServerInterfaceWrapper) PostAuctionItemsImport(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAuctionItemsImportParams
+
+	// ------------- Required query parameter "format" -------------
+
+	if paramValue := c.Query("format"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument format is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "atomic" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "atomic", c.Request.URL.Query(), &params.Atomic)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter atomic: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuctionItemsImport(c, params)
+}
+
+// GetAuthLogout operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthLogout(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuthLogoutParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("username"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "username", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter username: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.Username = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAuthLogout(c, params)
+}
+
+// PostAuthSsoProviderCallback operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthSsoProviderCallback(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider SSOProvider
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAuthSsoProviderCallbackParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("requestState"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "requestState", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestState: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.RequestState = &value
+
+		}
+	}
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("requestNonce"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "requestNonce", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestNonce: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.RequestNonce = &value
+
+		}
+	}
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("requestRedirectUrl"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "requestRedirectUrl", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestRedirectUrl: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.RequestRedirectUrl = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuthSsoProviderCallback(c, provider, params)
+}
+
+// DeleteAuthSsoProviderLink operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAuthSsoProviderLink(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider SSOProvider
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteAuthSsoProviderLinkParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteAuthSsoProviderLink(c, provider, params)
+}
+
+// PostAuthSsoProviderLink operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthSsoProviderLink(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider SSOProvider
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAuthSsoProviderLinkParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("requestState"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "requestState", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestState: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.RequestState = &value
+
+		}
+	}
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("requestNonce"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "requestNonce", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestNonce: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.RequestNonce = &value
+
+		}
+	}
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("requestRedirectUrl"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "requestRedirectUrl", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestRedirectUrl: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.RequestRedirectUrl = &value
+
+		}
+	}
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuthSsoProviderLink(c, provider, params)
+}
+
+// GetAuthSsoProviderLogin operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthSsoProviderLogin(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider SSOProvider
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuthSsoProviderLoginParams
+
+	// ------------- Required query parameter "redirectUrl" -------------
+
+	if paramValue := c.Query("redirectUrl"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument redirectUrl is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "redirectUrl", c.Request.URL.Query(), &params.RedirectUrl)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter redirectUrl: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAuthSsoProviderLogin(c, provider, params)
+}
+
+// PostImage operation middleware
+func (siw *ServerInterfaceWrapper) PostImage(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostImageParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostImage(c, params)
+}
+
+// GetUserAuctionItems operation middleware
+func (siw *ServerInterfaceWrapper) GetUserAuctionItems(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserAuctionItemsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "lastItemID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lastItemID", c.Request.URL.Query(), &params.LastItemID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter lastItemID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", c.Request.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter size: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserAuctionItems(c, params)
+}
+
+// GetUserInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetUserInfo(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserInfoParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserInfo(c, params)
+}
+
+// PatchUserInfo operation middleware
+func (siw *ServerInterfaceWrapper) PatchUserInfo(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchUserInfoParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchUserInfo(c, params)
+}
+
+// GetUserListingTemplates operation middleware
+func (siw *ServerInterfaceWrapper) GetUserListingTemplates(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserListingTemplatesParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserListingTemplates(c, params)
+}
+
+// PostUserListingTemplates operation middleware
+func (siw *ServerInterfaceWrapper) PostUserListingTemplates(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUserListingTemplatesParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUserListingTemplates(c, params)
+}
+
+// DeleteUserListingTemplatesTemplateID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUserListingTemplatesTemplateID(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "templateID" -------------
+	var templateID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "templateID", c.Param("templateID"), &templateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter templateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUserListingTemplatesTemplateIDParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteUserListingTemplatesTemplateID(c, templateID, params)
+}
+
+// PostUserListingTemplatesTemplateIDItem operation middleware
+func (siw *ServerInterfaceWrapper) PostUserListingTemplatesTemplateIDItem(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "templateID" -------------
+	var templateID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "templateID", c.Param("templateID"), &templateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter templateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUserListingTemplatesTemplateIDItemParams
+
+	{
+		var cookie string
+
+		if cookie, err = c.Cookie("accessToken"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "accessToken", cookie, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter accessToken: %w", err), http.StatusBadRequest)
+				return
+			}
+			params.AccessToken = &value
+
+		}
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUserListingTemplatesTemplateIDItem(c, templateID, params)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.POST(options.BaseURL+"/admin/auction/bid/:bidID/remove", wrapper.PostAdminAuctionBidBidIDRemove)
+	router.POST(options.BaseURL+"/admin/auction/item/:itemID/cancel", wrapper.PostAdminAuctionItemItemIDCancel)
+	router.GET(options.BaseURL+"/admin/dead-letters", wrapper.GetAdminDeadLetters)
+	router.POST(options.BaseURL+"/admin/dead-letters/discard", wrapper.PostAdminDeadLettersDiscard)
+	router.POST(options.BaseURL+"/admin/dead-letters/replay", wrapper.PostAdminDeadLettersReplay)
+	router.GET(options.BaseURL+"/admin/shill/findings", wrapper.GetAdminShillFindings)
+	router.POST(options.BaseURL+"/admin/shill/findings/:findingID/resolve", wrapper.PostAdminShillFindingsFindingIDResolve)
+	router.POST(options.BaseURL+"/admin/user/:userID/suspend", wrapper.PostAdminUserUserIDSuspend)
+	router.POST(options.BaseURL+"/admin/user/:userID/unsuspend", wrapper.PostAdminUserUserIDUnsuspend)
+	router.GET(options.BaseURL+"/auction/bids/export", wrapper.GetAuctionBidsExport)
+	router.POST(options.BaseURL+"/auction/item", wrapper.PostAuctionItem)
+	router.GET(options.BaseURL+"/auction/item/:itemID", wrapper.GetAuctionItemItemID)
+	router.PATCH(options.BaseURL+"/auction/item/:itemID", wrapper.PatchAuctionItemItemID)
+	router.POST(options.BaseURL+"/auction/item/:itemID/bids", wrapper.PostAuctionItemItemIDBids)
+	router.GET(options.BaseURL+"/auction/item/:itemID/events", wrapper.GetAuctionItemItemIDEvents)
+	router.POST(options.BaseURL+"/auction/item/:itemID/publish", wrapper.PostAuctionItemItemIDPublish)
+	router.POST(options.BaseURL+"/auction/item/:itemID/relist", wrapper.PostAuctionItemItemIDRelist)
+	router.GET(options.BaseURL+"/auction/items", wrapper.GetAuctionItems)
+	router.GET(options.BaseURL+"/auction/items/export", wrapper.GetAuctionItemsExport)
+	router.POST(options.BaseURL+"/auction/items/import", wrapper.PostAuctionItemsImport)
+	router.GET(options.BaseURL+"/auth/logout", wrapper.GetAuthLogout)
+	router.POST(options.BaseURL+"/auth/sso/:provider/callback", wrapper.PostAuthSsoProviderCallback)
+	router.DELETE(options.BaseURL+"/auth/sso/:provider/link", wrapper.DeleteAuthSsoProviderLink)
+	router.POST(options.BaseURL+"/auth/sso/:provider/link", wrapper.PostAuthSsoProviderLink)
+	router.GET(options.BaseURL+"/auth/sso/:provider/login", wrapper.GetAuthSsoProviderLogin)
+	router.POST(options.BaseURL+"/image", wrapper.PostImage)
+	router.GET(options.BaseURL+"/user/auction/items", wrapper.GetUserAuctionItems)
+	router.GET(options.BaseURL+"/user/info", wrapper.GetUserInfo)
+	router.PATCH(options.BaseURL+"/user/info", wrapper.PatchUserInfo)
+	router.GET(options.BaseURL+"/user/listing/templates", wrapper.GetUserListingTemplates)
+	router.POST(options.BaseURL+"/user/listing/templates", wrapper.PostUserListingTemplates)
+	router.DELETE(options.BaseURL+"/user/listing/templates/:templateID", wrapper.DeleteUserListingTemplatesTemplateID)
+	router.POST(options.BaseURL+"/user/listing/templates/:templateID/item", wrapper.PostUserListingTemplatesTemplateIDItem)
+}
+
+type PostAdminAuctionBidBidIDRemoveRequestObject struct {
+	BidID  openapi_types.UUID `json:"bidID"`
+	Params PostAdminAuctionBidBidIDRemoveParams
+	Body   *PostAdminAuctionBidBidIDRemoveJSONRequestBody
+}
+
+type PostAdminAuctionBidBidIDRemoveResponseObject interface {
+	VisitPostAdminAuctionBidBidIDRemoveResponse(w http.ResponseWriter) error
+}
+
+type PostAdminAuctionBidBidIDRemove200Response struct {
+}
+
+func (response PostAdminAuctionBidBidIDRemove200Response) VisitPostAdminAuctionBidBidIDRemoveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PostAdminAuctionBidBidIDRemove400JSONResponse ApiResponse
+
+func (response PostAdminAuctionBidBidIDRemove400JSONResponse) VisitPostAdminAuctionBidBidIDRemoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminAuctionBidBidIDRemove401Response struct {
+}
+
+func (response PostAdminAuctionBidBidIDRemove401Response) VisitPostAdminAuctionBidBidIDRemoveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAdminAuctionBidBidIDRemove403JSONResponse ApiResponse
+
+func (response PostAdminAuctionBidBidIDRemove403JSONResponse) VisitPostAdminAuctionBidBidIDRemoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminAuctionBidBidIDRemove404Response struct {
+}
+
+func (response PostAdminAuctionBidBidIDRemove404Response) VisitPostAdminAuctionBidBidIDRemoveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAdminAuctionBidBidIDRemove409Response struct {
+}
+
+func (response PostAdminAuctionBidBidIDRemove409Response) VisitPostAdminAuctionBidBidIDRemoveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type PostAdminAuctionItemItemIDCancelRequestObject struct {
+	ItemID openapi_types.UUID `json:"itemID"`
+	Params PostAdminAuctionItemItemIDCancelParams
+	Body   *PostAdminAuctionItemItemIDCancelJSONRequestBody
+}
+
+type PostAdminAuctionItemItemIDCancelResponseObject interface {
+	VisitPostAdminAuctionItemItemIDCancelResponse(w http.ResponseWriter) error
+}
+
+type PostAdminAuctionItemItemIDCancel200Response struct {
+}
+
+func (response PostAdminAuctionItemItemIDCancel200Response) VisitPostAdminAuctionItemItemIDCancelResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PostAdminAuctionItemItemIDCancel400JSONResponse ApiResponse
+
+func (response PostAdminAuctionItemItemIDCancel400JSONResponse) VisitPostAdminAuctionItemItemIDCancelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminAuctionItemItemIDCancel401Response struct {
+}
+
+func (response PostAdminAuctionItemItemIDCancel401Response) VisitPostAdminAuctionItemItemIDCancelResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAdminAuctionItemItemIDCancel403JSONResponse ApiResponse
+
+func (response PostAdminAuctionItemItemIDCancel403JSONResponse) VisitPostAdminAuctionItemItemIDCancelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminAuctionItemItemIDCancel404Response struct {
+}
+
+func (response PostAdminAuctionItemItemIDCancel404Response) VisitPostAdminAuctionItemItemIDCancelResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAdminAuctionItemItemIDCancel409Response struct {
+}
+
+func (response PostAdminAuctionItemItemIDCancel409Response) VisitPostAdminAuctionItemItemIDCancelResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type GetAdminDeadLettersRequestObject struct {
+	Params GetAdminDeadLettersParams
+}
+
+type GetAdminDeadLettersResponseObject interface {
+	VisitGetAdminDeadLettersResponse(w http.ResponseWriter) error
+}
+
+type GetAdminDeadLetters200JSONResponse struct {
+	Count   int               `json:"count"`
+	Entries []DeadLetterEntry `json:"entries"`
+
+	// Partitions The number of bid stream partitions.
+	Partitions int `json:"partitions"`
+}
+
+func (response GetAdminDeadLetters200JSONResponse) VisitGetAdminDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminDeadLetters400JSONResponse ApiResponse
+
+func (response GetAdminDeadLetters400JSONResponse) VisitGetAdminDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminDeadLetters401Response struct {
+}
+
+func (response GetAdminDeadLetters401Response) VisitGetAdminDeadLettersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetAdminDeadLetters403JSONResponse ApiResponse
+
+func (response GetAdminDeadLetters403JSONResponse) VisitGetAdminDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminDeadLettersDiscardRequestObject struct {
+	Params PostAdminDeadLettersDiscardParams
+	Body   *PostAdminDeadLettersDiscardJSONRequestBody
+}
+
+type PostAdminDeadLettersDiscardResponseObject interface {
+	VisitPostAdminDeadLettersDiscardResponse(w http.ResponseWriter) error
+}
+
+type PostAdminDeadLettersDiscard200JSONResponse DeadLetterActionResult
+
+func (response PostAdminDeadLettersDiscard200JSONResponse) VisitPostAdminDeadLettersDiscardResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminDeadLettersDiscard400JSONResponse ApiResponse
+
+func (response PostAdminDeadLettersDiscard400JSONResponse) VisitPostAdminDeadLettersDiscardResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminDeadLettersDiscard401Response struct {
+}
+
+func (response PostAdminDeadLettersDiscard401Response) VisitPostAdminDeadLettersDiscardResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAdminDeadLettersDiscard403JSONResponse ApiResponse
+
+func (response PostAdminDeadLettersDiscard403JSONResponse) VisitPostAdminDeadLettersDiscardResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminDeadLettersReplayRequestObject struct {
+	Params PostAdminDeadLettersReplayParams
+	Body   *PostAdminDeadLettersReplayJSONRequestBody
+}
+
+type PostAdminDeadLettersReplayResponseObject interface {
+	VisitPostAdminDeadLettersReplayResponse(w http.ResponseWriter) error
+}
+
+type PostAdminDeadLettersReplay200JSONResponse DeadLetterActionResult
+
+func (response PostAdminDeadLettersReplay200JSONResponse) VisitPostAdminDeadLettersReplayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminDeadLettersReplay400JSONResponse ApiResponse
+
+func (response PostAdminDeadLettersReplay400JSONResponse) VisitPostAdminDeadLettersReplayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminDeadLettersReplay401Response struct {
+}
+
+func (response PostAdminDeadLettersReplay401Response) VisitPostAdminDeadLettersReplayResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAdminDeadLettersReplay403JSONResponse ApiResponse
+
+func (response PostAdminDeadLettersReplay403JSONResponse) VisitPostAdminDeadLettersReplayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminShillFindingsRequestObject struct {
+	Params GetAdminShillFindingsParams
+}
+
+type GetAdminShillFindingsResponseObject interface {
+	VisitGetAdminShillFindingsResponse(w http.ResponseWriter) error
+}
+
+type GetAdminShillFindings200JSONResponse struct {
+	Count    int            `json:"count"`
+	Findings []ShillFinding `json:"findings"`
+}
+
+func (response GetAdminShillFindings200JSONResponse) VisitGetAdminShillFindingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminShillFindings400JSONResponse ApiResponse
+
+func (response GetAdminShillFindings400JSONResponse) VisitGetAdminShillFindingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminShillFindings401Response struct {
+}
+
+func (response GetAdminShillFindings401Response) VisitGetAdminShillFindingsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetAdminShillFindings403JSONResponse ApiResponse
+
+func (response GetAdminShillFindings403JSONResponse) VisitGetAdminShillFindingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminShillFindingsFindingIDResolveRequestObject struct {
+	FindingID openapi_types.UUID `json:"findingID"`
+	Params    PostAdminShillFindingsFindingIDResolveParams
+	Body      *PostAdminShillFindingsFindingIDResolveJSONRequestBody
+}
+
+type PostAdminShillFindingsFindingIDResolveResponseObject interface {
+	VisitPostAdminShillFindingsFindingIDResolveResponse(w http.ResponseWriter) error
+}
+
+type PostAdminShillFindingsFindingIDResolve200Response struct {
+}
+
+func (response PostAdminShillFindingsFindingIDResolve200Response) VisitPostAdminShillFindingsFindingIDResolveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PostAdminShillFindingsFindingIDResolve400JSONResponse ApiResponse
+
+func (response PostAdminShillFindingsFindingIDResolve400JSONResponse) VisitPostAdminShillFindingsFindingIDResolveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminShillFindingsFindingIDResolve401Response struct {
+}
+
+func (response PostAdminShillFindingsFindingIDResolve401Response) VisitPostAdminShillFindingsFindingIDResolveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAdminShillFindingsFindingIDResolve403JSONResponse ApiResponse
+
+func (response PostAdminShillFindingsFindingIDResolve403JSONResponse) VisitPostAdminShillFindingsFindingIDResolveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminShillFindingsFindingIDResolve404Response struct {
+}
+
+func (response PostAdminShillFindingsFindingIDResolve404Response) VisitPostAdminShillFindingsFindingIDResolveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAdminShillFindingsFindingIDResolve409Response struct {
+}
+
+func (response PostAdminShillFindingsFindingIDResolve409Response) VisitPostAdminShillFindingsFindingIDResolveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type PostAdminUserUserIDSuspendRequestObject struct {
+	UserID openapi_types.UUID `json:"userID"`
+	Params PostAdminUserUserIDSuspendParams
+	Body   *PostAdminUserUserIDSuspendJSONRequestBody
+}
+
+type PostAdminUserUserIDSuspendResponseObject interface {
+	VisitPostAdminUserUserIDSuspendResponse(w http.ResponseWriter) error
+}
+
+type PostAdminUserUserIDSuspend200Response struct {
+}
+
+func (response PostAdminUserUserIDSuspend200Response) VisitPostAdminUserUserIDSuspendResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PostAdminUserUserIDSuspend400JSONResponse ApiResponse
+
+func (response PostAdminUserUserIDSuspend400JSONResponse) VisitPostAdminUserUserIDSuspendResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUserUserIDSuspend401Response struct {
+}
+
+func (response PostAdminUserUserIDSuspend401Response) VisitPostAdminUserUserIDSuspendResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAdminUserUserIDSuspend403JSONResponse ApiResponse
+
+func (response PostAdminUserUserIDSuspend403JSONResponse) VisitPostAdminUserUserIDSuspendResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUserUserIDSuspend404Response struct {
+}
+
+func (response PostAdminUserUserIDSuspend404Response) VisitPostAdminUserUserIDSuspendResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAdminUserUserIDSuspend409Response struct {
+}
+
+func (response PostAdminUserUserIDSuspend409Response) VisitPostAdminUserUserIDSuspendResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type PostAdminUserUserIDUnsuspendRequestObject struct {
+	UserID openapi_types.UUID `json:"userID"`
+	Params PostAdminUserUserIDUnsuspendParams
+	Body   *PostAdminUserUserIDUnsuspendJSONRequestBody
+}
+
+type PostAdminUserUserIDUnsuspendResponseObject interface {
+	VisitPostAdminUserUserIDUnsuspendResponse(w http.ResponseWriter) error
+}
+
+type PostAdminUserUserIDUnsuspend200Response struct {
+}
+
+func (response PostAdminUserUserIDUnsuspend200Response) VisitPostAdminUserUserIDUnsuspendResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PostAdminUserUserIDUnsuspend400JSONResponse ApiResponse
+
+func (response PostAdminUserUserIDUnsuspend400JSONResponse) VisitPostAdminUserUserIDUnsuspendResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUserUserIDUnsuspend401Response struct {
+}
+
+func (response PostAdminUserUserIDUnsuspend401Response) VisitPostAdminUserUserIDUnsuspendResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAdminUserUserIDUnsuspend403JSONResponse ApiResponse
+
+func (response PostAdminUserUserIDUnsuspend403JSONResponse) VisitPostAdminUserUserIDUnsuspendResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUserUserIDUnsuspend404Response struct {
+}
+
+func (response PostAdminUserUserIDUnsuspend404Response) VisitPostAdminUserUserIDUnsuspendResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAdminUserUserIDUnsuspend409Response struct {
+}
+
+func (response PostAdminUserUserIDUnsuspend409Response) VisitPostAdminUserUserIDUnsuspendResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type GetAuctionBidsExportRequestObject struct {
+	Params GetAuctionBidsExportParams
+}
+
+type GetAuctionBidsExportResponseObject interface {
+	VisitGetAuctionBidsExportResponse(w http.ResponseWriter) error
+}
+
+type GetAuctionBidsExport200Response struct {
+}
+
+func (response GetAuctionBidsExport200Response) VisitGetAuctionBidsExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type GetAuctionBidsExport400JSONResponse ApiResponse
+
+func (response GetAuctionBidsExport400JSONResponse) VisitGetAuctionBidsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuctionBidsExport401Response struct {
+}
+
+func (response GetAuctionBidsExport401Response) VisitGetAuctionBidsExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetAuctionBidsExport403JSONResponse ApiResponse
+
+func (response GetAuctionBidsExport403JSONResponse) VisitGetAuctionBidsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuctionItemRequestObject struct {
+	Params PostAuctionItemParams
+	Body   *PostAuctionItemJSONRequestBody
+}
+
+type PostAuctionItemResponseObject interface {
+	VisitPostAuctionItemResponse(w http.ResponseWriter) error
+}
+
+type PostAuctionItem201ResponseHeaders struct {
+	Location string
+}
+
+type PostAuctionItem201Response struct {
+	Headers PostAuctionItem201ResponseHeaders
+}
+
+func (response PostAuctionItem201Response) VisitPostAuctionItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", response.Headers.Location)
+
+	w.WriteHeader(201)
+	return nil
+}
+
+type PostAuctionItem400JSONResponse ApiResponse
+
+func (response PostAuctionItem400JSONResponse) VisitPostAuctionItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuctionItem401Response struct {
+}
+
+func (response PostAuctionItem401Response) VisitPostAuctionItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAuctionItem403JSONResponse ApiResponse
+
+func (response PostAuctionItem403JSONResponse) VisitPostAuctionItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuctionItemItemIDRequestObject struct {
+	ItemID openapi_types.UUID `json:"itemID"`
+	Params GetAuctionItemItemIDParams
+}
+
+type GetAuctionItemItemIDResponseObject interface {
+	VisitGetAuctionItemItemIDResponse(w http.ResponseWriter) error
+}
+
+type GetAuctionItemItemID200JSONResponse struct {
+	BidRecords  []BidEvent    `json:"bidRecords"`
+	Carousels   []string      `json:"carousels"`
+	Description string        `json:"description"`
+	EndTime     time.Time     `json:"endTime"`
+	StartPrice  int64         `json:"startPrice"`
+	StartTime   time.Time     `json:"startTime"`
+	Status      AuctionStatus `json:"status"`
+	Title       string        `json:"title"`
+}
+
+func (response GetAuctionItemItemID200JSONResponse) VisitGetAuctionItemItemIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
 
 type GetAuctionItemItemID404Response struct {
 }
@@ -1024,6 +3070,67 @@ func (response GetAuctionItemItemID404Response) VisitGetAuctionItemItemIDRespons
 	return nil
 }
 
+type PatchAuctionItemItemIDRequestObject struct {
+	ItemID openapi_types.UUID `json:"itemID"`
+	Params PatchAuctionItemItemIDParams
+	Body   *PatchAuctionItemItemIDJSONRequestBody
+}
+
+type PatchAuctionItemItemIDResponseObject interface {
+	VisitPatchAuctionItemItemIDResponse(w http.ResponseWriter) error
+}
+
+type PatchAuctionItemItemID200Response struct {
+}
+
+func (response PatchAuctionItemItemID200Response) VisitPatchAuctionItemItemIDResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PatchAuctionItemItemID400JSONResponse ApiResponse
+
+func (response PatchAuctionItemItemID400JSONResponse) VisitPatchAuctionItemItemIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchAuctionItemItemID401Response struct {
+}
+
+func (response PatchAuctionItemItemID401Response) VisitPatchAuctionItemItemIDResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PatchAuctionItemItemID403JSONResponse ApiResponse
+
+func (response PatchAuctionItemItemID403JSONResponse) VisitPatchAuctionItemItemIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchAuctionItemItemID404Response struct {
+}
+
+func (response PatchAuctionItemItemID404Response) VisitPatchAuctionItemItemIDResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PatchAuctionItemItemID409JSONResponse ApiResponse
+
+func (response PatchAuctionItemItemID409JSONResponse) VisitPatchAuctionItemItemIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostAuctionItemItemIDBidsRequestObject struct {
 	ItemID openapi_types.UUID `json:"itemID"`
 	Params PostAuctionItemItemIDBidsParams
@@ -1091,8 +3198,35 @@ func (response PostAuctionItemItemIDBids410JSONResponse) VisitPostAuctionItemIte
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostAuctionItemItemIDBids422JSONResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+func (response PostAuctionItemItemIDBids422JSONResponse) VisitPostAuctionItemItemIDBidsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuctionItemItemIDBids429ResponseHeaders struct {
+	RetryAfter string
+}
+
+type PostAuctionItemItemIDBids429Response struct {
+	Headers PostAuctionItemItemIDBids429ResponseHeaders
+}
+
+func (response PostAuctionItemItemIDBids429Response) VisitPostAuctionItemItemIDBidsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Retry-After", response.Headers.RetryAfter)
+
+	w.WriteHeader(429)
+	return nil
+}
+
 type GetAuctionItemItemIDEventsRequestObject struct {
 	ItemID openapi_types.UUID `json:"itemID"`
+	Params GetAuctionItemItemIDEventsParams
 }
 
 type GetAuctionItemItemIDEventsResponseObject interface {
@@ -1113,74 +3247,300 @@ type GetAuctionItemItemIDEvents403JSONResponse struct {
 
 func (response GetAuctionItemItemIDEvents403JSONResponse) VisitGetAuctionItemItemIDEventsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuctionItemItemIDEvents404Response struct {
+}
+
+func (response GetAuctionItemItemIDEvents404Response) VisitGetAuctionItemItemIDEventsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetAuctionItemItemIDEvents410JSONResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+func (response GetAuctionItemItemIDEvents410JSONResponse) VisitGetAuctionItemItemIDEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuctionItemItemIDPublishRequestObject struct {
+	ItemID openapi_types.UUID `json:"itemID"`
+	Params PostAuctionItemItemIDPublishParams
+}
+
+type PostAuctionItemItemIDPublishResponseObject interface {
+	VisitPostAuctionItemItemIDPublishResponse(w http.ResponseWriter) error
+}
+
+type PostAuctionItemItemIDPublish200JSONResponse struct {
+	Status AuctionStatus `json:"status"`
+}
+
+func (response PostAuctionItemItemIDPublish200JSONResponse) VisitPostAuctionItemItemIDPublishResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuctionItemItemIDPublish400JSONResponse ApiResponse
+
+func (response PostAuctionItemItemIDPublish400JSONResponse) VisitPostAuctionItemItemIDPublishResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuctionItemItemIDPublish401Response struct {
+}
+
+func (response PostAuctionItemItemIDPublish401Response) VisitPostAuctionItemItemIDPublishResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAuctionItemItemIDPublish403JSONResponse ApiResponse
+
+func (response PostAuctionItemItemIDPublish403JSONResponse) VisitPostAuctionItemItemIDPublishResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuctionItemItemIDPublish404Response struct {
+}
+
+func (response PostAuctionItemItemIDPublish404Response) VisitPostAuctionItemItemIDPublishResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAuctionItemItemIDPublish409Response struct {
+}
+
+func (response PostAuctionItemItemIDPublish409Response) VisitPostAuctionItemItemIDPublishResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type PostAuctionItemItemIDRelistRequestObject struct {
+	ItemID openapi_types.UUID `json:"itemID"`
+	Params PostAuctionItemItemIDRelistParams
+	Body   *PostAuctionItemItemIDRelistJSONRequestBody
+}
+
+type PostAuctionItemItemIDRelistResponseObject interface {
+	VisitPostAuctionItemItemIDRelistResponse(w http.ResponseWriter) error
+}
+
+type PostAuctionItemItemIDRelist201ResponseHeaders struct {
+	Location string
+}
+
+type PostAuctionItemItemIDRelist201Response struct {
+	Headers PostAuctionItemItemIDRelist201ResponseHeaders
+}
+
+func (response PostAuctionItemItemIDRelist201Response) VisitPostAuctionItemItemIDRelistResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", response.Headers.Location)
+
+	w.WriteHeader(201)
+	return nil
+}
+
+type PostAuctionItemItemIDRelist400JSONResponse ApiResponse
+
+func (response PostAuctionItemItemIDRelist400JSONResponse) VisitPostAuctionItemItemIDRelistResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuctionItemItemIDRelist401Response struct {
+}
+
+func (response PostAuctionItemItemIDRelist401Response) VisitPostAuctionItemItemIDRelistResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAuctionItemItemIDRelist403JSONResponse ApiResponse
+
+func (response PostAuctionItemItemIDRelist403JSONResponse) VisitPostAuctionItemItemIDRelistResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuctionItemItemIDRelist404Response struct {
+}
+
+func (response PostAuctionItemItemIDRelist404Response) VisitPostAuctionItemItemIDRelistResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAuctionItemItemIDRelist409JSONResponse ApiResponse
+
+func (response PostAuctionItemItemIDRelist409JSONResponse) VisitPostAuctionItemItemIDRelistResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuctionItemsRequestObject struct {
+	Params GetAuctionItemsParams
+}
+
+type GetAuctionItemsResponseObject interface {
+	VisitGetAuctionItemsResponse(w http.ResponseWriter) error
+}
+
+type GetAuctionItems200JSONResponse struct {
+	Count int `json:"count"`
+	Items []struct {
+		CurrentBid uint32             `json:"currentBid"`
+		EndTime    time.Time          `json:"endTime"`
+		Id         openapi_types.UUID `json:"id"`
+		IsEnded    bool               `json:"isEnded"`
+		StartTime  time.Time          `json:"startTime"`
+		Status     AuctionStatus      `json:"status"`
+		Title      string             `json:"title"`
+	} `json:"items"`
+}
+
+func (response GetAuctionItems200JSONResponse) VisitGetAuctionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuctionItems400JSONResponse ApiResponse
+
+func (response GetAuctionItems400JSONResponse) VisitGetAuctionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuctionItems404Response struct {
+}
+
+func (response GetAuctionItems404Response) VisitGetAuctionItemsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetAuctionItemsExportRequestObject struct {
+	Params GetAuctionItemsExportParams
+}
+
+type GetAuctionItemsExportResponseObject interface {
+	VisitGetAuctionItemsExportResponse(w http.ResponseWriter) error
+}
+
+type GetAuctionItemsExport200Response struct {
+}
+
+func (response GetAuctionItemsExport200Response) VisitGetAuctionItemsExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type GetAuctionItemsExport400JSONResponse ApiResponse
+
+func (response GetAuctionItemsExport400JSONResponse) VisitGetAuctionItemsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuctionItemItemIDEvents404Response struct {
+type GetAuctionItemsExport401Response struct {
 }
 
-func (response GetAuctionItemItemIDEvents404Response) VisitGetAuctionItemItemIDEventsResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
+func (response GetAuctionItemsExport401Response) VisitGetAuctionItemsExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
 	return nil
 }
 
-type GetAuctionItemItemIDEvents410JSONResponse struct {
-	Message *string `json:"message,omitempty"`
-}
+type GetAuctionItemsExport403JSONResponse ApiResponse
 
-func (response GetAuctionItemItemIDEvents410JSONResponse) VisitGetAuctionItemItemIDEventsResponse(w http.ResponseWriter) error {
+func (response GetAuctionItemsExport403JSONResponse) VisitGetAuctionItemsExportResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(410)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuctionItemsRequestObject struct {
-	Params GetAuctionItemsParams
+type PostAuctionItemsImportRequestObject struct {
+	Params PostAuctionItemsImportParams
+	Body   io.Reader
 }
 
-type GetAuctionItemsResponseObject interface {
-	VisitGetAuctionItemsResponse(w http.ResponseWriter) error
+type PostAuctionItemsImportResponseObject interface {
+	VisitPostAuctionItemsImportResponse(w http.ResponseWriter) error
 }
 
-type GetAuctionItems200JSONResponse struct {
-	Count int `json:"count"`
-	Items []struct {
-		CurrentBid uint32             `json:"currentBid"`
-		EndTime    time.Time          `json:"endTime"`
-		Id         openapi_types.UUID `json:"id"`
-		IsEnded    bool               `json:"isEnded"`
-		StartTime  time.Time          `json:"startTime"`
-		Title      string             `json:"title"`
-	} `json:"items"`
-}
+type PostAuctionItemsImport200JSONResponse AuctionItemImportReport
 
-func (response GetAuctionItems200JSONResponse) VisitGetAuctionItemsResponse(w http.ResponseWriter) error {
+func (response PostAuctionItemsImport200JSONResponse) VisitPostAuctionItemsImportResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuctionItems400JSONResponse ApiResponse
+type PostAuctionItemsImport400JSONResponse ApiResponse
 
-func (response GetAuctionItems400JSONResponse) VisitGetAuctionItemsResponse(w http.ResponseWriter) error {
+func (response PostAuctionItemsImport400JSONResponse) VisitPostAuctionItemsImportResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuctionItems404Response struct {
+type PostAuctionItemsImport401Response struct {
 }
 
-func (response GetAuctionItems404Response) VisitGetAuctionItemsResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
+func (response PostAuctionItemsImport401Response) VisitPostAuctionItemsImportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
 	return nil
 }
 
+type PostAuctionItemsImport403JSONResponse ApiResponse
+
+func (response PostAuctionItemsImport403JSONResponse) VisitPostAuctionItemsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuctionItemsImport422JSONResponse AuctionItemImportReport
+
+func (response PostAuctionItemsImport422JSONResponse) VisitPostAuctionItemsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetAuthLogoutRequestObject struct {
 	Params GetAuthLogoutParams
 }
@@ -1308,6 +3668,15 @@ func (response PostAuthSsoProviderCallback400Response) VisitPostAuthSsoProviderC
 	return nil
 }
 
+type PostAuthSsoProviderCallback403JSONResponse ApiResponse
+
+func (response PostAuthSsoProviderCallback403JSONResponse) VisitPostAuthSsoProviderCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostAuthSsoProviderCallback404Response struct {
 }
 
@@ -1341,6 +3710,15 @@ func (response DeleteAuthSsoProviderLink401Response) VisitDeleteAuthSsoProviderL
 	return nil
 }
 
+type DeleteAuthSsoProviderLink403JSONResponse ApiResponse
+
+func (response DeleteAuthSsoProviderLink403JSONResponse) VisitDeleteAuthSsoProviderLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteAuthSsoProviderLink404Response struct {
 }
 
@@ -1428,6 +3806,15 @@ func (response PostAuthSsoProviderLink401Response) VisitPostAuthSsoProviderLinkR
 	return nil
 }
 
+type PostAuthSsoProviderLink403JSONResponse ApiResponse
+
+func (response PostAuthSsoProviderLink403JSONResponse) VisitPostAuthSsoProviderLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostAuthSsoProviderLink404Response struct {
 }
 
@@ -1541,6 +3928,15 @@ func (response PostImage401Response) VisitPostImageResponse(w http.ResponseWrite
 	return nil
 }
 
+type PostImage403JSONResponse ApiResponse
+
+func (response PostImage403JSONResponse) VisitPostImageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostImage429Response struct {
 }
 
@@ -1549,6 +3945,58 @@ func (response PostImage429Response) VisitPostImageResponse(w http.ResponseWrite
 	return nil
 }
 
+type GetUserAuctionItemsRequestObject struct {
+	Params GetUserAuctionItemsParams
+}
+
+type GetUserAuctionItemsResponseObject interface {
+	VisitGetUserAuctionItemsResponse(w http.ResponseWriter) error
+}
+
+type GetUserAuctionItems200JSONResponse struct {
+	Count int `json:"count"`
+	Items []struct {
+		EndTime   time.Time          `json:"endTime"`
+		Id        openapi_types.UUID `json:"id"`
+		StartTime time.Time          `json:"startTime"`
+		Status    AuctionStatus      `json:"status"`
+		Title     string             `json:"title"`
+	} `json:"items"`
+}
+
+func (response GetUserAuctionItems200JSONResponse) VisitGetUserAuctionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserAuctionItems400JSONResponse ApiResponse
+
+func (response GetUserAuctionItems400JSONResponse) VisitGetUserAuctionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserAuctionItems401Response struct {
+}
+
+func (response GetUserAuctionItems401Response) VisitGetUserAuctionItemsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetUserAuctionItems403JSONResponse ApiResponse
+
+func (response GetUserAuctionItems403JSONResponse) VisitGetUserAuctionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetUserInfoRequestObject struct {
 	Params GetUserInfoParams
 }
@@ -1577,6 +4025,15 @@ func (response GetUserInfo401Response) VisitGetUserInfoResponse(w http.ResponseW
 	return nil
 }
 
+type GetUserInfo403JSONResponse ApiResponse
+
+func (response GetUserInfo403JSONResponse) VisitGetUserInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PatchUserInfoRequestObject struct {
 	Params PatchUserInfoParams
 	Body   *PatchUserInfoJSONRequestBody
@@ -1602,31 +4059,281 @@ func (response PatchUserInfo400Response) VisitPatchUserInfoResponse(w http.Respo
 	return nil
 }
 
-type PatchUserInfo401Response struct {
+type PatchUserInfo401Response struct {
+}
+
+func (response PatchUserInfo401Response) VisitPatchUserInfoResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PatchUserInfo403JSONResponse ApiResponse
+
+func (response PatchUserInfo403JSONResponse) VisitPatchUserInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserListingTemplatesRequestObject struct {
+	Params GetUserListingTemplatesParams
+}
+
+type GetUserListingTemplatesResponseObject interface {
+	VisitGetUserListingTemplatesResponse(w http.ResponseWriter) error
+}
+
+type GetUserListingTemplates200JSONResponse struct {
+	Count     int               `json:"count"`
+	Templates []ListingTemplate `json:"templates"`
+}
+
+func (response GetUserListingTemplates200JSONResponse) VisitGetUserListingTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserListingTemplates401Response struct {
+}
+
+func (response GetUserListingTemplates401Response) VisitGetUserListingTemplatesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetUserListingTemplates403JSONResponse ApiResponse
+
+func (response GetUserListingTemplates403JSONResponse) VisitGetUserListingTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUserListingTemplatesRequestObject struct {
+	Params PostUserListingTemplatesParams
+	Body   *PostUserListingTemplatesJSONRequestBody
+}
+
+type PostUserListingTemplatesResponseObject interface {
+	VisitPostUserListingTemplatesResponse(w http.ResponseWriter) error
+}
+
+type PostUserListingTemplates201ResponseHeaders struct {
+	Location string
+}
+
+type PostUserListingTemplates201Response struct {
+	Headers PostUserListingTemplates201ResponseHeaders
+}
+
+func (response PostUserListingTemplates201Response) VisitPostUserListingTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", response.Headers.Location)
+
+	w.WriteHeader(201)
+	return nil
+}
+
+type PostUserListingTemplates400JSONResponse ApiResponse
+
+func (response PostUserListingTemplates400JSONResponse) VisitPostUserListingTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUserListingTemplates401Response struct {
+}
+
+func (response PostUserListingTemplates401Response) VisitPostUserListingTemplatesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostUserListingTemplates403JSONResponse ApiResponse
+
+func (response PostUserListingTemplates403JSONResponse) VisitPostUserListingTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUserListingTemplates409Response struct {
+}
+
+func (response PostUserListingTemplates409Response) VisitPostUserListingTemplatesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type DeleteUserListingTemplatesTemplateIDRequestObject struct {
+	TemplateID openapi_types.UUID `json:"templateID"`
+	Params     DeleteUserListingTemplatesTemplateIDParams
+}
+
+type DeleteUserListingTemplatesTemplateIDResponseObject interface {
+	VisitDeleteUserListingTemplatesTemplateIDResponse(w http.ResponseWriter) error
+}
+
+type DeleteUserListingTemplatesTemplateID200Response struct {
+}
+
+func (response DeleteUserListingTemplatesTemplateID200Response) VisitDeleteUserListingTemplatesTemplateIDResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type DeleteUserListingTemplatesTemplateID401Response struct {
+}
+
+func (response DeleteUserListingTemplatesTemplateID401Response) VisitDeleteUserListingTemplatesTemplateIDResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteUserListingTemplatesTemplateID403JSONResponse ApiResponse
+
+func (response DeleteUserListingTemplatesTemplateID403JSONResponse) VisitDeleteUserListingTemplatesTemplateIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUserListingTemplatesTemplateID404Response struct {
+}
+
+func (response DeleteUserListingTemplatesTemplateID404Response) VisitDeleteUserListingTemplatesTemplateIDResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostUserListingTemplatesTemplateIDItemRequestObject struct {
+	TemplateID openapi_types.UUID `json:"templateID"`
+	Params     PostUserListingTemplatesTemplateIDItemParams
+	Body       *PostUserListingTemplatesTemplateIDItemJSONRequestBody
+}
+
+type PostUserListingTemplatesTemplateIDItemResponseObject interface {
+	VisitPostUserListingTemplatesTemplateIDItemResponse(w http.ResponseWriter) error
+}
+
+type PostUserListingTemplatesTemplateIDItem201ResponseHeaders struct {
+	Location string
+}
+
+type PostUserListingTemplatesTemplateIDItem201Response struct {
+	Headers PostUserListingTemplatesTemplateIDItem201ResponseHeaders
+}
+
+func (response PostUserListingTemplatesTemplateIDItem201Response) VisitPostUserListingTemplatesTemplateIDItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", response.Headers.Location)
+
+	w.WriteHeader(201)
+	return nil
+}
+
+type PostUserListingTemplatesTemplateIDItem400JSONResponse ApiResponse
+
+func (response PostUserListingTemplatesTemplateIDItem400JSONResponse) VisitPostUserListingTemplatesTemplateIDItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUserListingTemplatesTemplateIDItem401Response struct {
+}
+
+func (response PostUserListingTemplatesTemplateIDItem401Response) VisitPostUserListingTemplatesTemplateIDItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostUserListingTemplatesTemplateIDItem403JSONResponse ApiResponse
+
+func (response PostUserListingTemplatesTemplateIDItem403JSONResponse) VisitPostUserListingTemplatesTemplateIDItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response PatchUserInfo401Response) VisitPatchUserInfoResponse(w http.ResponseWriter) error {
-	w.WriteHeader(401)
+type PostUserListingTemplatesTemplateIDItem404Response struct {
+}
+
+func (response PostUserListingTemplatesTemplateIDItem404Response) VisitPostUserListingTemplatesTemplateIDItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
 	return nil
 }
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Remove a bid
+	// (POST /admin/auction/bid/{bidID}/remove)
+	PostAdminAuctionBidBidIDRemove(ctx context.Context, request PostAdminAuctionBidBidIDRemoveRequestObject) (PostAdminAuctionBidBidIDRemoveResponseObject, error)
+	// Force-cancel an auction
+	// (POST /admin/auction/item/{itemID}/cancel)
+	PostAdminAuctionItemItemIDCancel(ctx context.Context, request PostAdminAuctionItemItemIDCancelRequestObject) (PostAdminAuctionItemItemIDCancelResponseObject, error)
+	// List dead letters
+	// (GET /admin/dead-letters)
+	GetAdminDeadLetters(ctx context.Context, request GetAdminDeadLettersRequestObject) (GetAdminDeadLettersResponseObject, error)
+	// Discard dead letters
+	// (POST /admin/dead-letters/discard)
+	PostAdminDeadLettersDiscard(ctx context.Context, request PostAdminDeadLettersDiscardRequestObject) (PostAdminDeadLettersDiscardResponseObject, error)
+	// Replay dead letters
+	// (POST /admin/dead-letters/replay)
+	PostAdminDeadLettersReplay(ctx context.Context, request PostAdminDeadLettersReplayRequestObject) (PostAdminDeadLettersReplayResponseObject, error)
+	// List shill bidding findings
+	// (GET /admin/shill/findings)
+	GetAdminShillFindings(ctx context.Context, request GetAdminShillFindingsRequestObject) (GetAdminShillFindingsResponseObject, error)
+	// Resolve a shill bidding finding
+	// (POST /admin/shill/findings/{findingID}/resolve)
+	PostAdminShillFindingsFindingIDResolve(ctx context.Context, request PostAdminShillFindingsFindingIDResolveRequestObject) (PostAdminShillFindingsFindingIDResolveResponseObject, error)
+	// Suspend a user
+	// (POST /admin/user/{userID}/suspend)
+	PostAdminUserUserIDSuspend(ctx context.Context, request PostAdminUserUserIDSuspendRequestObject) (PostAdminUserUserIDSuspendResponseObject, error)
+	// Unsuspend a user
+	// (POST /admin/user/{userID}/unsuspend)
+	PostAdminUserUserIDUnsuspend(ctx context.Context, request PostAdminUserUserIDUnsuspendRequestObject) (PostAdminUserUserIDUnsuspendResponseObject, error)
+	// Export bid history
+	// (GET /auction/bids/export)
+	GetAuctionBidsExport(ctx context.Context, request GetAuctionBidsExportRequestObject) (GetAuctionBidsExportResponseObject, error)
 	// Add a new auction item
 	// (POST /auction/item)
 	PostAuctionItem(ctx context.Context, request PostAuctionItemRequestObject) (PostAuctionItemResponseObject, error)
 	// Get auction item details
 	// (GET /auction/item/{itemID})
 	GetAuctionItemItemID(ctx context.Context, request GetAuctionItemItemIDRequestObject) (GetAuctionItemItemIDResponseObject, error)
+	// Update an auction item
+	// (PATCH /auction/item/{itemID})
+	PatchAuctionItemItemID(ctx context.Context, request PatchAuctionItemItemIDRequestObject) (PatchAuctionItemItemIDResponseObject, error)
 	// Place a bid on an auction item
 	// (POST /auction/item/{itemID}/bids)
 	PostAuctionItemItemIDBids(ctx context.Context, request PostAuctionItemItemIDBidsRequestObject) (PostAuctionItemItemIDBidsResponseObject, error)
 	// Track auction item events
 	// (GET /auction/item/{itemID}/events)
 	GetAuctionItemItemIDEvents(ctx context.Context, request GetAuctionItemItemIDEventsRequestObject) (GetAuctionItemItemIDEventsResponseObject, error)
+	// Publish a draft auction item
+	// (POST /auction/item/{itemID}/publish)
+	PostAuctionItemItemIDPublish(ctx context.Context, request PostAuctionItemItemIDPublishRequestObject) (PostAuctionItemItemIDPublishResponseObject, error)
+	// Relist an unsold auction item
+	// (POST /auction/item/{itemID}/relist)
+	PostAuctionItemItemIDRelist(ctx context.Context, request PostAuctionItemItemIDRelistRequestObject) (PostAuctionItemItemIDRelistResponseObject, error)
 	// List auction items
 	// (GET /auction/items)
 	GetAuctionItems(ctx context.Context, request GetAuctionItemsRequestObject) (GetAuctionItemsResponseObject, error)
+	// Export auction items
+	// (GET /auction/items/export)
+	GetAuctionItemsExport(ctx context.Context, request GetAuctionItemsExportRequestObject) (GetAuctionItemsExportResponseObject, error)
+	// Import auction items in bulk
+	// (POST /auction/items/import)
+	PostAuctionItemsImport(ctx context.Context, request PostAuctionItemsImportRequestObject) (PostAuctionItemsImportResponseObject, error)
 	// Revoke authentication token
 	// (GET /auth/logout)
 	GetAuthLogout(ctx context.Context, request GetAuthLogoutRequestObject) (GetAuthLogoutResponseObject, error)
@@ -1645,24 +4352,370 @@ type StrictServerInterface interface {
 	// Upload an image
 	// (POST /image)
 	PostImage(ctx context.Context, request PostImageRequestObject) (PostImageResponseObject, error)
+	// List auction items of current user
+	// (GET /user/auction/items)
+	GetUserAuctionItems(ctx context.Context, request GetUserAuctionItemsRequestObject) (GetUserAuctionItemsResponseObject, error)
 	// Get user information
 	// (GET /user/info)
 	GetUserInfo(ctx context.Context, request GetUserInfoRequestObject) (GetUserInfoResponseObject, error)
 	// Update user information
 	// (PATCH /user/info)
 	PatchUserInfo(ctx context.Context, request PatchUserInfoRequestObject) (PatchUserInfoResponseObject, error)
+	// List listing templates
+	// (GET /user/listing/templates)
+	GetUserListingTemplates(ctx context.Context, request GetUserListingTemplatesRequestObject) (GetUserListingTemplatesResponseObject, error)
+	// Save a listing template
+	// (POST /user/listing/templates)
+	PostUserListingTemplates(ctx context.Context, request PostUserListingTemplatesRequestObject) (PostUserListingTemplatesResponseObject, error)
+	// Delete a listing template
+	// (DELETE /user/listing/templates/{templateID})
+	DeleteUserListingTemplatesTemplateID(ctx context.Context, request DeleteUserListingTemplatesTemplateIDRequestObject) (DeleteUserListingTemplatesTemplateIDResponseObject, error)
+	// Create an auction item from a template
+	// (POST /user/listing/templates/{templateID}/item)
+	PostUserListingTemplatesTemplateIDItem(ctx context.Context, request PostUserListingTemplatesTemplateIDItemRequestObject) (PostUserListingTemplatesTemplateIDItemResponseObject, error)
+}
+
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// PostAdminAuctionBidBidIDRemove operation middleware
+func (sh *strictHandler) PostAdminAuctionBidBidIDRemove(ctx *gin.Context, bidID openapi_types.UUID, params PostAdminAuctionBidBidIDRemoveParams) {
+	var request PostAdminAuctionBidBidIDRemoveRequestObject
+
+	request.BidID = bidID
+	request.Params = params
+
+	var body PostAdminAuctionBidBidIDRemoveJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminAuctionBidBidIDRemove(ctx, request.(PostAdminAuctionBidBidIDRemoveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminAuctionBidBidIDRemove")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminAuctionBidBidIDRemoveResponseObject); ok {
+		if err := validResponse.VisitPostAdminAuctionBidBidIDRemoveResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminAuctionItemItemIDCancel operation middleware
+func (sh *strictHandler) PostAdminAuctionItemItemIDCancel(ctx *gin.Context, itemID openapi_types.UUID, params PostAdminAuctionItemItemIDCancelParams) {
+	var request PostAdminAuctionItemItemIDCancelRequestObject
+
+	request.ItemID = itemID
+	request.Params = params
+
+	var body PostAdminAuctionItemItemIDCancelJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminAuctionItemItemIDCancel(ctx, request.(PostAdminAuctionItemItemIDCancelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminAuctionItemItemIDCancel")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminAuctionItemItemIDCancelResponseObject); ok {
+		if err := validResponse.VisitPostAdminAuctionItemItemIDCancelResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminDeadLetters operation middleware
+func (sh *strictHandler) GetAdminDeadLetters(ctx *gin.Context, params GetAdminDeadLettersParams) {
+	var request GetAdminDeadLettersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminDeadLetters(ctx, request.(GetAdminDeadLettersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminDeadLetters")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAdminDeadLettersResponseObject); ok {
+		if err := validResponse.VisitGetAdminDeadLettersResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminDeadLettersDiscard operation middleware
+func (sh *strictHandler) PostAdminDeadLettersDiscard(ctx *gin.Context, params PostAdminDeadLettersDiscardParams) {
+	var request PostAdminDeadLettersDiscardRequestObject
+
+	request.Params = params
+
+	var body PostAdminDeadLettersDiscardJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminDeadLettersDiscard(ctx, request.(PostAdminDeadLettersDiscardRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminDeadLettersDiscard")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminDeadLettersDiscardResponseObject); ok {
+		if err := validResponse.VisitPostAdminDeadLettersDiscardResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminDeadLettersReplay operation middleware
+func (sh *strictHandler) PostAdminDeadLettersReplay(ctx *gin.Context, params PostAdminDeadLettersReplayParams) {
+	var request PostAdminDeadLettersReplayRequestObject
+
+	request.Params = params
+
+	var body PostAdminDeadLettersReplayJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminDeadLettersReplay(ctx, request.(PostAdminDeadLettersReplayRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminDeadLettersReplay")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminDeadLettersReplayResponseObject); ok {
+		if err := validResponse.VisitPostAdminDeadLettersReplayResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminShillFindings operation middleware
+func (sh *strictHandler) GetAdminShillFindings(ctx *gin.Context, params GetAdminShillFindingsParams) {
+	var request GetAdminShillFindingsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminShillFindings(ctx, request.(GetAdminShillFindingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminShillFindings")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAdminShillFindingsResponseObject); ok {
+		if err := validResponse.VisitGetAdminShillFindingsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminShillFindingsFindingIDResolve operation middleware
+func (sh *strictHandler) PostAdminShillFindingsFindingIDResolve(ctx *gin.Context, findingID openapi_types.UUID, params PostAdminShillFindingsFindingIDResolveParams) {
+	var request PostAdminShillFindingsFindingIDResolveRequestObject
+
+	request.FindingID = findingID
+	request.Params = params
+
+	var body PostAdminShillFindingsFindingIDResolveJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminShillFindingsFindingIDResolve(ctx, request.(PostAdminShillFindingsFindingIDResolveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminShillFindingsFindingIDResolve")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminShillFindingsFindingIDResolveResponseObject); ok {
+		if err := validResponse.VisitPostAdminShillFindingsFindingIDResolveResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminUserUserIDSuspend operation middleware
+func (sh *strictHandler) PostAdminUserUserIDSuspend(ctx *gin.Context, userID openapi_types.UUID, params PostAdminUserUserIDSuspendParams) {
+	var request PostAdminUserUserIDSuspendRequestObject
+
+	request.UserID = userID
+	request.Params = params
+
+	var body PostAdminUserUserIDSuspendJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminUserUserIDSuspend(ctx, request.(PostAdminUserUserIDSuspendRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminUserUserIDSuspend")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminUserUserIDSuspendResponseObject); ok {
+		if err := validResponse.VisitPostAdminUserUserIDSuspendResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminUserUserIDUnsuspend operation middleware
+func (sh *strictHandler) PostAdminUserUserIDUnsuspend(ctx *gin.Context, userID openapi_types.UUID, params PostAdminUserUserIDUnsuspendParams) {
+	var request PostAdminUserUserIDUnsuspendRequestObject
+
+	request.UserID = userID
+	request.Params = params
+
+	var body PostAdminUserUserIDUnsuspendJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminUserUserIDUnsuspend(ctx, request.(PostAdminUserUserIDUnsuspendRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminUserUserIDUnsuspend")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminUserUserIDUnsuspendResponseObject); ok {
+		if err := validResponse.VisitPostAdminUserUserIDUnsuspendResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
-type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+// GetAuctionBidsExport operation middleware
+func (sh *strictHandler) GetAuctionBidsExport(ctx *gin.Context, params GetAuctionBidsExportParams) {
+	var request GetAuctionBidsExportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAuctionBidsExport(ctx, request.(GetAuctionBidsExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAuctionBidsExport")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
-}
+	response, err := handler(ctx, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAuctionBidsExportResponseObject); ok {
+		if err := validResponse.VisitGetAuctionBidsExportResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
 // PostAuctionItem operation middleware
@@ -1701,10 +4754,11 @@ func (sh *strictHandler) PostAuctionItem(ctx *gin.Context, params PostAuctionIte
 }
 
 // GetAuctionItemItemID operation middleware
-func (sh *strictHandler) GetAuctionItemItemID(ctx *gin.Context, itemID openapi_types.UUID) {
+func (sh *strictHandler) GetAuctionItemItemID(ctx *gin.Context, itemID openapi_types.UUID, params GetAuctionItemItemIDParams) {
 	var request GetAuctionItemItemIDRequestObject
 
 	request.ItemID = itemID
+	request.Params = params
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetAuctionItemItemID(ctx, request.(GetAuctionItemItemIDRequestObject))
@@ -1727,6 +4781,42 @@ func (sh *strictHandler) GetAuctionItemItemID(ctx *gin.Context, itemID openapi_t
 	}
 }
 
+// PatchAuctionItemItemID operation middleware
+func (sh *strictHandler) PatchAuctionItemItemID(ctx *gin.Context, itemID openapi_types.UUID, params PatchAuctionItemItemIDParams) {
+	var request PatchAuctionItemItemIDRequestObject
+
+	request.ItemID = itemID
+	request.Params = params
+
+	var body PatchAuctionItemItemIDJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchAuctionItemItemID(ctx, request.(PatchAuctionItemItemIDRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchAuctionItemItemID")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PatchAuctionItemItemIDResponseObject); ok {
+		if err := validResponse.VisitPatchAuctionItemItemIDResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // PostAuctionItemItemIDBids operation middleware
 func (sh *strictHandler) PostAuctionItemItemIDBids(ctx *gin.Context, itemID openapi_types.UUID, params PostAuctionItemItemIDBidsParams) {
 	var request PostAuctionItemItemIDBidsRequestObject
@@ -1764,10 +4854,11 @@ func (sh *strictHandler) PostAuctionItemItemIDBids(ctx *gin.Context, itemID open
 }
 
 // GetAuctionItemItemIDEvents operation middleware
-func (sh *strictHandler) GetAuctionItemItemIDEvents(ctx *gin.Context, itemID openapi_types.UUID) {
+func (sh *strictHandler) GetAuctionItemItemIDEvents(ctx *gin.Context, itemID openapi_types.UUID, params GetAuctionItemItemIDEventsParams) {
 	var request GetAuctionItemItemIDEventsRequestObject
 
 	request.ItemID = itemID
+	request.Params = params
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetAuctionItemItemIDEvents(ctx, request.(GetAuctionItemItemIDEventsRequestObject))
@@ -1790,6 +4881,70 @@ func (sh *strictHandler) GetAuctionItemItemIDEvents(ctx *gin.Context, itemID ope
 	}
 }
 
+// PostAuctionItemItemIDPublish operation middleware
+func (sh *strictHandler) PostAuctionItemItemIDPublish(ctx *gin.Context, itemID openapi_types.UUID, params PostAuctionItemItemIDPublishParams) {
+	var request PostAuctionItemItemIDPublishRequestObject
+
+	request.ItemID = itemID
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuctionItemItemIDPublish(ctx, request.(PostAuctionItemItemIDPublishRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuctionItemItemIDPublish")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAuctionItemItemIDPublishResponseObject); ok {
+		if err := validResponse.VisitPostAuctionItemItemIDPublishResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuctionItemItemIDRelist operation middleware
+func (sh *strictHandler) PostAuctionItemItemIDRelist(ctx *gin.Context, itemID openapi_types.UUID, params PostAuctionItemItemIDRelistParams) {
+	var request PostAuctionItemItemIDRelistRequestObject
+
+	request.ItemID = itemID
+	request.Params = params
+
+	var body PostAuctionItemItemIDRelistJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuctionItemItemIDRelist(ctx, request.(PostAuctionItemItemIDRelistRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuctionItemItemIDRelist")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAuctionItemItemIDRelistResponseObject); ok {
+		if err := validResponse.VisitPostAuctionItemItemIDRelistResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetAuctionItems operation middleware
 func (sh *strictHandler) GetAuctionItems(ctx *gin.Context, params GetAuctionItemsParams) {
 	var request GetAuctionItemsRequestObject
@@ -1817,6 +4972,62 @@ func (sh *strictHandler) GetAuctionItems(ctx *gin.Context, params GetAuctionItem
 	}
 }
 
+// GetAuctionItemsExport operation middleware
+func (sh *strictHandler) GetAuctionItemsExport(ctx *gin.Context, params GetAuctionItemsExportParams) {
+	var request GetAuctionItemsExportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAuctionItemsExport(ctx, request.(GetAuctionItemsExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAuctionItemsExport")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAuctionItemsExportResponseObject); ok {
+		if err := validResponse.VisitGetAuctionItemsExportResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuctionItemsImport operation middleware
+func (sh *strictHandler) PostAuctionItemsImport(ctx *gin.Context, params PostAuctionItemsImportParams) {
+	var request PostAuctionItemsImportRequestObject
+
+	request.Params = params
+
+	request.Body = ctx.Request.Body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuctionItemsImport(ctx, request.(PostAuctionItemsImportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuctionItemsImport")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAuctionItemsImportResponseObject); ok {
+		if err := validResponse.VisitPostAuctionItemsImportResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetAuthLogout operation middleware
 func (sh *strictHandler) GetAuthLogout(ctx *gin.Context, params GetAuthLogoutParams) {
 	var request GetAuthLogoutRequestObject
@@ -2001,6 +5212,33 @@ func (sh *strictHandler) PostImage(ctx *gin.Context, params PostImageParams) {
 	}
 }
 
+// GetUserAuctionItems operation middleware
+func (sh *strictHandler) GetUserAuctionItems(ctx *gin.Context, params GetUserAuctionItemsParams) {
+	var request GetUserAuctionItemsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserAuctionItems(ctx, request.(GetUserAuctionItemsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserAuctionItems")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUserAuctionItemsResponseObject); ok {
+		if err := validResponse.VisitGetUserAuctionItemsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetUserInfo operation middleware
 func (sh *strictHandler) GetUserInfo(ctx *gin.Context, params GetUserInfoParams) {
 	var request GetUserInfoRequestObject
@@ -2063,54 +5301,243 @@ func (sh *strictHandler) PatchUserInfo(ctx *gin.Context, params PatchUserInfoPar
 	}
 }
 
+// GetUserListingTemplates operation middleware
+func (sh *strictHandler) GetUserListingTemplates(ctx *gin.Context, params GetUserListingTemplatesParams) {
+	var request GetUserListingTemplatesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserListingTemplates(ctx, request.(GetUserListingTemplatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserListingTemplates")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUserListingTemplatesResponseObject); ok {
+		if err := validResponse.VisitGetUserListingTemplatesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostUserListingTemplates operation middleware
+func (sh *strictHandler) PostUserListingTemplates(ctx *gin.Context, params PostUserListingTemplatesParams) {
+	var request PostUserListingTemplatesRequestObject
+
+	request.Params = params
+
+	var body PostUserListingTemplatesJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUserListingTemplates(ctx, request.(PostUserListingTemplatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUserListingTemplates")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostUserListingTemplatesResponseObject); ok {
+		if err := validResponse.VisitPostUserListingTemplatesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteUserListingTemplatesTemplateID operation middleware
+func (sh *strictHandler) DeleteUserListingTemplatesTemplateID(ctx *gin.Context, templateID openapi_types.UUID, params DeleteUserListingTemplatesTemplateIDParams) {
+	var request DeleteUserListingTemplatesTemplateIDRequestObject
+
+	request.TemplateID = templateID
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUserListingTemplatesTemplateID(ctx, request.(DeleteUserListingTemplatesTemplateIDRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUserListingTemplatesTemplateID")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteUserListingTemplatesTemplateIDResponseObject); ok {
+		if err := validResponse.VisitDeleteUserListingTemplatesTemplateIDResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostUserListingTemplatesTemplateIDItem operation middleware
+func (sh *strictHandler) PostUserListingTemplatesTemplateIDItem(ctx *gin.Context, templateID openapi_types.UUID, params PostUserListingTemplatesTemplateIDItemParams) {
+	var request PostUserListingTemplatesTemplateIDItemRequestObject
+
+	request.TemplateID = templateID
+	request.Params = params
+
+	var body PostUserListingTemplatesTemplateIDItemJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUserListingTemplatesTemplateIDItem(ctx, request.(PostUserListingTemplatesTemplateIDItemRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUserListingTemplatesTemplateIDItem")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostUserListingTemplatesTemplateIDItemResponseObject); ok {
+		if err := validResponse.VisitPostUserListingTemplatesTemplateIDItemResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xce2/bOBL/KoTu/rgD1Nhpg97VRf9I2iCbRbsN6gRXoCgOtDS2uZFILUm59mbz3Q9D",
-	"6i3Klh9t0l6DprUlPmY4M795kOydF4g4ERy4Vt7ozlPBHGJqPp4m7AOoRHAF+DWRIgGpGZiXgQjN06mQ",
-	"MdXeyGNcP3vq+Z5eJWC/wgykd+97MShFZ6Z19lJpyfjMu78vmovJ7xBobH3GwvMFcN2ecsLC2oxp95Sa",
-	"xXXqQqrhiXnqN4nwvVSBdFMn4Y+USQi90SfbyjdUZBN8dpA/Hr+/kmLBQjsk8DTGzpdcg+Q08nzvQohZ",
-	"hHRcMP1LOvF87x0LpFBiqisjltRVRnwtOIdAjzXVqWovUDZgyclEiAgox1GyWZ3vCuKcb0vqHK8ba7Q1",
-	"m/nC4UCMTwXOEYIKJEs0E9wbeadXl2QqJIkppzPGZ4TykCRUahawhGp8wjihnNA0wC5ErZSG+MgISSPL",
-	"3mn25vTq0vO9BUhlhz4+Gh4NkUWRAKcJ80bes6Ph0TPP9xKq52ZNB9mwA6YhNksulG5T+VoC1UAo4fCl",
-	"oIRldKCMKD65DL2RdyWUzii6xDFxMklj0CCVN/rUHJkGAShFtLgFbhYiSKUErgnqI47OsFUgxC3D1eYU",
-	"NT/rdY2dPD8zaqOPSxonZlGWy+XR0vzYf9qGcf/ZCheUPhPhylo915lt0iSJWGDYGvyukNS7yjwNsKBS",
-	"pAoi8wVXRdUNWTKXWWYPqJR0hd9r63LXbg88vN7K7pWmUu/QhfHZlWRBC/+en3SAkY5gM77YZiUbbiMp",
-	"e2iZgnlgIdos6dPhcVs3UctIYBQ0JCo1mjFNo2iF2jMHGhrFu/PeCivO9gjXcyBR9paIKdFzKAbMlbyU",
-	"/Xq5Gi5OhsOt1OnvEqbeyPvboHRWg8xTDapuygze4J4vaMRCElJNSWKBNDzyDBGOxbrhNNVzIdmfEBJr",
-	"RUdGVCqNYypXCCdh6DB05JTO0IJzvPE+Y78agAzu8O/LN/c48QwcQPIBtGSwABKCpixSuNyUqAQCNmXB",
-	"Bmi5gCqymN83bXwxgIEAV8IFy5vW1cst1dR6QBdY1FRxuAdiTFj4AQIhwzpkrNODInBwQMdjBqBtoGQH",
-	"xNoSfao81gj0qzLxvcwLnRldKMkqF6G66G4oqyv+uAAmIq0N0AiVH6WVG0NmticdGMeFJlOR8rBpsReg",
-	"a5aTj7edzQ4mzKqjOwQYp5OYaULJhIXGUfc220ZEYO32jJl1/ia26/+IQUfvdKFhCdhvN987bCvFGQtJ",
-	"EtGg5Xl3cIJ19rbJqtrWhnRpIUgkvmzrC7HxswcjPI/l0dYN7EBIVqC3QAbfOzkePjj9c6oIcBuKIFZB",
-	"kEqmV8bIJ0AlyNNUz73Rp89oESWUXaEyZSAjannP9kHIABZ52u+MRcZaAo1xqhDTLNt6HbSRVGHD8fi8",
-	"X2xybud/2Ail0w0FNttG5rRApogyC/LTBr6SDRRKfi1pcFvXLMhVZbN+q83BdXVoRb4wPSfCNKIRmbII",
-	"FdHUGZQw2d4mbVabkvgxUBnMiQYZG/uxc5jaBXYvPOofKchVqfR5UFaKYaPnHmcJKkkwaiOS8hlsM2Ut",
-	"4usS/1SKuEJNNdcVrueuOp/SKxMShADJ+/xpq6yShR2IdluzUg9RH5oVzKGLEhUuMsGIfUcBZRH2JqZ6",
-	"Zgiib9uDMA883JH1MrX4bhgfC6lJIBmyRztFKqRew9QtrCyUTWka6Qos5MXlAiaqtrsxQXPVmoXM6tbl",
-	"ZFQFlansN+TR0X8v9Yio0hbqL9/kNaZEwoKJVJGEzqBr9bBjUerYI+dBImK6ZHEaE57GE5B5+omJECal",
-	"qeSdImR/1vWyWMBjv1cK0qTmfBlEaQjWP24wC9v0HFu6aZjSSIHfLt4ftmYTiJRrN5YWbrn40OhaKmvP",
-	"HZ6tay3NoZ1a4XtM2XV0boV8vfJLtqlk7bhHbSUn07UFVS9bNSayQsolsm9hRu2UyO5RzX3HlEkxymir",
-	"O+r9TWTW66wJvWWqXhRaF1zq+SASM5HqNaHlQtwCqRZOOqJGPX9rh3q8Gz8tNMLB+9OAL83HTgLW1X42",
-	"ZWiGNyLNeq/dz7jhCvST14a4vyrr8tcvWifvebR6OcasG1zCjMWiLkyCoQUxaTqQudYJETxaEcv6UQen",
-	"lUlfvSTFtMTO+5KcLxMmQb26nqc+GR6TXyknxy/+NSTD4cj8IRfvrr2KA/n1P9fO4KTGar78vfnMO9R4",
-	"XM9Z3mUvtto7QzULzU0q1XPgOoMTK42aqVZfVy1WKTG4y3Z85P0goFE0ocFtdwX3fBnMTUyal73sjIEI",
-	"ASOAKeNMzZv0TCPxxeSKEkImIdDYVEg2Y7wIW1zlXj0fK1Fs7Oe0bcCEOrP5dlZpifWaSf56bdVkHRhX",
-	"DzM4c00hIWwuiNJUQyc2ZLXdMTbq0C2l/7ssfvqgk5sOLniwkY7fsFEHHXw3Mgo9SGW0afoPWdsbGXUQ",
-	"gVCjRoNB9uQoEPFX3anPjvW4tqp0jzjG9M9bH6yK3lD7NZg/7oX4L9/R5ZPTGbw6Hv7bOV8Y1sGfcS22",
-	"Bf+SFFL1A5nfxV8XduaUPXs+HPZB/rED93twV0B+lbOekD+hCp6f/OPjx48f/9lJ+AYfVTW//v7YYeA7",
-	"+eWKaMwgB/Vibk4rlt6b3yqS7MunschvwKcB9l0laoh8fJxWTqz0OFuC/n8Bkk1X3alJhx/v3sFeE5rs",
-	"EgtFjN9aqiLQ4Nr5wxZkPH6P2IUpo5UKLJky1eXsaTu4eWNGbIQ3b3G67yq0OX3gDfCN21QVyaRGWO4d",
-	"5q12dXdUVOz6wkHiXKQRhkMxZZxQTSKgShPBoaZXlvamvm+jgJ3a73fE+W+bQ2vRHphcavJFyFsSsVsg",
-	"efJAJqk2+0Ui1Rkk1YL/GxmRXHq9Iv/vzzR+Rv2PJep/dKj142YgbcTqXXn6Ger+DHX/X0LdbxFuNKr4",
-	"PVz5ThGymDHeWe1/P9EmqKmTi7pbDQY6NwCq/t/M810FABjjaEFMOESnGmRWGTVLXk1PujYsZc3/9TnQ",
-	"5bxIsMVuAVMqPeTlB6Mdprxrzuc3TBvkwgqnNyu1Os46h1FWrp521a1cnmPP+pX1HNtVrvqwuMZTbGa0",
-	"5jL2ZNACqSPqOhCjTlextSytzzgIq/vJ8v5g+N0JpJswm8XZ+UN3ineTRIKGhHJiGpIpizrysUsz0A9w",
-	"CU8EGvQTe0S1HmAX4DNhnBogbgl0t4tlZmlTs9SHRNdiRCO7H/5yme+dPH3h8lyCxJSvSCb+1j20ho5X",
-	"LMbqtDUU1NBBfrV2/alYbEqwKS5x5r9b8cuNAnmJwz2QyZyJyVe/fabK+ExtESzVr2dnF8stY30ul+dH",
-	"JqqT73NApy3OPS49XoBuDVjROHM53lTcqA7mLjwO0Xdt1rAr7P+IdewQJY4d1OJghYybhgRIaiTTfT3p",
-	"a9+m7dCMtm6Z46X2UevgBg8TwfLLKeX/FFA93eXn91h8k6NpSYPbaiN7weCoVJP8DJjjbGZtOkN5I4DB",
-	"GVqpUDFuLZzZNHzBDc5Tpc8sS+/exkVUu1sfcf/5/n8BAAD//5mFMc4CRAAA",
+	"H4sIAAAAAAAC/+x9aXMbOZL2X0HUOx/ejS1JlI8+1NEf5KN7NGu3HaK82xHT3jFYlSQxqgI4AEoSx63/",
+	"vpE46kSRReqwZLNjplskq4DEkU8m8sLnKBH5QnDgWkVHnyOVzCGn5s/jBTsFtRBcAX5cSLEAqRmYHxOR",
+	"mm+nQuZUR0cR4/rpkyiO9HIB9iPMQEbXcZSDUnRmnnY/Ki0Zn0XX1+XjYvJPSDQ+fVwkmgl+oiE/yRdC",
+	"6lPAfwcokEA1pLVma31OKcv6fpOgisyOlmnIzR9/kTCNjqL/d1DNxoGbioMARdhAVFFPpaRL81lomoV6",
+	"Nd3+q2ASifq7ey4ux1ASXFH3cdjcGEo6c8PM0FNQiWQLfCU6ik5eETEleg7E9Upw9DERPFuShQQFXJPL",
+	"OXDzjBSXhCnCTDeQ7kdxtdRFwdIobi9mY6GbXZ8CVYKTy/my3rYEHBekq0jAaVH7oc6kuAx0JC4JL/IJ",
+	"SMJsI8UiEzSFlExZBjFRmkrN+IxMpcjJIaE8JXCVZEWKX+ILL8f/TeZAU5D7gd3cWkckYsVCjTXVhVkQ",
+	"4EWOL6SSTnUUGzZLC7viGbuAKI6Ap+ajAq3tDwnlCWT498fABLxg6esL4IHVn9jlr9arnzc1y5tsnFIN",
+	"e+bbQJeFAhlm4/qkmKdiQ4XrIDRFr4Cmb0BrkMeJXb3uJm7yaIecNvctcG19WyG+p6rxWw/9VTOxoaF8",
+	"c8gw+jgyZ0phZ50t+5pruSQnrxTRc6oJFyQTfAaSwBVT2m/jFGi6l5l+iNISaI67c4OpkSIBpSBdS8Al",
+	"SCASFhldQkqEJClTCZUppJv02J7Ssvu4nInVs/kiBGFnZiZQ9KRkwlLCuN23THDENmqmidhpQmqbS0Bz",
+	"UVh2GcIZDiSP9XD2wMk5edXsoAcqkUc2eJTTHNZvXNd/2Xrt3diPvj6w1Stg9kUvuKwSmM1VvI4jkFLI",
+	"8HLKpmjAVbWikGhB1JIncyk4+zfEBPKFXlbSAcy2vaSK5OIC9wMktFBAmCYJ5VxoMik3S1CAzKQoFmGi",
+	"EsFVkYMk5hnLFxVVbjN7evfJqeeWCUsVoRL7RVCX9nk9Z8q1hIJunxxPjKCbCmlGwaAaw1RIMA3b53F4",
+	"EhKB7BeTyzlL5oTW+VMLAhcgl/b54Dj7GAkc36+GGLii+SLDFg+/P3zy9Nnz777/4cfR4d4o1NWCSgWv",
+	"By+2paCzWnGpChi9wKy44XZFqJm5/Z6++8G/zShpVH/Bb9AQP/zCMvjFsWglxhN1EcURT//ZFAsVNW+Y",
+	"QjVj7MR8l42sIoB/wJQaiTGlmYK4NWtjemH3A/I2oQoxDt/0m4EpO0kXTLFJBna7AVGoNkhScM0ysigm",
+	"GVPzBhtMhMiAcsOcPD3bSAswOtQmr7Rm33cYmm43b2eQLzKqA/NGCy1OIWNKn4qCp6pBA+P6u2dhNKdS",
+	"FAqypkZRYa9koZG2pegWMqGxnJ/7uHOtEOgRAOa4APIC3kuWwMC58DrwJu9oprMhEiiNHK3+leYMtDtv",
+	"0V9fp7i71OtkV2vznMK/ClD6ge2hdRvikax0Y5FDi/FWpCCpVYl71mGoQr5C/R6P372X4oKlIOv4fMI1",
+	"SG6O2L8KMTP78Fem/1pMojh6yxIplJjqIHLXWnwpOIdEV6e4JvmuwaPPAVB1vQZ/K4kL/lpRF/i5NTEb",
+	"D7M2cXOWZb8wnrpDSdC2shnQacqym2DcBuqzBGTw9IPRc/v4sefdNkNKuGBw+ZvQfWyHP282F+6doRq+",
+	"dBrCKp3arNcpPmj52u3Ita+4JXZ7+M5OHmnkhhGvPoW0Fq7cNuWY1mF8fVCnoERWhI0Hvdhyk9lrjbok",
+	"eRVAdZup4dQCzA84asGnTObmhJwylTNzWg4CVLkTag1xuPwHTRJ3xKOZwQWE/n9MWJqCVMbOxM8h9Y+p",
+	"YOO4MKei2baz59A0ZyFlF2cFD+Jdlf/4/Yk55OSU0xna19DaZtTuhC0MdXj2oJxQay4jaqk05PulXDny",
+	"hjRy/P4kiqMLkMo2fbg/2h8hwWIBnC5YdBQ93R/tPzV6vZ6bST4wFB+4xg8mLD34PGHpyavrAwl44DK7",
+	"RigdMlji74Sac4c9gMXWdFpICVyb75011RN/ybKMTGmWkQlNzr0mPmezOShNJOSUcRwyHhkjQ7gVjydp",
+	"dBS9F0ofI7luwC9Y+gJJtYSYUUmag8aVPPp752xF5Qy0b5nhVzgLXhc7isywo/ru1bKA2Fn7B4DBddzu",
+	"kyb2ECzOgZtV9lOD26UkIxHinEFFiH3rDF+K6t1Xx8yrq6v9q6ur8j8BWj7agYDSL0S6tN4Irp0plC4W",
+	"GUvMzB7800FA1c8qju9qLNfX1+05M19Yp4jZZE9Go+72eefXlmA3GWhIiSrM0KdFli33cec+G41ujfS6",
+	"q8YQ3TL98wuasZSkVFOysNpN6og47JL/gdNCz4Vk/4aU2BVzDz+9L4oRh/Bwy4U2AIGswZSWVAtJhPlJ",
+	"FWphbOaOtmfdgbxgqWlhimq+e+zH8GNMEZpJoOmSWHDA51FYFHlO5bKFCVEcaTpDVoyOLSzisy3AQUF4",
+	"8NmKw+sDa8zvh5yX5vc6Glr3DNMWWSaIJWkK3LouMnvWMZBa2YxxsRYGCNR6jDFuJEOd7XsgytTJ64Gb",
+	"UgXY4c0Ob74hvEFuGgI4XqupgU7p68PenAuwjUC/CJnAXtIGipVgVLPqmv0zg6C6oyWDC7AG7Ja5u2aE",
+	"t9ATNhZbB0xpVI2JkKmxgJt3OFyC0ticyFJQuotOv4IFp8p5oNYCknMYOALKvks0+FcBclmBQd3iW+2a",
+	"0gA7Crl7Q31mVOnKfO7UwAUe+kShyILOoI8CfNP4VU5eNWhYi37YbU6vWF7k3sEtpqX3QAsiQReyd+SK",
+	"/RvCg34yige4wx4eHHchcTBMtANZnFOwawpz0zs4UKTtO1vlolZhD0m1tqGNrQaEJPgzYK2naiTdE2oX",
+	"Csel4CDS4gLNkKCab1XtBMotC5QGzqMduzHdgxH+wDnr+9XMV4DqQaN1sgCZUxxdtuzicqk11oD5letl",
+	"DT5/fSpbJ2hluMZ2J/37SLTu/tuphF+Sgx2DbMnE1rvfz8Nv8Rza4OC6xakmOJxqomgOlRAhyjy3NIEE",
+	"NeUuJXRGGR+GADbkYQcAOwDYAUAIACx/DOd/hUb9g6n1Eww4qGHHLDHnDTTwoyVoQbEbrogEG7NLJjbM",
+	"xrRNUtCQaCG3PpjVPRlrj2a/sEyDJH5ASIp1ihHrNOk9qHiXyrAVDDtpes9sjpxtTm2uk9a5bWMrVvgc",
+	"V07T7iB3Jwe5Ol8NOsnV99Xa4FZ/4ip7uckpyzeyg+c7P2FZXPTwOa2QbShMH3yeelRAl6IS2Sqf4lsq",
+	"z9FCBrzeH6GKlL5fF25tnb8rFLEGFJfIdOooGGbGd/33WPCnNbjbGfEHIkUtHmFnyX/clny3okOM+f7R",
+	"hgfRcGJAKzTfExrGnpXQgxxy8NnG1FwfuCH0o83YPkCoYS175qPapkThFwnlNb/hAiTyMqF8SXAFgWtc",
+	"HEQkvyFXwBFOuY3ocb0OhKAG0zfxp4wd2oHPzoP4DeGOaWUA6PjePOL0ajpNHNgAYQq+FmPesKlFFPuk",
+	"KtOwPEOtxYsPXO0QY4cYO8S4P8TAR3vRouTHNXhRhVSqA7jy+flBk9Vr87OxSs+Z0kIu8ciDWdZCkt9e",
+	"/W387rcYk7M1yMpkpVkO3kSDUY1/8LHJaFJGb7E9+h8VESaBjUkiLm1YkopNXJSZ0cYr1Cpdav8PHrRz",
+	"lcGXypK91sxlEMVTajtxueZ9FhwHQqtwatWequWjBQDrHeaCubHasFUzO0ISOtUgbSoiTm4vdVLkYQvX",
+	"yvyuIXSUyY1rSNDijghoxes24tdaNJQBbDcQHitIsOl5MeGC75lNapjN7lSTzqcWkLCp4YVcQXYB/QZT",
+	"09RNaX14RsBAvmqduww4GicXwrcinzRc6YNEXXzCzf6pjtlXezZP89P+H9wmodresAktC27POSbLFIFC",
+	"SkTfJCmkckxj+8Fz2pwqW0ECEJK+kICrAOlxiblS6MQGj1qb3215BopQLvQcvzFbuy2iuvKkLqQsb7fE",
+	"FHLzisBbk2+CBMFlBxwCGmwVPPt1uj/X50o2R/lb6cZAcFZVtrIp8YJv4tYrtMipZgnNfEo30wR4qkjB",
+	"lciaBWa+dA7mI07ObmeJtmzQjBvX05RxmtliC0JWQ9ACw8xxOWIyIjlQrggXxLU5cIk2Tg+/0zxV+1i8",
+	"Iul8yPnnsCfW11dSah584sgWEDIvvxGW08JCLXO/hkoz7ffI9NAev77+MsLo8Z22es8+x2kaEAKDhEuZ",
+	"5bHecW/zHZW1kziZl7RSPhyMhPCDaeXxw+DNwnrV91ecZqo0j668+kZyN27Nxzxh6alV5Qe7kssyXaF6",
+	"EvcizbYr8LFxnYGN+xiQhNuso7Y56gdqT/hKE7WljCO3H1+w1D92Zun2c9esTOGIv4mf38hbBwYbJJQ0",
+	"EOvXVjaWby+IWcj7OpkHsHmRWv3Xgo6QpCxO12zdHeC908pMUyjNDLvZYc9OCb9v2NqpvlvptqMe2CkW",
+	"aUC33amZw436tcOemFZb7RbSCe9l7rH3ZpjABAikTAf8Bk6G8BvozsabsCqgYZIzTWjJuj3q81qrjZVH",
+	"aOP/FmVSh5aXGcOeZ8BxziAl57A0NKWQFm5r8ZlTXmyJQzyh2BwFF61q5Y2QbGbAVRQ6ETUDvz0NVwM5",
+	"SSFfCA08We79Fyx7BjM6/PH55Dv6bO/79BnsfZ8c0r0fp09h78nkh+QwHcGz6XMaxVFOr94An+l5dPTk",
+	"+fM4yhn3nw/vUCQPrrHb0kzxvY+3hdNYuGCR0eQ2ULo5vE1qdnehA+nSAmHj8u7B+jYJ94nZxklqDf1k",
+	"CTq2Juo6aiOK+y/DOL8Zrh+Ovvig0btRjg0/TAB4lZhu6Xzy5IvRWYMNA1IliYWynmMrGy6ZnpOUTadg",
+	"INXWvXXEB/ziZ0KQHOPefNGMmu0OrTbLvWP0AgUEEiQCVWctyCVl2h+PkB99LXEOV2VVnhDEPQ8a8lCy",
+	"QlJIppdGKE2ASpDHBULa3z9ef6wL3vfI/W7ggt9QAMOFvwUgaMMa26Qyf/6zT68SxaRQ+OB4/Hr/D/6a",
+	"JnP7DkmotGnrc6ilYtiCzq0UNhQ1iZVQEhJbjw/bNIv86Q1Ves9YV/ZOXn0iUyZNpaUE2AWoKj6AaWJD",
+	"qk1UgPYVhLFErxvF6niASmswfX05vaGarIxWI03tIOJqwE4mWPcl082SwW6fmtG7OsXLXlHdmOGeXXz4",
+	"/eHT54fPfhjhP6YwsEtFio6i//3jj/Q/9/Bff9nW91uzoPgNIDhy3Xj8uixU/DDlxlcoAhpq/5nEvNMG",
+	"y4PnkE2AxznB+pX/9/aBysFWHqYmgKqmqtmsrF/NGMpxJWwsETL+TICy294wxiWVvVWS2nzv+t8Z0G9q",
+	"QN/K7BsuuzjE+mrYrHSxPghThucWGwL1zVsyAs+5Ph2vtzGnBQU30XesYXSLyBCbOkt9nSb7nfX5W/Q0",
+	"6gkXLjxRol5W8Bx03Z44AHaszXdnOr+1Ld8uy79z/u+sso/QKutG4uAnrjQ19F80VDfv++lmouH3FpfM",
+	"SxvD6IAiBfU2lcVEYR6imYv4VkYxU8K4QNYFMawtOjAGKpM50SBzA1e2D5Obh6/3Bhw7Z/EG5djG/hqx",
+	"hWQJEEn5DDbpsuGJ7tOVUMiE89m16LnkpFN+WumlgdwUYPHOf9uRc7WixhsPpek6/9JDOasFeNfU/+0W",
+	"yHn+1w1qmKNPi6HP3srggadbDr0KeXg0Ax8LqUkiGQ6P9i6pzefoG9Q5LBsRn7XIQVsG3X9u8O7awJFQ",
+	"kXVTgKXZGVVJrSv7CccYfby9WSrLoBh5t0UNlJNbyIoIF0CxEuIG1U8Otyt+8tpc/QhOa1/NFvbR1/6W",
+	"xi4NLka4c2fHvZU5KcVy+Ufr1WqzDrwEb+MYjKFXfSg7j8EbUB5cNJkdg2P/AaFifnQrAsUGlpSxC3nT",
+	"OLN7Lybz1l7xWEtV6VenfxOO+4MhbqZQS0OPHKacDsxKbLQ8IC+xlKrNXLLe/MShOYl2ldY6IXZZibus",
+	"xF1W4i4rcZeV+ECzEreRVPaK9fVW6IaocgbohrhyV5wjK1tWM+NzAyhF2Cf72ydiWLZat31iPPTubvac",
+	"Lhb+9logE5EuESU+vX83PiMN+j8ZqWb2gN2xaOUpi87KIgOUa0gnUqdIXijnIrWPUnfZOnYcu4CB8vUp",
+	"gywlyNdGOOP3ZqBIjxWnCnAAxhTjo5LJh9M3ztr06U9kr5eNHwwBEyAspzNQ1f3wbnrqEEP0XIpiNvfj",
+	"Nq9giyecUC1ylpBcpICIqeeuBJS34TrOXfoJZZZPfiJmF10yBXbS8Hd7Z7B/k3GSzAt+bm1jjLce89U9",
+	"Q9pCy4mgTvLN1YXGhflfRF3wGz7L7KgZih8gWlKuaOIvlyDqnC0a09NHrF2q8JnNUt29ZvExukJEokHv",
+	"WbnQxMlS8k4Yp3IZ6OReixjX/Vxmh56aPR0UL+Z3DNo3HtyYKLApt5YNyhh+aeog4x4Gh2Jfyr9r9IBH",
+	"mxG5eYTfLS30WORg2R1BzrM1Wwu0bUHsNkxTWjJOJkV2vkIg6/lBJmai0Cu8GhfiHEgdCHocFnr+xjb1",
+	"cMsDdPCNG3E7lIbahZ49BNxIrTYTIs18r/SufuAK9N5LQ9yftXn5869aL/Cg89MYkkJC//2OjRVAlcoE",
+	"XgKZa20v6yd26H0BnLVOf/6JlN0S2+9P5PXVgklQP5/Ni5iMDsnfKCeHP34/IqPRkfkf+fXtWT236G//",
+	"cxZaruZQ/fQPHqd/oTHG1SPzr9xoWOHY1poT0rJUVXDSRvbZrV2xav3nOscqJQ4+O6iVeMNeluFFCP2q",
+	"9OurZG7cIR57fQGz1CRXWynTpmeaiUujiklImYTEFks3uQ6lxTykhun5WInyKmlP2xpMaA7Wy5G+mnf+",
+	"560Vsfr12UE3p5A2b7BOlNJUQy82OF0Fjax9+KD0P67Kf4agU5gOLniylo7f8KEeOvh2ZJT7oJDZuu5P",
+	"3bMfZNZDBEKNOjo4cN/sJyIP0HJreSu413tvRR5gCzfv+6dvLZGlte1XYP54EOL/9JZe7R3P4OfD0Q/B",
+	"/tK0Cf6Ma7Ep+FekkBopPzu5i/8PYaen7Ol3o9EQ5B8HcH/A6PyzjZENhPwJVfDds///+++///4fvYSv",
+	"kVF19hsujwMMvpVcri2NaeRWpVh4pDVOHzzeOpLcdJyGI+9hnAbYt11RQ+TDG2ktfm5ApBvK/wuQbLp8",
+	"ICe20bO1iOpol/3FJFboRtsoY3jZvKUqAw2h8y8+Qcbjd8RdSG+3BVz5i43tt13tyt5g19Kv3jD+yHSr",
+	"4wfu3KivTGEWKxxA/6hsG9tySk+4+nguiix1d/sTqkkGVGljraxPn528bq3d4RzQy35xb2nuVtNadBsm",
+	"J5pcCnlOMnYOxB+fyKSwtnlRaAfKjePPB5mV3qpBZ5/Hx5u7c89DOfc8ONj8es9gXcQabHvbKfs7Zf9b",
+	"Ufa/CX2nFYM3QJfY6owgZoz3OlzeTbTRqprkIvPUtZFeH0xdATH9PCoNBJUsLElm8qun7ipNpozzq3FA",
+	"63N6y4YAHpIUKFmfzBvmsGFKFbeZDZcJb2HHb9rYglmUcqMkuYYpbZXEqoyHT/pMhyHRdUMTomlkQ+Ph",
+	"kCGuEFXrB9qQWTccoEXygNp3SwMNyqqN19IKrVsZ6s3W8vrW8LsXSNdhtol36vemfTCRQoRyG0tVBgx1",
+	"D4QnpqGvoFr+HYfYhDKNzdSWQVm3hq5li2btdtnG9xFYs6r6lNt/qltZscFkNZa1TGU51VzddqPsXB99",
+	"OFk2QyAZx7QnVDtMuQW1zfXhOB+b5PG6y8MtYZMlydgUkmWSwe1eHt6pMvItJcztrgvfPo/urrLiHnbi",
+	"WzjX7RvNcPsK77/optrh7NbZuyZ8zMea7GF8KtaLHCdR7NYO3inspMUJNveF9MUXYnLn4KMq44TawFLw",
+	"0ta/q/i4DMRcy8r1kM165zfhvO5yPub9/yvozoi6O37dNQrrt7i5H+EBb/Lb8HBssS9vzY/xobUCawvZ",
+	"f/XI3rM1V8B5Zq27BxryRUY1DDhOuFdI+UpbepQHh8nSpHT1gr8r0nVW9v3ADQf3oZI2FmLQvUOtWRys",
+	"kFU93UQ0lK18BTpRZ2cHpUL40gK8IZCa7Z6W77tKgQnlmIdoildr4Q7gTQUsbE17jDxyZ6X8/AxsdJ15",
+	"YDf6du6uqp9f/p2t7T4cnz+uWGITF00zCTRdWk9mx+zmGLfN+puIzIPP/k93MWBfKKQNaAz01pagfbGQ",
+	"IUA4K/seVD5U1x//qgsX9+wJuzhfZ4RjtfH7XES9W3DrDb/tpctVAQE3ifamzDZdwyVjxQjhK5u/IWbY",
+	"1dPdSd0HCD4eC3iwxvYKJLouv+okevJ0IZi/niSnnM5s5EzVvor9TSa2kojGuwzqD7mbQSpG9Dnj1/Hq",
+	"7sxht+Vtxx46cTtlu/VH1zZfjgb7qdOHn4e/beuf1F63/sS174sUXMEV078bmZmZxmSlOePR9cfr/xsA",
+	"Qyx1ZGHGAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
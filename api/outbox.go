@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	redisAdapter "q4/adapters/redis"
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// outboxMetrics 發送 outbox 事件的指標
+//   - published: 成功發送的事件數量
+//   - failed: 發送失敗的次數
+//   - lastRunAt: 最後一次發送完成的時間(Unix秒)
+var (
+	outboxMetrics   = newMetricsMap("outbox")
+	outboxPublished = new(expvar.Int)
+	outboxFailed    = new(expvar.Int)
+	outboxLastRunAt = new(expvar.Int)
+)
+
+func init() {
+	outboxMetrics.Set("published", outboxPublished)
+	outboxMetrics.Set("failed", outboxFailed)
+	outboxMetrics.Set("lastRunAt", outboxLastRunAt)
+}
+
+// AuctionEvent 發送到拍賣事件 stream 的消息
+// 至少發送一次，同一個事件可能因為重送而收到多次，consumer 需要以 ID 去除重複
+type AuctionEvent struct {
+	// outbox 事件的ID
+	ID            uuid.UUID
+	Type          models.OutboxEventType
+	AuctionItemID uuid.UUID
+	// 事件發生時拍賣物品的內容
+	Payload    json.RawMessage
+	OccurredAt time.Time
+}
+
+// auctionEventPayload 拍賣事件中記錄的拍賣物品內容
+type auctionEventPayload struct {
+	UserID        uuid.UUID             `json:"userId"`
+	Title         string                `json:"title"`
+	Status        openapi.AuctionStatus `json:"status"`
+	StartingPrice uint32                `json:"startingPrice"`
+	StartTime     time.Time             `json:"startTime"`
+	EndTime       time.Time             `json:"endTime"`
+}
+
+// auctionEventSchema 拍賣事件以 JSON 編碼，方便其他語言的服務讀取
+var auctionEventSchema = lo.Must(redisAdapter.NewSchema[AuctionEvent]("auction_event", 1, redisAdapter.WithSchemaCodec(redisAdapter.CodecJSON)))
+
+// enqueueAuctionEvent 在指定的交易中寫入拍賣物品的 outbox 事件，和拍賣物品的修改一起提交或回滾
+func enqueueAuctionEvent(tx *gorm.DB, typ models.OutboxEventType, auction models.AuctionItem) error {
+	payload, err := json.Marshal(auctionEventPayload{
+		UserID:        auction.UserID,
+		Title:         auction.Title,
+		Status:        auction.Status,
+		StartingPrice: auction.StartingPrice,
+		StartTime:     auction.StartTime,
+		EndTime:       auction.EndTime,
+	})
+	if err != nil {
+		return fmt.Errorf("fail to marshal outbox event payload, err=%w", err)
+	}
+	event := models.OutboxEvent{
+		AggregateID: auction.ID,
+		Type:        typ,
+		Payload:     payload,
+	}
+	if result := tx.Create(&event); result.Error != nil {
+		return fmt.Errorf("fail to create outbox event, type=%s, id=%s, err=%w", typ, auction.ID, result.Error)
+	}
+	return nil
+}
+
+// enqueueAuctionEvents 在指定的交易中寫入多個拍賣物品的 outbox 事件
+func enqueueAuctionEvents(tx *gorm.DB, typ models.OutboxEventType, auctions []models.AuctionItem) error {
+	for _, auction := range auctions {
+		if err := enqueueAuctionEvent(tx, typ, auction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runOutboxRelay 定期將尚未發送的 outbox 事件發送到拍賣事件 stream，同一時間只會有一個實例執行
+func (impl *ServerImpl) runOutboxRelay(ctx context.Context) {
+	logger := slog.Default().With(slog.String("caller", "OutboxRelay"))
+	defer logger.Info("Outbox relay worker stopped")
+	mutex := impl.newMutex("outbox-relay")
+	for {
+		lockCtx, err := mutex.Lock(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("Fail to acquire lock", slog.Any("error", err))
+			continue
+		}
+		logger.Info("Acquire lock, start relaying outbox events")
+		ticker := time.NewTicker(impl.config.Outbox.Interval)
+	LOOP:
+		for {
+			// 一批都發送成功時可能還有剩下的事件，不等待下一次觸發
+			for {
+				count, err := impl.relayOutboxEvents(lockCtx)
+				if err != nil {
+					if !errors.Is(err, context.Canceled) {
+						logger.Error("Fail to relay outbox events", slog.Any("error", err))
+					}
+					break
+				}
+				if count < impl.config.Outbox.BatchSize {
+					break
+				}
+			}
+			select {
+			case <-lockCtx.Done():
+				break LOOP
+			case <-ticker.C:
+			}
+		}
+		ticker.Stop()
+		if _, err := mutex.Unlock(); err != nil {
+			logger.Warn("Fail to release lock", slog.Any("error", err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// relayOutboxEvents 依照ID順序發送一批尚未發送的 outbox 事件，返回發送成功的數量
+//   - 發送成功後記錄發送時間，記錄失敗時事件會在下一輪重送
+//   - 發送失敗時記錄失敗次數和錯誤，並停止發送後面的事件，避免事件的順序錯亂
+func (impl *ServerImpl) relayOutboxEvents(ctx context.Context) (int, error) {
+	var events []models.OutboxEvent
+	result := impl.db.WithContext(ctx).
+		Where("sent_at IS NULL").
+		Order("id").
+		Limit(impl.config.Outbox.BatchSize).
+		Find(&events)
+	if result.Error != nil {
+		return 0, fmt.Errorf("fail to find pending outbox events, err=%w", result.Error)
+	}
+	defer func() { outboxLastRunAt.Set(time.Now().Unix()) }()
+	for i, event := range events {
+		_, err := impl.outboxProducer.PublishSync(ctx, AuctionEvent{
+			ID:            event.ID,
+			Type:          event.Type,
+			AuctionItemID: event.AggregateID,
+			Payload:       event.Payload,
+			OccurredAt:    event.CreatedAt,
+		})
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return i, err
+			}
+			outboxFailed.Add(1)
+			result := impl.db.Model(&event).Updates(map[string]any{
+				"attempts":   gorm.Expr("attempts + 1"),
+				"last_error": err.Error(),
+			})
+			if result.Error != nil {
+				slog.Warn("Fail to record outbox event error", slog.String("id", event.ID.String()), slog.Any("error", result.Error))
+			}
+			return i, fmt.Errorf("fail to publish outbox event, id=%s, err=%w", event.ID, err)
+		}
+		outboxPublished.Add(1)
+		if result := impl.db.Model(&event).Update("sent_at", time.Now()); result.Error != nil {
+			return i, fmt.Errorf("fail to mark outbox event as sent, id=%s, err=%w", event.ID, result.Error)
+		}
+	}
+	return len(events), nil
+}
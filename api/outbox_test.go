@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	redisAdapter "q4/adapters/redis"
+	"q4/api/openapi"
+	"q4/models"
+)
+
+func TestRelayOutboxEvents(t *testing.T) {
+	db, _, auction := setupBidSyncDB(t)
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+	const stream = "auction-event-stream"
+	producer, err := redisAdapter.NewProducer(client, stream, redisAdapter.WithProducerSchema(auctionEventSchema))
+	require.NoError(t, err)
+	producer.Start()
+	defer producer.Close()
+	impl := &ServerImpl{
+		db:             db,
+		redisClient:    client,
+		outboxProducer: producer,
+		config:         ServerConfig{Outbox: OutboxConfig{BatchSize: 100}},
+	}
+	t.Cleanup(func() {
+		db.Unscoped().Where("aggregate_id = ?", auction.ID).Delete(&models.OutboxEvent{})
+	})
+
+	// 交易回滾時不會留下事件
+	_ = db.Transaction(func(tx *gorm.DB) error {
+		require.NoError(t, enqueueAuctionEvent(tx, models.OutboxEventAuctionEdited, auction))
+		return gorm.ErrInvalidTransaction
+	})
+	auction.Status = openapi.Cancelled
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return enqueueAuctionEvent(tx, models.OutboxEventAuctionCancelled, auction)
+	}))
+
+	// Redis 無法寫入時記錄失敗次數，事件保持未發送
+	mr.SetError("MOCK ERROR")
+	_, err = impl.relayOutboxEvents(ctx)
+	assert.Error(t, err)
+	mr.SetError("")
+	var event models.OutboxEvent
+	require.NoError(t, db.Where("aggregate_id = ?", auction.ID).First(&event).Error)
+	assert.Equal(t, models.OutboxEventAuctionCancelled, event.Type)
+	assert.Nil(t, event.SentAt)
+	assert.Equal(t, 1, event.Attempts)
+	assert.NotEmpty(t, event.LastError)
+
+	// 發送成功後標記為已發送，不會重複發送
+	_, err = impl.relayOutboxEvents(ctx)
+	require.NoError(t, err)
+	_, err = impl.relayOutboxEvents(ctx)
+	require.NoError(t, err)
+	require.NoError(t, db.First(&event).Error)
+	assert.NotNil(t, event.SentAt)
+
+	messages, err := client.XRange(ctx, stream, "-", "+").Result()
+	require.NoError(t, err)
+	var received []AuctionEvent
+	for _, message := range messages {
+		data, err := auctionEventSchema.Decode(message.Values)
+		require.NoError(t, err)
+		if data.AuctionItemID == auction.ID {
+			received = append(received, data)
+		}
+	}
+	require.Len(t, received, 1)
+	assert.Equal(t, event.ID, received[0].ID)
+	assert.Equal(t, models.OutboxEventAuctionCancelled, received[0].Type)
+	assert.JSONEq(t, string(event.Payload), string(received[0].Payload))
+	assert.WithinDuration(t, event.CreatedAt, received[0].OccurredAt, time.Millisecond)
+}
+
+func TestAuctionEventSchema(t *testing.T) {
+	event := AuctionEvent{
+		Type:       models.OutboxEventAuctionCreated,
+		Payload:    []byte(`{"title":"test"}`),
+		OccurredAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	values, err := auctionEventSchema.Encode(event)
+	require.NoError(t, err)
+	assert.Equal(t, redisAdapter.CodecJSON, values[redisAdapter.EnvelopeCodecField])
+
+	data, err := auctionEventSchema.Decode(values)
+	require.NoError(t, err)
+	assert.Equal(t, event, data)
+}
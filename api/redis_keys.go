@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	redisAdapter "q4/adapters/redis"
+)
+
+// newRedisClient 依照設定建立 Redis 連線
+//   - Cluster 為 true 時連線到 Redis Cluster
+//   - 設定 MasterName 時透過 Sentinel 連線，主從切換後會自動連到新的主節點
+//   - 其他情況連線到單一節點
+func newRedisClient(config RedisConfig) redis.UniversalClient {
+	addrs := strings.Split(config.Addr, ",")
+	for i := range addrs {
+		addrs[i] = strings.TrimSpace(addrs[i])
+	}
+	opts := &redis.UniversalOptions{
+		Addrs:            addrs,
+		Password:         config.Password,
+		DB:               config.DB,
+		MasterName:       config.MasterName,
+		SentinelPassword: config.SentinelPassword,
+		MaxRetries:       config.MaxRetries,
+		DialTimeout:      config.DialTimeout,
+		ReadTimeout:      config.ReadTimeout,
+		WriteTimeout:     config.WriteTimeout,
+		PoolSize:         config.PoolSize,
+	}
+	switch {
+	case config.Cluster:
+		return redis.NewClusterClient(opts.Cluster())
+	case config.MasterName != "":
+		return redis.NewFailoverClient(opts.Failover())
+	default:
+		return redis.NewClient(opts.Simple())
+	}
+}
+
+// bidPartition 計算拍賣物品所屬的出價分區
+func bidPartition(config RedisConfig, itemID uuid.UUID) int {
+	h := fnv.New32a()
+	h.Write(itemID[:])
+	return int(h.Sum32() % uint32(config.StreamPartitions))
+}
+
+// bidStreamKey 取得出價分區的 stream 鍵
+// NOTE: 分區編號放在 hash tag 中，讓同一個分區的 stream 和最高競價、冪等鍵落在同一個 slot，
+// 出價的 Lua script 在 Redis Cluster 上才不會發生 CROSSSLOT 錯誤
+func bidStreamKey(config RedisConfig, partition int) string {
+	return fmt.Sprintf("%s:{%d}", config.StreamKeys.BidStream, partition)
+}
+
+// bidStreamKeys 取得所有出價分區的 stream 鍵
+func bidStreamKeys(config RedisConfig) []string {
+	keys := make([]string, config.StreamPartitions)
+	for i := range keys {
+		keys[i] = bidStreamKey(config, i)
+	}
+	return keys
+}
+
+// checkLegacyBidStream 檢查分區前的出價 stream(<BidStream>)是否還有尚未同步的出價
+// 改為分區的 stream 後不會再讀取原本的 stream，原本 stream 中尚未確認的出價和 dead-letter 中的出價都不會被同步，
+// 所以在這些出價處理完之前拒絕啟動，需要先以舊版本同步完成，或由管理員手動處理後刪除原本的 stream
+func checkLegacyBidStream(ctx context.Context, client redis.Cmdable, config RedisConfig) error {
+	legacy := config.StreamKeys.BidStream
+	deadLetter := redisAdapter.DeadLetterStream(legacy)
+	n, err := client.XLen(ctx, deadLetter).Result()
+	if err != nil {
+		return fmt.Errorf("fail to check legacy dead-letter stream %s, err=%w", deadLetter, err)
+	}
+	if n > 0 {
+		return fmt.Errorf("legacy dead-letter stream %s still has %d bids, replay or discard them with the previous version before upgrading", deadLetter, n)
+	}
+	kind, err := client.Type(ctx, legacy).Result()
+	if err != nil {
+		return fmt.Errorf("fail to check legacy bid stream %s, err=%w", legacy, err)
+	}
+	if kind != "stream" {
+		return nil
+	}
+	groups, err := client.XInfoGroups(ctx, legacy).Result()
+	if err != nil {
+		return fmt.Errorf("fail to get consumer groups of legacy bid stream %s, err=%w", legacy, err)
+	}
+	// 沒有 consumer group 時 stream 中的出價都沒有被同步過
+	start := "-"
+	for _, group := range groups {
+		if group.Name != config.ConsumerGroup {
+			continue
+		}
+		if group.Pending > 0 {
+			return fmt.Errorf("legacy bid stream %s still has %d unacked bids, sync them with the previous version before upgrading", legacy, group.Pending)
+		}
+		if group.LastDeliveredID != "" && group.LastDeliveredID != "0-0" {
+			start = "(" + group.LastDeliveredID
+		}
+	}
+	undelivered, err := client.XRangeN(ctx, legacy, start, "+", 1).Result()
+	if err != nil {
+		return fmt.Errorf("fail to read legacy bid stream %s, err=%w", legacy, err)
+	}
+	if len(undelivered) > 0 {
+		return fmt.Errorf("legacy bid stream %s still has unsynced bids from %s, sync them with the previous version before upgrading", legacy, undelivered[0].ID)
+	}
+	return nil
+}
+
+// bidStreamKey 取得拍賣物品的出價寫入的 stream 鍵
+func (impl *ServerImpl) bidStreamKey(itemID uuid.UUID) string {
+	return bidStreamKey(impl.config.Redis, bidPartition(impl.config.Redis, itemID))
+}
+
+// auctionKey 取得拍賣物品在Redis中記錄最高競價的鍵
+func (impl *ServerImpl) auctionKey(itemID uuid.UUID) string {
+	return auctionKey(impl.config.Redis, itemID)
+}
+
+// idempotencyKey 回傳出價冪等鍵在 Redis 中的鍵，以使用者和拍賣物品區分範圍，避免不同使用者的冪等鍵互相衝突
+func (impl *ServerImpl) idempotencyKey(userID string, itemID uuid.UUID, key string) string {
+	return idempotencyKey(impl.config.Redis, userID, itemID, key)
+}
+
+func auctionKey(config RedisConfig, itemID uuid.UUID) string {
+	return fmt.Sprintf("%sauction:{%d}:%s", config.KeyPrefix, bidPartition(config, itemID), itemID)
+}
+
+func idempotencyKey(config RedisConfig, userID string, itemID uuid.UUID, key string) string {
+	return fmt.Sprintf("%sidempotency:bid:{%d}:%s:%s:%s", config.KeyPrefix, bidPartition(config, itemID), userID, itemID, key)
+}
+
+// newBidGroupConsumer 為每個出價分區建立 group consumer，並合併成一個
+func newBidGroupConsumer(
+	client redis.UniversalClient,
+	streams []string,
+	group, consumer string,
+	opts ...redisAdapter.GroupConsumerOption[BidInfo],
+) (redisAdapter.IGroupConsumer[BidInfo], error) {
+	consumers := make([]redisAdapter.IGroupConsumer[BidInfo], len(streams))
+	for i, stream := range streams {
+		c, err := redisAdapter.NewGroupConsumer[BidInfo](client, stream, group, consumer, opts...)
+		if err != nil {
+			return nil, err
+		}
+		consumers[i] = c
+	}
+	return redisAdapter.MergeGroupConsumers(consumers...)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBidKeys_SameHashTag(t *testing.T) {
+	impl := &ServerImpl{config: ServerConfig{Redis: RedisConfig{
+		KeyPrefix:        "q4:",
+		StreamKeys:       RedisStreamKeys{BidStream: "bids"},
+		StreamPartitions: 8,
+	}}}
+	hashTag := regexp.MustCompile(`\{([^}]*)\}`)
+	partitions := map[int]bool{}
+	for range 100 {
+		itemID := uuid.New()
+		partition := bidPartition(impl.config.Redis, itemID)
+		assert.GreaterOrEqual(t, partition, 0)
+		assert.Less(t, partition, 8)
+		partitions[partition] = true
+
+		keys := []string{
+			impl.auctionKey(itemID),
+			impl.bidStreamKey(itemID),
+			impl.idempotencyKey("user", itemID, "key"),
+		}
+		tag := hashTag.FindStringSubmatch(keys[0])
+		if assert.Len(t, tag, 2, keys[0]) {
+			for _, key := range keys[1:] {
+				assert.Equal(t, tag, hashTag.FindStringSubmatch(key), key)
+			}
+		}
+	}
+	assert.Greater(t, len(partitions), 1, "items should be spread across partitions")
+	assert.Equal(t, []string{"bids:{0}", "bids:{1}"}, bidStreamKeys(RedisConfig{
+		StreamKeys:       RedisStreamKeys{BidStream: "bids"},
+		StreamPartitions: 2,
+	}))
+}
+
+func TestNewRedisClient(t *testing.T) {
+	t.Run("單一節點", func(t *testing.T) {
+		client := newRedisClient(RedisConfig{Addr: "localhost:6379", DB: 3, PoolSize: 7})
+		defer client.Close()
+		c, ok := client.(*redis.Client)
+		if assert.True(t, ok) {
+			assert.Equal(t, "localhost:6379", c.Options().Addr)
+			assert.Equal(t, 3, c.Options().DB)
+			assert.Equal(t, 7, c.Options().PoolSize)
+		}
+	})
+
+	t.Run("Sentinel", func(t *testing.T) {
+		client := newRedisClient(RedisConfig{Addr: "sentinel-1:26379, sentinel-2:26379", MasterName: "mymaster", DB: 3})
+		defer client.Close()
+		c, ok := client.(*redis.Client)
+		if assert.True(t, ok) {
+			// go-redis 的 failover client 會以 FailoverClient 取代實際的位址
+			assert.Equal(t, "FailoverClient", c.Options().Addr)
+			assert.Equal(t, 3, c.Options().DB)
+		}
+	})
+
+	t.Run("Cluster", func(t *testing.T) {
+		client := newRedisClient(RedisConfig{Addr: "node-1:6379,node-2:6379", Cluster: true})
+		defer client.Close()
+		c, ok := client.(*redis.ClusterClient)
+		if assert.True(t, ok) {
+			assert.Equal(t, []string{"node-1:6379", "node-2:6379"}, c.Options().Addrs)
+		}
+	})
+}
+
+func TestCheckLegacyBidStream(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	config := RedisConfig{ConsumerGroup: "bid-group", StreamKeys: RedisStreamKeys{BidStream: "bids"}}
+	add := func(stream string) string {
+		id, err := client.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: map[string]any{"data": "bid"}}).Result()
+		require.NoError(t, err)
+		return id
+	}
+	read := func() []redis.XMessage {
+		streams, err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group: "bid-group", Consumer: "consumer", Streams: []string{"bids", ">"}, Count: 1,
+		}).Result()
+		require.NoError(t, err)
+		return streams[0].Messages
+	}
+
+	// 沒有原本的 stream
+	assert.NoError(t, checkLegacyBidStream(ctx, client, config))
+
+	// 沒有 consumer group 時所有出價都沒有同步
+	add("bids")
+	assert.Error(t, checkLegacyBidStream(ctx, client, config))
+
+	// 有尚未確認的出價
+	require.NoError(t, client.XGroupCreate(ctx, "bids", "bid-group", "0").Err())
+	messages := read()
+	assert.Error(t, checkLegacyBidStream(ctx, client, config))
+
+	// 全部確認後可以啟動，保留在 stream 中已經確認的出價不影響
+	require.NoError(t, client.XAck(ctx, "bids", "bid-group", messages[0].ID).Err())
+	assert.NoError(t, checkLegacyBidStream(ctx, client, config))
+
+	// 有還沒被讀取的出價
+	add("bids")
+	assert.Error(t, checkLegacyBidStream(ctx, client, config))
+	require.NoError(t, client.XAck(ctx, "bids", "bid-group", read()[0].ID).Err())
+	assert.NoError(t, checkLegacyBidStream(ctx, client, config))
+
+	// dead-letter 中還有出價
+	id := add("bids:dead-letter")
+	assert.Error(t, checkLegacyBidStream(ctx, client, config))
+	require.NoError(t, client.XDel(ctx, "bids:dead-letter", id).Err())
+	assert.NoError(t, checkLegacyBidStream(ctx, client, config))
+}
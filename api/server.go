@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,13 +18,13 @@ import (
 	awsCfg "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	ginsse "github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/redis/go-redis/v9"
 	"github.com/samber/lo"
-	"github.com/vmihailenco/msgpack/v5"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -38,15 +40,22 @@ import (
 
 type ServerImpl struct {
 	oidcProviders map[openapi.SSOProvider]*oidc.Provider
-	sseManager    sse.IConnectionManager[openapi.BidEvent]
+	sseManager    sse.IConnectionManager[bidEvent]
 	s3Operator    *internalS3.S3Operator
 	htmlChecker   *bluemonday.Policy
-	redisClient   *redis.Client
-	consumer      redisAdapter.IConsumer[sse.PublishRequest[openapi.BidEvent]]
-	groupConsumer redisAdapter.IGroupConsumer[BidInfo]
-	wg            sync.WaitGroup
-	cancelFunc    context.CancelFunc
-	db            *gorm.DB
+	redisClient   redis.UniversalClient
+	bidStore      bidStore
+	consumer      bidEventSource
+	groupConsumer redisAdapter.IBatchGroupConsumer[BidInfo]
+	shillConsumer redisAdapter.IGroupConsumer[BidInfo]
+	bidLimiters   []bidLimiter
+	// 每個分區的出價 stream 各自對應一個 dead-letter queue
+	deadLetterQueues []redisAdapter.IDeadLetterQueue[BidInfo]
+	// 發送 outbox 中的拍賣事件
+	outboxProducer redisAdapter.IProducer[AuctionEvent]
+	wg             sync.WaitGroup
+	cancelFunc     context.CancelFunc
+	db             *gorm.DB
 
 	config ServerConfig
 }
@@ -54,6 +63,16 @@ type ServerImpl struct {
 func NewServer(config ServerConfig) (*ServerImpl, error) {
 	const op = "NewServer"
 
+	if config.Auction.LifecycleInterval <= 0 {
+		return nil, fmt.Errorf("[%s] Auction lifecycle interval must be positive", op)
+	}
+	if config.Redis.ReconcileInterval <= 0 {
+		return nil, fmt.Errorf("[%s] Redis reconcile interval must be positive", op)
+	}
+	if config.Auction.ImportChunkSize <= 0 {
+		return nil, fmt.Errorf("[%s] Auction import chunk size must be positive", op)
+	}
+
 	// 初始化OIDC提供者
 	oidcProviders := make(map[openapi.SSOProvider]*oidc.Provider, len(config.OIDC.Providers))
 	for provider, providerConfig := range config.OIDC.Providers {
@@ -91,66 +110,215 @@ func NewServer(config ServerConfig) (*ServerImpl, error) {
 		return nil, fmt.Errorf("[%s] Fail to connect to database, err=%w", op, err)
 	}
 
-	// 初始化Redis連線
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     config.Redis.Addr,
-		Password: config.Redis.Password,
-		DB:       config.Redis.DB,
-	})
+	if config.StreamRetention.Interval <= 0 {
+		return nil, fmt.Errorf("[%s] Stream retention interval must be positive", op)
+	}
+	if config.Outbox.Interval <= 0 || config.Outbox.BatchSize <= 0 {
+		return nil, fmt.Errorf("[%s] Outbox interval and batch size must be positive", op)
+	}
+	if config.Redis.SyncBatchSize <= 0 {
+		return nil, fmt.Errorf("[%s] Redis sync batch size must be positive", op)
+	}
+	// 冪等鍵以 SET EX 記錄結果，過期時間的單位是秒
+	if config.Redis.IdempotencyExpireTime < time.Second {
+		return nil, fmt.Errorf("[%s] Redis idempotency expire time must be at least 1s", op)
+	}
+	if config.SSE.QueueSize <= 0 {
+		return nil, fmt.Errorf("[%s] SSE queue size must be positive", op)
+	}
+	overflowPolicy, err := sseOverflowPolicy(config.SSE.OverflowPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
+	}
 
-	// 初始化SSE管理器
-	consumer, err := redisAdapter.NewConsumer(
-		redisClient,
-		config.Redis.StreamKeys.BidStream,
-		redisAdapter.WithConsumerParseFunc(func(m map[string]any) (sse.PublishRequest[openapi.BidEvent], error) {
-			bidInfo, err := redisAdapter.DefaultParseFromMessage[BidInfo](m)
-			if err != nil {
-				return sse.PublishRequest[openapi.BidEvent]{}, fmt.Errorf("fail to parse message to sse.PublishRequest[openapi.BidEvent], err=%w", err)
-			}
-			return sse.PublishRequest[openapi.BidEvent]{
-				Channel: bidInfo.ItemID.String(),
-				Message: openapi.BidEvent{
-					Bid:  bidInfo.Amount,
-					User: bidInfo.User.Name,
-					Time: bidInfo.CreatedAt,
-				},
-			}, nil
-		}),
-	)
+	// 初始化出價相關的元件
+	//  - 單一程序模式下以記憶體中的實作取代 Redis
+	var backend *bidBackend
+	if config.InMemory {
+		// 記憶體中的出價只有這個程序看得到，不能從出價 stream 以外的地方接收
+		if config.SSE.Backend == SSEBackendRedis {
+			config.SSE.Backend = SSEBackendMemory
+		}
+		backend, err = newMemoryBidBackend(config, db, dsn)
+	} else {
+		backend, err = newRedisBidBackend(config, db, dsn)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("[%s] Fail to create consumer, err=%w", op, err)
+		return nil, fmt.Errorf("[%s] %w", op, err)
 	}
-	sseManager, err := sse.NewConnectionManager[openapi.BidEvent](
-		sse.WithLogger[openapi.BidEvent](slog.Default()),
-		sse.WithSubscriber(consumer),
-	)
+
+	// 初始化SSE管理器
+	//  - 依照設定從出價 stream、PostgreSQL 的 LISTEN/NOTIFY 或程序內接收出價事件
+	//  - 每個連線各自有一個有界的佇列，讀取太慢的連線依照 OverflowPolicy 處理，不會拖慢其他連線
+	sseOptions := []sse.ConnectionManagerOption[bidEvent]{
+		sse.WithLogger[bidEvent](slog.Default()),
+		sse.WithSubscriber(backend.source),
+		sse.WithChannelOptions[bidEvent](sse.WithChannelBuffer(config.SSE.QueueSize, overflowPolicy)),
+		sse.WithMetrics[bidEvent](sseMetrics),
+	}
+	if backend.publisher != nil {
+		sseOptions = append(sseOptions, sse.WithPublisher(backend.publisher))
+	}
+	sseManager, err := sse.NewConnectionManager(sseOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("[%s] Fail to create sse connection manager, err=%w", op, err)
 	}
 
+	return &ServerImpl{
+		oidcProviders:    oidcProviders,
+		sseManager:       sseManager,
+		s3Operator:       s3Operator,
+		htmlChecker:      bluemonday.UGCPolicy(),
+		redisClient:      backend.redisClient,
+		bidStore:         backend.bidStore,
+		consumer:         backend.source,
+		groupConsumer:    backend.groupConsumer,
+		shillConsumer:    backend.shillConsumer,
+		bidLimiters:      backend.bidLimiters,
+		deadLetterQueues: backend.deadLetterQueues,
+		outboxProducer:   backend.outboxProducer,
+		db:               db,
+		config:           config,
+	}, nil
+}
+
+// bidBackend 處理出價、同步出價和推播出價事件的元件
+type bidBackend struct {
+	// 單一程序模式下為 nil
+	redisClient      redis.UniversalClient
+	bidStore         bidStore
+	source           bidEventSource
+	publisher        sse.Publisher[sse.PublishRequest[bidEvent]]
+	groupConsumer    redisAdapter.IBatchGroupConsumer[BidInfo]
+	shillConsumer    redisAdapter.IGroupConsumer[BidInfo]
+	bidLimiters      []bidLimiter
+	deadLetterQueues []redisAdapter.IDeadLetterQueue[BidInfo]
+	outboxProducer   redisAdapter.IProducer[AuctionEvent]
+}
+
+// newRedisBidBackend 建立以 Redis 處理出價的元件
+func newRedisBidBackend(config ServerConfig, db *gorm.DB, dsn string) (*bidBackend, error) {
+	// 初始化Redis連線
+	if config.Redis.StreamPartitions <= 0 {
+		return nil, errors.New("redis stream partitions must be positive")
+	}
+	if config.ID == "" {
+		return nil, errors.New("instance ID is required to balance bid partitions among instances")
+	}
+	if config.Redis.Cluster && config.Redis.MasterName != "" {
+		return nil, errors.New("redis cluster and sentinel cannot be used together")
+	}
+	if config.Redis.RetryDelay < 0 {
+		return nil, errors.New("redis retry delay cannot be negative")
+	}
+	redisClient := newRedisClient(config.Redis)
+	bidStreams := bidStreamKeys(config.Redis)
+	if err := checkLegacyBidStream(context.Background(), redisClient, config.Redis); err != nil {
+		return nil, err
+	}
+
+	// 初始化SSE的出價事件來源
+	//  - 依照設定從出價 stream 或 PostgreSQL 的 LISTEN/NOTIFY 接收出價事件
+	source, publisher, err := newBidEventSource(config, redisClient, db, dsn, bidStreams)
+	if err != nil {
+		return nil, err
+	}
+
 	// 初始化group consumer
-	groupConsumer, err := redisAdapter.NewGroupConsumer[BidInfo](
+	//  - 存活的實例之間依照 rendezvous hashing 分配出價分區，同步的吞吐量隨著實例數量增加
+	//  - 每個分區各自持有嚴格順序模式的鎖，同一個拍賣物品的出價依序同步
+	//  - 以批次讀取出價，每一批在同一個交易中同步
+	groupConsumer, err := redisAdapter.NewPartitionedGroupConsumer[BidInfo](
 		redisClient,
-		config.Redis.StreamKeys.BidStream,
+		bidStreams,
 		config.Redis.ConsumerGroup,
 		config.ID,
-		redisAdapter.WithGroupConsumerLogger[BidInfo](slog.Default()),
-		redisAdapter.WithGroupConsumerStrictOrdering[BidInfo](true),
+		redisAdapter.WithPartitionedGroupConsumerLogger[BidInfo](slog.Default()),
+		redisAdapter.WithPartitionedGroupConsumerRegistry[BidInfo](config.Redis.KeyPrefix+"registry:"+config.Redis.ConsumerGroup),
+		redisAdapter.WithPartitionedGroupConsumerHeartbeat[BidInfo](config.Redis.PartitionHeartbeatInterval, config.Redis.PartitionInstanceTTL),
+		redisAdapter.WithPartitionedGroupConsumerOptions(
+			redisAdapter.WithGroupConsumerLogger[BidInfo](slog.Default()),
+			redisAdapter.WithGroupConsumerSchema(bidInfoSchema),
+			redisAdapter.WithGroupConsumerCreateGroup[BidInfo]("0"),
+			redisAdapter.WithGroupConsumerRetryDelay[BidInfo](config.Redis.RetryDelay),
+			redisAdapter.WithGroupConsumerBatchSize[BidInfo](config.Redis.SyncBatchSize),
+			redisAdapter.WithGroupConsumerMaxAttempts[BidInfo](config.Redis.MaxAttempts),
+			redisAdapter.WithGroupConsumerBackoff[BidInfo](config.Redis.RetryBackoff, config.Redis.RetryMaxBackoff),
+		),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("[%s] Fail to create group consumer, err=%w", op, err)
+		return nil, fmt.Errorf("fail to create group consumer, err=%w", err)
 	}
 
-	return &ServerImpl{
-		oidcProviders: oidcProviders,
-		sseManager:    sseManager,
-		s3Operator:    s3Operator,
-		htmlChecker:   bluemonday.UGCPolicy(),
-		redisClient:   redisClient,
-		consumer:      consumer,
-		groupConsumer: groupConsumer,
-		db:            db,
-		config:        config,
+	// 初始化可疑出價偵測使用的group consumer
+	// NOTE: 偵測結果不需要嚴格的順序，多個實例可以一起分擔偵測的工作
+	//       實例意外下線時留下的 pending 出價會在閒置一段時間後被其他實例認領
+	var shillConsumer redisAdapter.IGroupConsumer[BidInfo]
+	if config.ShillDetection.Enabled {
+		shillConsumer, err = newBidGroupConsumer(
+			redisClient,
+			bidStreams,
+			config.ShillDetection.ConsumerGroup,
+			config.ID,
+			redisAdapter.WithGroupConsumerLogger[BidInfo](slog.Default()),
+			redisAdapter.WithGroupConsumerSchema(bidInfoSchema),
+			redisAdapter.WithGroupConsumerCreateGroup[BidInfo]("$"),
+			redisAdapter.WithGroupConsumerRetryDelay[BidInfo](config.Redis.RetryDelay),
+			redisAdapter.WithGroupConsumerClaimMinIdle[BidInfo](config.Redis.ClaimMinIdle),
+			redisAdapter.WithGroupConsumerClaimInterval[BidInfo](config.Redis.ClaimInterval),
+			redisAdapter.WithGroupConsumerMaxDeliveries[BidInfo](config.Redis.MaxDeliveries),
+			redisAdapter.WithGroupConsumerMaxAttempts[BidInfo](config.Redis.MaxAttempts),
+			redisAdapter.WithGroupConsumerBackoff[BidInfo](config.Redis.RetryBackoff, config.Redis.RetryMaxBackoff),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("fail to create shill detection group consumer, err=%w", err)
+		}
+	}
+
+	// 初始化 dead-letter queue，用於管理員查看、重送或刪除同步失敗的出價
+	deadLetterQueues := make([]redisAdapter.IDeadLetterQueue[BidInfo], len(bidStreams))
+	for i, stream := range bidStreams {
+		deadLetterQueues[i], err = redisAdapter.NewDeadLetterQueue(
+			redisClient,
+			stream,
+			redisAdapter.WithDeadLetterQueueLogger[BidInfo](slog.Default()),
+			redisAdapter.WithDeadLetterQueueSchema(bidInfoSchema),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("fail to create dead letter queue, err=%w", err)
+		}
+	}
+
+	// 初始化發送拍賣事件的producer
+	//  - 事件由 outbox 的背景工作逐筆同步寫入，寫入成功後才標記為已發送
+	outboxProducer, err := redisAdapter.NewProducer(
+		redisClient,
+		config.Redis.StreamKeys.AuctionEventStream,
+		redisAdapter.WithProducerLogger[AuctionEvent](slog.Default()),
+		redisAdapter.WithProducerSchema(auctionEventSchema),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create auction event producer, err=%w", err)
+	}
+
+	// 初始化出價限流器
+	bidLimiters, err := newBidLimiters(config, func(name string, rule RateLimitRule) (redisAdapter.IRateLimiter, error) {
+		return redisAdapter.NewRateLimiter(redisClient, config.Redis.KeyPrefix+"rate-limit:"+name, rule.Limit, rule.Window)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &bidBackend{
+		redisClient:      redisClient,
+		bidStore:         &redisBidStore{client: redisClient, config: config.Redis},
+		source:           source,
+		publisher:        publisher,
+		groupConsumer:    groupConsumer,
+		shillConsumer:    shillConsumer,
+		bidLimiters:      bidLimiters,
+		deadLetterQueues: deadLetterQueues,
+		outboxProducer:   outboxProducer,
 	}, nil
 }
 
@@ -167,78 +335,64 @@ func (impl *ServerImpl) Start() {
 	slog.Info("Start bid synchronization worker")
 	impl.wg.Add(1)
 	go func() {
-		logger := slog.Default().With(slog.String("caller", "BidSynchronize"))
 		defer impl.wg.Done()
-		defer slog.Info("Bid synchronization worker stopped")
-		defer impl.groupConsumer.Close()
-		ch := impl.groupConsumer.Subscribe()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case msg, ok := <-ch:
-				if !ok {
-					return
-				}
-				logger.Debug("Receive message")
-				handle := func() error {
-					// 更新最高出價
-					// NOTE: 參考 PostAuctionItemItemIDBids 的實現邏輯，為了避免低機率的邊界條件造成的低金額出價問題，同步出價資料庫時需要再次檢查最高出價金額。
-					record := models.Bid{
-						UserID:        msg.Data.User.ID,
-						Amount:        msg.Data.Amount,
-						AuctionItemID: msg.Data.ItemID,
-					}
-					auction := models.AuctionItem{ID: msg.Data.ItemID}
-					if result := impl.db.Preload("CurrentBid.User").First(&auction); result.Error != nil {
-						return fmt.Errorf("fail to find auction item, err=%w", result.Error)
-					}
-					// NOTE: 參考 redisAdapter.GroupConsumer 的 StrictOrder 設計，同一時間只會有一個 server 來進行處理，所以這裡不需要擔心競爭條件
-					var currentBid uint32
-					if auction.CurrentBid != nil {
-						currentBid = auction.CurrentBid.Amount
-					} else {
-						currentBid = auction.StartingPrice
-					}
-					if currentBid < msg.Data.Amount {
-						logger.Debug("Update current bid", slog.String("itemID", msg.Data.ItemID.String()), slog.Uint64("from", uint64(currentBid)), slog.Int64("to", int64(msg.Data.Amount)))
-						auction.CurrentBidID = &record.ID
-						auction.CurrentBid = &record
-						if result := impl.db.Save(&auction); result.Error != nil {
-							return fmt.Errorf("fail to update auction item, err=%w", result.Error)
-						}
-					} else {
-						logger.Warn("Ignore lower bid", slog.String("itemID", msg.Data.ItemID.String()), slog.Int64("current", int64(auction.CurrentBid.Amount)), slog.Int64("new", int64(msg.Data.Amount)))
-					}
-					return nil
-				}
-				handleErr := handle()
-				if handleErr != nil {
-					logger.Error("Fail to synchronize bid", slog.Any("error", handleErr))
-					if err := msg.Fail(ctx, handleErr); err != nil {
-						logger.Error("Fail to fail message", slog.Any("error", err))
-					}
-					continue
-				}
-				if err := msg.Done(ctx); err != nil {
-					logger.Error("Sync success but fail to done message", slog.Any("error", err))
-					if err := msg.Fail(ctx, err); err != nil {
-						logger.Error("Sync success but fail to fail message", slog.Any("error", err))
-					}
-					continue
-				}
-				logger.Debug("Synchronize success")
-			}
-		}
+		impl.runBidSynchronizer(ctx)
+	}()
+	// 啟動一個worker用於更新拍賣物品的生命週期狀態
+	slog.Info("Start auction lifecycle worker")
+	impl.wg.Add(1)
+	go func() {
+		defer impl.wg.Done()
+		impl.runAuctionLifecycle(ctx)
 	}()
+	// 單一程序模式下最高競價只存在於記憶體中，stream 也會自行刪除舊的出價，不需要校正和修剪
+	if !impl.config.InMemory {
+		// 啟動一個worker用於校正Redis中的最高競價
+		slog.Info("Start bid state reconciler")
+		impl.wg.Add(1)
+		go func() {
+			defer impl.wg.Done()
+			impl.runBidStateReconciler(ctx)
+		}()
+		// 啟動一個worker用於修剪出價stream
+		slog.Info("Start stream retention worker")
+		impl.wg.Add(1)
+		go func() {
+			defer impl.wg.Done()
+			impl.runStreamRetention(ctx)
+		}()
+	}
+	// 啟動一個worker用於發送outbox中的拍賣事件
+	impl.outboxProducer.Start()
+	slog.Info("Start outbox relay worker")
+	impl.wg.Add(1)
+	go func() {
+		defer impl.wg.Done()
+		impl.runOutboxRelay(ctx)
+	}()
+	// 啟動一個worker用於偵測可疑的出價模式
+	if impl.shillConsumer != nil {
+		impl.shillConsumer.Start()
+		slog.Info("Start shill detector")
+		impl.wg.Add(1)
+		go func() {
+			defer impl.wg.Done()
+			impl.runShillDetector(ctx)
+		}()
+	}
 }
 
 func (impl *ServerImpl) Close() {
 	// 關閉group consumer
 	impl.groupConsumer.Close()
+	if impl.shillConsumer != nil {
+		impl.shillConsumer.Close()
+	}
 	// 關閉worker
 	impl.cancelFunc()
 	impl.wg.Wait()
+	// 關閉outbox producer
+	impl.outboxProducer.Close()
 	// 關閉consumer
 	impl.consumer.Close()
 	// 關閉sse connection manager
@@ -249,12 +403,6 @@ func (impl *ServerImpl) Close() {
 // (POST /auction/item)
 func (impl *ServerImpl) PostAuctionItem(ctx context.Context, request openapi.PostAuctionItemRequestObject) (openapi.PostAuctionItemResponseObject, error) {
 	const op = "PostAuctionItem"
-	// 檢查拍賣物品的拍賣時間和結束時間是否合法
-	if request.Body.StartTime.After(request.Body.EndTime) || request.Body.EndTime.Before(time.Now()) {
-		return openapi.PostAuctionItem400JSONResponse{
-			Message: lo.ToPtr("Invalid auction time"),
-		}, nil
-	}
 	// 檢查使用者是否有權限新增拍賣物品
 	//  - 檢查是否有提供access token
 	if request.Params.AccessToken == nil {
@@ -266,36 +414,30 @@ func (impl *ServerImpl) PostAuctionItem(ctx context.Context, request openapi.Pos
 		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
 		return openapi.PostAuctionItem401Response{}, nil
 	}
-	// 處理拍賣描述
-	if request.Body.Description != nil {
-		request.Body.Description = lo.ToPtr(impl.htmlChecker.Sanitize(*request.Body.Description))
-	}
-	// 處理預設值
-	if request.Body.Description == nil {
-		request.Body.Description = lo.ToPtr("")
-	}
-	if request.Body.StartingPrice == nil {
-		request.Body.StartingPrice = lo.ToPtr(int64(0))
-	}
-	if request.Body.StartTime == nil {
-		request.Body.StartTime = lo.ToPtr(time.Now())
-	}
-	if request.Body.Carousels == nil {
-		request.Body.Carousels = lo.ToPtr([]string{})
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PostAuctionItem403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
 	}
-	// 儲存拍賣物品
-	auction := models.AuctionItem{
-		UserID:        uuid.MustParse(token.Subject),
-		Title:         request.Body.Title,
-		Description:   *request.Body.Description,
-		StartingPrice: uint32(*request.Body.StartingPrice),
-		CurrentBidID:  nil,
-		StartTime:     *request.Body.StartTime,
-		EndTime:       request.Body.EndTime,
-		Carousels:     *request.Body.Carousels,
+	// 檢查拍賣物品的內容並處理預設值
+	auction, msg := impl.auctionItemFromRequest(uuid.MustParse(token.Subject), *request.Body, time.Now())
+	if msg != "" {
+		return openapi.PostAuctionItem400JSONResponse{
+			Message: lo.ToPtr(msg),
+		}, nil
 	}
-	if result := impl.db.Debug().Create(&auction); result.Error != nil {
-		return nil, fmt.Errorf("[%s] Fail to create auction item, err=%w", op, result.Error)
+	// 儲存拍賣物品並記錄建立事件
+	err = impl.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Debug().Create(&auction); result.Error != nil {
+			return fmt.Errorf("fail to create auction item, err=%w", result.Error)
+		}
+		return enqueueAuctionEvent(tx, models.OutboxEventAuctionCreated, auction)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] %w", op, err)
 	}
 	return openapi.PostAuctionItem201Response{
 		Headers: openapi.PostAuctionItem201ResponseHeaders{
@@ -324,6 +466,21 @@ func (impl *ServerImpl) GetAuctionItemItemID(ctx context.Context, request openap
 		}
 		return nil, fmt.Errorf("[%s] Fail to find auction item, err=%w", op, result.Error)
 	}
+	status := auction.EffectiveStatus(time.Now())
+	switch status {
+	// 被下架的拍賣物品不公開
+	case openapi.Cancelled:
+		return openapi.GetAuctionItemItemID404Response{}, nil
+	// 草稿只開放賣家預覽
+	case openapi.Draft:
+		if request.Params.AccessToken == nil {
+			return openapi.GetAuctionItemItemID404Response{}, nil
+		}
+		token, err := openapi.ParseAndValidateJWT(*request.Params.AccessToken, impl.config.Auth.PrivateKey)
+		if err != nil || token.Subject != auction.UserID.String() {
+			return openapi.GetAuctionItemItemID404Response{}, nil
+		}
+	}
 	// 取得所有出價紀錄
 	bidRecords := make([]openapi.BidEvent, len(auction.BidRecords))
 	for i, bid := range auction.BidRecords {
@@ -343,6 +500,7 @@ func (impl *ServerImpl) GetAuctionItemItemID(ctx context.Context, request openap
 		StartPrice:  int64(auction.StartingPrice),
 		StartTime:   auction.StartTime,
 		Carousels:   auction.Carousels,
+		Status:      status,
 	}, nil
 }
 
@@ -361,6 +519,40 @@ func (impl *ServerImpl) PostAuctionItemItemIDBids(ctx context.Context, request o
 		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
 		return openapi.PostAuctionItemItemIDBids401Response{}, nil
 	}
+	//  - 檢查冪等鍵的格式
+	if request.Params.IdempotencyKey != nil && (len(*request.Params.IdempotencyKey) == 0 || len(*request.Params.IdempotencyKey) > 255) {
+		return openapi.PostAuctionItemItemIDBids400JSONResponse{
+			Message: lo.ToPtr("Invalid idempotency key"),
+		}, nil
+	}
+	//  - 重送的出價直接返回第一次出價的結果，不受之後的限流、停權和拍賣狀態影響
+	if request.Params.IdempotencyKey != nil {
+		status, found, err := impl.bidStore.LookupBid(ctx, uuid.MustParse(token.Subject).String(), request.ItemID, *request.Params.IdempotencyKey, request.Body.Bid)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] %w", op, err)
+		}
+		if found {
+			return placeBidResponse(op, status)
+		}
+	}
+	//  - 檢查使用者的出價頻率(在查詢資料庫前先擋下過於頻繁的請求)
+	if result, err := impl.allowBid(ctx, token.Subject, request.ItemID); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check bid rate limit, err=%w", op, err)
+	} else if !result.Allowed {
+		return openapi.PostAuctionItemItemIDBids429Response{
+			Headers: openapi.PostAuctionItemItemIDBids429ResponseHeaders{
+				RetryAfter: retryAfterSeconds(result.RetryAfter),
+			},
+		}, nil
+	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PostAuctionItemItemIDBids403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
 	// 檢查拍賣物品是否存在
 	auction := models.AuctionItem{ID: request.ItemID}
 	if result := impl.db.Preload("CurrentBid.User").First(&auction); result.Error != nil {
@@ -369,16 +561,26 @@ func (impl *ServerImpl) PostAuctionItemItemIDBids(ctx context.Context, request o
 		}
 		return nil, fmt.Errorf("[%s] Fail to find auction item, err=%w", op, result.Error)
 	}
-	// 檢查拍賣物品是否已經開始
-	if time.Now().Before(auction.StartTime) {
-		return openapi.PostAuctionItemItemIDBids403JSONResponse{}, nil
+	// 賣家不能對自己的拍賣物品出價
+	if auction.UserID.String() == token.Subject {
+		return openapi.PostAuctionItemItemIDBids403JSONResponse{
+			Message: lo.ToPtr("Seller cannot bid on own item"),
+		}, nil
 	}
-	// 檢查拍賣物品是否已經結束
-	if time.Now().After(auction.EndTime) {
+	// 檢查拍賣物品的狀態是否可以出價
+	switch auction.EffectiveStatus(time.Now()) {
+	case openapi.Draft:
+		return openapi.PostAuctionItemItemIDBids404Response{}, nil
+	case openapi.Cancelled:
+		return openapi.PostAuctionItemItemIDBids410JSONResponse{
+			Message: lo.ToPtr("Auction has been cancelled"),
+		}, nil
+	case openapi.Scheduled:
+		return openapi.PostAuctionItemItemIDBids403JSONResponse{}, nil
+	case openapi.Ended, openapi.Settled:
 		return openapi.PostAuctionItemItemIDBids410JSONResponse{}, nil
 	}
 	// 準備出價資訊
-	auctionKey := fmt.Sprintf("%sauction:%s", impl.config.Redis.KeyPrefix, request.ItemID)
 	bidInfo := BidInfo{
 		ItemID: request.ItemID,
 		User: BidInfoUser{
@@ -388,27 +590,43 @@ func (impl *ServerImpl) PostAuctionItemItemIDBids(ctx context.Context, request o
 		Amount:    request.Body.Bid,
 		CreatedAt: time.Now(),
 	}
-	bidInfoBytes, err := msgpack.Marshal(bidInfo)
-	if err != nil {
-		return nil, fmt.Errorf("[%s] Fail to marshal bid info, err=%w", op, err)
-	}
-	bidInfoBase64 := base64.StdEncoding.EncodeToString(bidInfoBytes)
-	expireTime := impl.config.Redis.ExpireTime.Seconds()
 	dbCurrentBid := auction.StartingPrice
 	if auction.CurrentBidID != nil {
 		dbCurrentBid = auction.CurrentBid.Amount
 	}
-	// 透過Lua script來處理出價
+	// 透過Lua script(或單一程序模式下記憶體中的鎖)來處理出價
 	// NOTE: 由於資料庫的出價紀錄是異步更新的，所以 dbCurrentBid 只是一個參考值，實際上的最高出價金額可能會比這個值更高，只是還在 Redis Stream 中等待同步。
-	//       為了盡量避免使用這個參考值來處理，需要指定一個較大的過期時間，同時在同步出價紀錄到資料庫時再次檢查最高出價金額，確保記錄到資料庫的出價紀錄是正確的。
-	status, err := BidScript.Run(ctx, impl.redisClient, []string{auctionKey, impl.config.Redis.StreamKeys.BidStream}, request.Body.Bid, bidInfoBase64, expireTime, dbCurrentBid).Int()
+	//       為了盡量避免使用這個參考值來處理，最高競價會保留到拍賣結束後一段時間，並由背景工作定期校正，同時在同步出價紀錄到資料庫時再次檢查最高出價金額，確保記錄到資料庫的出價紀錄是正確的。
+	//       有提供冪等鍵時，重送的出價會直接返回第一次出價的結果，不會再次寫入 Redis Stream。
+	status, streamID, err := impl.bidStore.PlaceBid(ctx, bidInfo, dbCurrentBid, auction.EndTime, request.Params.IdempotencyKey)
 	if err != nil {
-		return nil, fmt.Errorf("[%s] Fail to place bid, err=%w", op, err)
+		return nil, fmt.Errorf("[%s] %w", op, err)
 	}
-	if status == 0 {
-		return openapi.PostAuctionItemItemIDBids400JSONResponse{}, nil
-	} else if status == 1 {
+	if status == 1 {
 		slog.Info("Higher bid occurs", slog.String("user", token.Subject), slog.Int64("bid", int64(request.Body.Bid)), slog.String("auctionID", auction.ID.String()))
+		// redis 以外的模式下不讀取出價 stream，出價成功後直接發送出價事件
+		// NOTE: 出價已經成功，發送失敗只會讓連線中的使用者晚一點看到最新的出價，所以只記錄在日誌中
+		//       以相同冪等鍵重送的出價沒有寫入 stream，第一次出價時已經發送過，不會再次發送
+		if impl.config.SSE.Backend != SSEBackendRedis && streamID != "" {
+			event := newBidEvent(streamID, bidInfo)
+			if err := impl.sseManager.Publish(request.ItemID.String(), event); err != nil {
+				slog.Warn("Fail to publish bid event", slog.String("op", op), slog.String("auctionID", auction.ID.String()), slog.Any("error", err))
+			}
+		}
+	}
+	return placeBidResponse(op, status)
+}
+
+// placeBidResponse 依照出價的結果返回對應的回應，結果參考 BidScript
+func placeBidResponse(op string, status int) (openapi.PostAuctionItemItemIDBidsResponseObject, error) {
+	switch status {
+	case -1:
+		return openapi.PostAuctionItemItemIDBids422JSONResponse{
+			Message: lo.ToPtr("Idempotency key has been used by a bid with different amount"),
+		}, nil
+	case 0:
+		return openapi.PostAuctionItemItemIDBids400JSONResponse{}, nil
+	case 1:
 		return openapi.PostAuctionItemItemIDBids200Response{}, nil
 	}
 	return nil, fmt.Errorf("[%s] Invalid script return value: %d", op, status)
@@ -426,18 +644,33 @@ func (impl *ServerImpl) GetAuctionItemItemIDEvents(ctx context.Context, request
 		}
 		return nil, fmt.Errorf("[%s] Fail to find auction item, err=%w", op, result.Error)
 	}
-	// 檢查拍賣物品是否已經開始拍賣(開始前5分鐘開放連線)
-	if time.Now().Before(auction.StartTime.Add(-5 * time.Minute)) {
-		return openapi.GetAuctionItemItemIDEvents403JSONResponse{
-			Message: lo.ToPtr("Auction has not started"),
+	// 檢查拍賣物品的狀態是否可以追蹤出價
+	now := time.Now()
+	switch auction.EffectiveStatus(now) {
+	case openapi.Draft:
+		return openapi.GetAuctionItemItemIDEvents404Response{}, nil
+	case openapi.Cancelled:
+		return openapi.GetAuctionItemItemIDEvents410JSONResponse{
+			Message: lo.ToPtr("Auction has been cancelled"),
 		}, nil
-	}
-	// 檢查拍賣物品是否已經結束拍賣
-	if time.Now().After(auction.EndTime) {
+	case openapi.Scheduled:
+		// 開始前5分鐘開放連線
+		if now.Before(auction.StartTime.Add(-5 * time.Minute)) {
+			return openapi.GetAuctionItemItemIDEvents403JSONResponse{
+				Message: lo.ToPtr("Auction has not started"),
+			}, nil
+		}
+	case openapi.Ended, openapi.Settled:
 		return openapi.GetAuctionItemItemIDEvents410JSONResponse{
 			Message: lo.ToPtr("Auction has ended"),
 		}, nil
 	}
+	// 重新連線時瀏覽器會帶上最後收到的事件ID，格式不正確時視為新的連線
+	lastEventID := lo.FromPtr(request.Params.LastEventID)
+	if _, _, err := parseStreamID(lastEventID); lastEventID != "" && err != nil {
+		slog.Warn("Invalid Last-Event-ID, ignored", slog.String("op", op), slog.String("lastEventID", lastEventID))
+		lastEventID = ""
+	}
 	// SSE請求合法，開始初始化串流
 	c := ctx.(*gin.Context)
 	w := c.Writer
@@ -449,14 +682,44 @@ func (impl *ServerImpl) GetAuctionItemItemIDEvents(ctx context.Context, request
 	if err != nil {
 		return nil, fmt.Errorf("[%s] Fail to subscribe to item events, err=%w", op, err)
 	}
+	writeEvent := func(event bidEvent) {
+		c.Render(-1, ginsse.Event{Id: event.ID, Event: "bid", Data: event.Event})
+	}
+	// 補送離線期間的出價後再開始推播即時的出價
+	//  - 先訂閱再讀取 stream，讀取期間寫入的出價可能同時出現在重播和即時的事件中
+	//  - 同一個拍賣物品的出價都寫入同一個 stream，ID 依照寫入的順序遞增，讀取 stream 之後寫入的出價ID一定大於最後重播的ID
+	//    所以即時的事件中ID不大於 seenID 的出價都已經送出過，略過即可確保沒有重複也沒有遺漏
+	seenID := lastEventID
+	if lastEventID != "" {
+		err := impl.bidStore.ReplayBids(ctx, request.ItemID, lastEventID, func(id string, bid BidInfo) error {
+			writeEvent(newBidEvent(id, bid))
+			seenID = id
+			return nil
+		})
+		if err != nil {
+			impl.sseManager.Unsubscribe(request.ItemID.String(), ch)
+			return nil, fmt.Errorf("[%s] Fail to replay bids, err=%w", op, err)
+		}
+		w.Flush()
+	}
 LOOP:
 	for {
 		select {
 		case <-w.CloseNotify():
 			impl.sseManager.Unsubscribe(request.ItemID.String(), ch)
 			break LOOP
-		case event := <-ch:
-			c.SSEvent("bid", event)
+		case event, ok := <-ch:
+			// 連線太慢被中斷或伺服器關閉時結束串流，瀏覽器重新連線後會補送遺漏的出價
+			if !ok {
+				impl.sseManager.Unsubscribe(request.ItemID.String(), ch)
+				break LOOP
+			}
+			if seenID != "" {
+				if newer, err := lessStreamID(seenID, event.ID); err == nil && !newer {
+					continue
+				}
+			}
+			writeEvent(event)
 			w.Flush()
 		// 30秒沒有事件就發送一個空行，確保瀏覽器和Cloudflare不會斷開連線
 		case <-time.After(30 * time.Second):
@@ -474,6 +737,8 @@ func (impl *ServerImpl) GetAuctionItems(ctx context.Context, request openapi.Get
 	now := time.Now()
 	// 建立查詢
 	query := impl.db.Debug().Joins("CurrentBid").Model(&models.AuctionItem{})
+	//  - 排除草稿和被下架的拍賣物品
+	query = query.Where("status NOT IN ?", []openapi.AuctionStatus{openapi.Draft, openapi.Cancelled})
 	//  - title
 	if request.Params.Title != nil {
 		query = query.Where("title LIKE ?", "%"+*request.Params.Title+"%")
@@ -556,12 +821,9 @@ func (impl *ServerImpl) GetAuctionItems(ctx context.Context, request openapi.Get
 			}
 			return nil, fmt.Errorf("[%s] Fail to find last item, err=%w", op, result.Error)
 		}
-		if desc {
-			query = query.Where(sortKey+" < ?", cursor)
-		} else {
-			query = query.Where(sortKey+" > ?", cursor)
-		}
-		query = query.Or(sortKey+" = ? AND id > ?", cursor, *request.Params.LastItemID)
+		// 游標的條件需要放在同一個括號中，不能以最外層的 OR 連接，否則會略過前面的篩選條件(例如排除草稿)
+		operator := lo.Ternary(desc, " < ?", " > ?")
+		query = query.Where(impl.db.Where(sortKey+operator, cursor).Or(sortKey+" = ? AND id > ?", cursor, *request.Params.LastItemID))
 	}
 	//  - size
 	size := uint32(1)
@@ -570,8 +832,9 @@ func (impl *ServerImpl) GetAuctionItems(ctx context.Context, request openapi.Get
 	}
 	query = query.Limit(int(size))
 	//  - excludeEnded
+	//    NOTE: 儲存的狀態由背景工作定期更新，可能還沒轉換成已結束，所以需要同時比較結束時間
 	if request.Params.ExcludeEnded != nil && *request.Params.ExcludeEnded {
-		query = query.Where("end_time > ?", now)
+		query = query.Where("status IN ? AND end_time > ?", []openapi.AuctionStatus{openapi.Scheduled, openapi.Live}, now)
 	}
 	// todo: 嘗試從redis查詢，如果有就直接返回redis內儲存的查詢結果
 	// 查詢拍賣物品
@@ -583,12 +846,13 @@ func (impl *ServerImpl) GetAuctionItems(ctx context.Context, request openapi.Get
 		return openapi.GetAuctionItems404Response{}, nil
 	}
 	output := make([]struct {
-		CurrentBid uint32    `json:"currentBid"`
-		EndTime    time.Time `json:"endTime"`
-		Id         uuid.UUID `json:"id"`
-		IsEnded    bool      `json:"isEnded"`
-		StartTime  time.Time `json:"startTime"`
-		Title      string    `json:"title"`
+		CurrentBid uint32                `json:"currentBid"`
+		EndTime    time.Time             `json:"endTime"`
+		Id         uuid.UUID             `json:"id"`
+		IsEnded    bool                  `json:"isEnded"`
+		StartTime  time.Time             `json:"startTime"`
+		Status     openapi.AuctionStatus `json:"status"`
+		Title      string                `json:"title"`
 	}, len(auctions))
 	for i, auction := range auctions {
 		if auction.CurrentBid != nil {
@@ -600,7 +864,8 @@ func (impl *ServerImpl) GetAuctionItems(ctx context.Context, request openapi.Get
 		output[i].Title = auction.Title
 		output[i].EndTime = auction.EndTime
 		output[i].StartTime = auction.StartTime
-		output[i].IsEnded = now.After(auction.EndTime)
+		output[i].Status = auction.EffectiveStatus(now)
+		output[i].IsEnded = output[i].Status == openapi.Ended || output[i].Status == openapi.Settled
 	}
 	return openapi.GetAuctionItems200JSONResponse{
 		Count: len(auctions),
@@ -653,14 +918,22 @@ func (impl *ServerImpl) PostAuthSsoProviderCallback(ctx context.Context, request
 	} else if result.Error != nil {
 		userIdentity.User = &models.User{
 			Username: token.IDToken.Name,
+			Role:     openapi.User,
 		}
 		if result := impl.db.Create(&userIdentity); result.Error != nil {
 			return nil, fmt.Errorf("[%s] Fail to create user identity, err=%w", op, result.Error)
 		}
 	}
+	// 被停權的使用者不發放token
+	if userIdentity.User.SuspendedAt != nil {
+		return openapi.PostAuthSsoProviderCallback403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
 	// 建立token
 	q4Token := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, openapi.JWT{
 		Username: userIdentity.User.Username,
+		Role:     userIdentity.User.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(impl.config.Auth.ExpireDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -738,6 +1011,14 @@ func (impl *ServerImpl) DeleteAuthSsoProviderLink(ctx context.Context, request o
 		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
 		return openapi.DeleteAuthSsoProviderLink401Response{}, nil
 	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.DeleteAuthSsoProviderLink403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
 	// 取得使用者現有的SSO綁定
 	user := models.User{ID: uuid.MustParse(token.Subject)}
 	if result := impl.db.Preload("Identities").Preload("Identities.SsoProvider").First(&user); result.Error != nil {
@@ -785,6 +1066,14 @@ func (impl *ServerImpl) PostAuthSsoProviderLink(ctx context.Context, request ope
 		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
 		return openapi.PostAuthSsoProviderLink401Response{}, nil
 	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PostAuthSsoProviderLink403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
 	// 驗證 callback 的參數和login時儲存在 secure cookie 的參數是否相同
 	var requestState, requestNonce string
 	if request.Params.RequestState != nil {
@@ -838,6 +1127,14 @@ func (impl *ServerImpl) GetUserInfo(ctx context.Context, request openapi.GetUser
 		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
 		return openapi.GetUserInfo401Response{}, nil
 	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.GetUserInfo403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
 	// 取得使用者資訊
 	userId := uuid.MustParse(token.Subject)
 	user := models.User{ID: userId}
@@ -878,6 +1175,14 @@ func (impl *ServerImpl) PatchUserInfo(ctx context.Context, request openapi.Patch
 		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
 		return openapi.PatchUserInfo401Response{}, nil
 	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PatchUserInfo403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
 	// 檢查新的使用者名稱是否合法
 	username := strings.TrimSpace(request.Body.Username)
 	if len(username) == 0 {
@@ -907,6 +1212,14 @@ func (impl *ServerImpl) PostImage(ctx context.Context, request openapi.PostImage
 		slog.Error("Fail to parse and validate JWT", slog.String("op", op), slog.Any("error", err))
 		return openapi.PostImage401Response{}, nil
 	}
+	//  - 檢查使用者是否被停權
+	if suspended, err := impl.isUserSuspended(token.Subject); err != nil {
+		return nil, fmt.Errorf("[%s] Fail to check user status, err=%w", op, err)
+	} else if suspended {
+		return openapi.PostImage403JSONResponse{
+			Message: lo.ToPtr("User is suspended"),
+		}, nil
+	}
 	//  - 檢查是否達到上傳限制
 	userId := uuid.MustParse(token.Subject)
 	var uploadedCount int64
@@ -956,6 +1269,85 @@ func (impl *ServerImpl) PostImage(ctx context.Context, request openapi.PostImage
 	}, nil
 }
 
+// bidLimiter 組合限流器和限流鍵的產生方式
+type bidLimiter struct {
+	limiter redisAdapter.IRateLimiter
+	keyFunc func(userID string, itemID uuid.UUID) string
+}
+
+// newBidLimiters 依照設定建立出價限流器，Limit 為0的規則不會建立，name 為限流器的名稱
+func newBidLimiters(config ServerConfig, newLimiter func(name string, rule RateLimitRule) (redisAdapter.IRateLimiter, error)) ([]bidLimiter, error) {
+	var bidLimiters []bidLimiter
+	if config.RateLimit.BidPerUser.Limit > 0 {
+		limiter, err := newLimiter("bid-per-user", config.RateLimit.BidPerUser)
+		if err != nil {
+			return nil, fmt.Errorf("fail to create bid per user rate limiter, err=%w", err)
+		}
+		bidLimiters = append(bidLimiters, bidLimiter{
+			limiter: limiter,
+			keyFunc: func(userID string, _ uuid.UUID) string { return userID },
+		})
+	}
+	if config.RateLimit.BidPerUserAuction.Limit > 0 {
+		limiter, err := newLimiter("bid-per-user-auction", config.RateLimit.BidPerUserAuction)
+		if err != nil {
+			return nil, fmt.Errorf("fail to create bid per user auction rate limiter, err=%w", err)
+		}
+		bidLimiters = append(bidLimiters, bidLimiter{
+			limiter: limiter,
+			keyFunc: func(userID string, itemID uuid.UUID) string { return userID + ":" + itemID.String() },
+		})
+	}
+	return bidLimiters, nil
+}
+
+// allowBid 依序檢查所有出價限流器，任何一個拒絕時返回該結果
+// NOTE: 被拒絕的請求會退回前面限流器已經記錄的次數，避免例如同一個拍賣物品的頻繁出價消耗使用者整體的出價次數
+func (impl *ServerImpl) allowBid(ctx context.Context, userID string, itemID uuid.UUID) (redisAdapter.RateLimitResult, error) {
+	type allowed struct {
+		limiter redisAdapter.IRateLimiter
+		key     string
+		token   string
+	}
+	var charged []allowed
+	refund := func() {
+		for _, a := range charged {
+			if err := a.limiter.Refund(ctx, a.key, a.token); err != nil {
+				slog.Warn("Fail to refund bid rate limit", slog.String("key", a.key), slog.Any("error", err))
+			}
+		}
+	}
+	for _, l := range impl.bidLimiters {
+		key := l.keyFunc(userID, itemID)
+		result, err := l.limiter.Allow(ctx, key)
+		if err != nil {
+			refund()
+			return redisAdapter.RateLimitResult{}, err
+		}
+		if !result.Allowed {
+			refund()
+			return result, nil
+		}
+		charged = append(charged, allowed{limiter: l.limiter, key: key, token: result.Token})
+	}
+	return redisAdapter.RateLimitResult{Allowed: true}, nil
+}
+
+// retryAfterSeconds 將等待時間轉換成 Retry-After 標頭使用的秒數(無條件進位)
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
+
+// isUserSuspended 檢查使用者是否已被停權
+// NOTE: 停權需要即時生效，所以不能依賴 access token 內的資訊，每次都需要向資料庫確認
+func (impl *ServerImpl) isUserSuspended(userID string) (bool, error) {
+	var count int64
+	if result := impl.db.Model(&models.User{}).Where("id = ? AND suspended_at IS NOT NULL", userID).Count(&count); result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
 func generateID(prefix string) (string, error) {
 	const op = "generateID"
 	bytes := make([]byte, 20)
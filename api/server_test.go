@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	memoryAdapter "q4/adapters/memory"
+	redisAdapter "q4/adapters/redis"
+	"q4/api/openapi"
+	"q4/models"
+)
+
+func TestGetAuctionItems_Pagination(t *testing.T) {
+	impl, user, _ := setupImportTest(t)
+	ctx := context.Background()
+
+	// 標題相同時以ID排序，ID依照建立的順序遞增，讓草稿和下架的拍賣物品落在游標之後
+	title := "pagination-test-" + uuid.NewString()
+	var ids []uuid.UUID
+	for _, status := range []openapi.AuctionStatus{openapi.Live, openapi.Draft, openapi.Cancelled, openapi.Live} {
+		auction := models.AuctionItem{
+			UserID:    user.ID,
+			Title:     title,
+			StartTime: time.Now().Add(-time.Hour),
+			EndTime:   time.Now().Add(time.Hour),
+			Status:    status,
+		}
+		require.NoError(t, impl.db.Create(&auction).Error)
+		ids = append(ids, auction.ID)
+	}
+
+	list := func(lastItemID *uuid.UUID) []uuid.UUID {
+		resp, err := impl.GetAuctionItems(ctx, openapi.GetAuctionItemsRequestObject{
+			Params: openapi.GetAuctionItemsParams{Title: &title, Size: lo.ToPtr(uint32(10)), LastItemID: lastItemID},
+		})
+		require.NoError(t, err)
+		if _, ok := resp.(openapi.GetAuctionItems404Response); ok {
+			return nil
+		}
+		require.IsType(t, openapi.GetAuctionItems200JSONResponse{}, resp)
+		var result []uuid.UUID
+		for _, item := range resp.(openapi.GetAuctionItems200JSONResponse).Items {
+			result = append(result, item.Id)
+		}
+		return result
+	}
+
+	assert.Equal(t, []uuid.UUID{ids[0], ids[3]}, list(nil))
+	// 分頁時仍然排除草稿和下架的拍賣物品
+	assert.Equal(t, []uuid.UUID{ids[3]}, list(&ids[0]))
+	assert.Empty(t, list(&ids[3]))
+}
+
+func TestAllowBid(t *testing.T) {
+	config := ServerConfig{RateLimit: RateLimitConfig{
+		BidPerUser:        RateLimitRule{Limit: 2, Window: time.Minute},
+		BidPerUserAuction: RateLimitRule{Limit: 1, Window: time.Minute},
+	}}
+	limiters, err := newBidLimiters(config, func(_ string, rule RateLimitRule) (redisAdapter.IRateLimiter, error) {
+		return memoryAdapter.NewRateLimiter(rule.Limit, rule.Window)
+	})
+	require.NoError(t, err)
+	impl := &ServerImpl{bidLimiters: limiters}
+	ctx := context.Background()
+	userID := uuid.NewString()
+	item1, item2, item3 := uuid.New(), uuid.New(), uuid.New()
+
+	allow := func(itemID uuid.UUID) bool {
+		result, err := impl.allowBid(ctx, userID, itemID)
+		require.NoError(t, err)
+		return result.Allowed
+	}
+	assert.True(t, allow(item1))
+	// 同一個拍賣物品被拒絕的出價不會消耗使用者整體的出價次數
+	assert.False(t, allow(item1))
+	assert.False(t, allow(item1))
+	assert.True(t, allow(item2))
+	assert.False(t, allow(item3))
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"gorm.io/gorm/clause"
+
+	"q4/api/openapi"
+	"q4/models"
+)
+
+// shillBid 代表偵測規則需要的出價資訊
+type shillBid struct {
+	UserID uuid.UUID
+	Amount uint32
+}
+
+// shillEvidence 代表偵測一筆出價時收集到的資料
+type shillEvidence struct {
+	// 本次出價
+	Bid BidInfo
+	// 出價者帳號的建立時間
+	BidderCreatedAt time.Time
+	// 拍賣物品最近的出價紀錄，依照出價順序排列，最後一筆為本次出價
+	RecentBids []shillBid
+	// 和出價者共同出價過同一賣家拍賣物品的帳號，以及共同出價的拍賣物品數量
+	CoBidders map[uuid.UUID]int
+}
+
+// shillFinding 代表規則偵測到的可疑出價模式
+type shillFinding struct {
+	Rule           openapi.ShillRule
+	RelatedUserIDs []uuid.UUID
+	Detail         string
+}
+
+// shillRules 代表可疑出價的偵測規則，門檻為0時不啟用該規則
+type shillRules struct {
+	// 帳號建立後多久內的出價會被視為新帳號出價
+	NewAccountAge time.Duration
+	// 最近幾次出價都由兩個帳號交替出價時視為可疑
+	AlternatingBids int
+	// 兩個帳號共同出價同一賣家多少個拍賣物品時視為關聯帳號
+	LinkedAuctions int
+}
+
+// Evaluate 依序執行所有啟用的規則，返回偵測到的可疑出價模式
+func (r shillRules) Evaluate(e shillEvidence) []shillFinding {
+	var findings []shillFinding
+	if finding, ok := r.detectNewAccount(e); ok {
+		findings = append(findings, finding)
+	}
+	if finding, ok := r.detectAlternatingBidders(e); ok {
+		findings = append(findings, finding)
+	}
+	if finding, ok := r.detectLinkedAccounts(e); ok {
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// detectNewAccount 偵測剛建立不久的帳號出價
+func (r shillRules) detectNewAccount(e shillEvidence) (shillFinding, bool) {
+	if r.NewAccountAge <= 0 {
+		return shillFinding{}, false
+	}
+	age := e.Bid.CreatedAt.Sub(e.BidderCreatedAt)
+	if age >= r.NewAccountAge {
+		return shillFinding{}, false
+	}
+	return shillFinding{
+		Rule:   openapi.NewAccount,
+		Detail: fmt.Sprintf("Account placed a bid of %d only %s after creation", e.Bid.Amount, age.Round(time.Second)),
+	}, true
+}
+
+// detectAlternatingBidders 偵測最近的出價都由兩個帳號輪流出價的情況
+func (r shillRules) detectAlternatingBidders(e shillEvidence) (shillFinding, bool) {
+	if r.AlternatingBids <= 0 || len(e.RecentBids) < r.AlternatingBids {
+		return shillFinding{}, false
+	}
+	bids := e.RecentBids[len(e.RecentBids)-r.AlternatingBids:]
+	users := lo.Uniq(lo.Map(bids, func(b shillBid, _ int) uuid.UUID { return b.UserID }))
+	if len(users) != 2 {
+		return shillFinding{}, false
+	}
+	for i := 1; i < len(bids); i++ {
+		if bids[i].UserID == bids[i-1].UserID {
+			return shillFinding{}, false
+		}
+	}
+	partner := users[0]
+	if partner == e.Bid.User.ID {
+		partner = users[1]
+	}
+	return shillFinding{
+		Rule:           openapi.AlternatingBidders,
+		RelatedUserIDs: []uuid.UUID{partner},
+		Detail:         fmt.Sprintf("Last %d bids alternated between two accounts, from %d to %d", len(bids), bids[0].Amount, bids[len(bids)-1].Amount),
+	}, true
+}
+
+// detectLinkedAccounts 偵測經常一起出價同一賣家拍賣物品的帳號
+func (r shillRules) detectLinkedAccounts(e shillEvidence) (shillFinding, bool) {
+	if r.LinkedAuctions <= 0 {
+		return shillFinding{}, false
+	}
+	var linked []uuid.UUID
+	for userID, count := range e.CoBidders {
+		if count >= r.LinkedAuctions {
+			linked = append(linked, userID)
+		}
+	}
+	if len(linked) == 0 {
+		return shillFinding{}, false
+	}
+	// 排序讓結果穩定，方便審查和測試
+	slices.SortFunc(linked, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	return shillFinding{
+		Rule:           openapi.LinkedAccounts,
+		RelatedUserIDs: linked,
+		Detail:         fmt.Sprintf("Account repeatedly bid together with %d account(s) on at least %d auctions of the same seller", len(linked), r.LinkedAuctions),
+	}, true
+}
+
+// runShillDetector 從出價的 stream 讀取出價，偵測可疑的出價模式並寫入審查清單
+// NOTE: 偵測器使用獨立的 consumer group，和出價同步互不影響；由於出價是異步同步到資料庫，偵測時資料庫的出價紀錄可能略為落後
+// consumer 由 ServerImpl.Close 關閉
+func (impl *ServerImpl) runShillDetector(ctx context.Context) {
+	logger := slog.Default().With(slog.String("caller", "ShillDetector"))
+	defer logger.Info("Shill detector stopped")
+	ch := impl.shillConsumer.Subscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := impl.detectShillBid(msg.Data); err != nil {
+				logger.Error("Fail to detect shill bid", slog.Int64("attempt", msg.Attempt()), slog.Any("error", err))
+				// 非嚴格順序模式下 Retry 會將出價放入延遲重試，到期後重新投遞
+				if _, err := msg.Retry(ctx, err); err != nil {
+					logger.Error("Fail to retry message", slog.Any("error", err))
+				}
+				continue
+			}
+			if err := msg.Done(ctx); err != nil {
+				logger.Error("Detect success but fail to done message", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// detectShillBid 收集出價相關的資料並執行偵測規則，偵測到的結果會寫入資料庫
+func (impl *ServerImpl) detectShillBid(bid BidInfo) error {
+	evidence, err := impl.collectShillEvidence(bid)
+	if err != nil {
+		return err
+	}
+	findings := impl.config.ShillDetection.rules().Evaluate(evidence)
+	for _, finding := range findings {
+		record := models.ShillFinding{
+			Rule:          finding.Rule,
+			AuctionItemID: bid.ItemID,
+			UserID:        bid.User.ID,
+			RelatedUserIDs: pq.StringArray(lo.Map(finding.RelatedUserIDs, func(id uuid.UUID, _ int) string {
+				return id.String()
+			})),
+			Detail: finding.Detail,
+		}
+		// 同一個使用者在同一個拍賣物品上，每種規則只記錄一次
+		result := impl.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+		if result.Error != nil {
+			return fmt.Errorf("fail to create shill finding, err=%w", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			slog.Warn("Suspicious bid detected", slog.String("rule", string(finding.Rule)), slog.String("user", bid.User.ID.String()), slog.String("itemID", bid.ItemID.String()))
+		}
+	}
+	return nil
+}
+
+// collectShillEvidence 從資料庫收集偵測規則需要的資料
+func (impl *ServerImpl) collectShillEvidence(bid BidInfo) (shillEvidence, error) {
+	evidence := shillEvidence{Bid: bid}
+	// 查詢拍賣物品和出價者
+	auction := models.AuctionItem{ID: bid.ItemID}
+	if result := impl.db.First(&auction); result.Error != nil {
+		return evidence, fmt.Errorf("fail to find auction item, err=%w", result.Error)
+	}
+	bidder := models.User{ID: bid.User.ID}
+	if result := impl.db.First(&bidder); result.Error != nil {
+		return evidence, fmt.Errorf("fail to find bidder, err=%w", result.Error)
+	}
+	evidence.BidderCreatedAt = bidder.CreatedAt
+	// 查詢拍賣物品最近的出價紀錄
+	// NOTE: 成功的出價金額一定是遞增的，只取金額低於本次出價的紀錄，就不會和已經同步到資料庫的本次出價重複
+	if n := impl.config.ShillDetection.AlternatingBids; n > 0 {
+		var bids []models.Bid
+		result := impl.db.Where("auction_item_id = ? AND amount < ?", bid.ItemID, bid.Amount).
+			Order("amount DESC").Limit(n - 1).Find(&bids)
+		if result.Error != nil {
+			return evidence, fmt.Errorf("fail to find recent bids, err=%w", result.Error)
+		}
+		slices.Reverse(bids)
+		for _, b := range bids {
+			evidence.RecentBids = append(evidence.RecentBids, shillBid{UserID: b.UserID, Amount: b.Amount})
+		}
+		evidence.RecentBids = append(evidence.RecentBids, shillBid{UserID: bid.User.ID, Amount: bid.Amount})
+	}
+	// 統計和出價者共同出價過同一賣家拍賣物品的帳號
+	if impl.config.ShillDetection.LinkedAuctions > 0 {
+		var rows []struct {
+			UserID   uuid.UUID
+			Auctions int
+		}
+		result := impl.db.Table("bids AS mine").
+			Select("other.user_id AS user_id, COUNT(DISTINCT other.auction_item_id) AS auctions").
+			Joins("JOIN bids AS other ON other.auction_item_id = mine.auction_item_id AND other.user_id <> mine.user_id AND other.deleted_at IS NULL").
+			Joins("JOIN auction_items ON auction_items.id = mine.auction_item_id").
+			Where("mine.user_id = ? AND auction_items.user_id = ? AND mine.deleted_at IS NULL", bid.User.ID, auction.UserID).
+			Group("other.user_id").
+			Scan(&rows)
+		if result.Error != nil {
+			return evidence, fmt.Errorf("fail to count co-bidders, err=%w", result.Error)
+		}
+		evidence.CoBidders = make(map[uuid.UUID]int, len(rows))
+		for _, row := range rows {
+			evidence.CoBidders[row.UserID] = row.Auctions
+		}
+	}
+	return evidence, nil
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"q4/api/openapi"
+)
+
+func TestShillRules_Evaluate(t *testing.T) {
+	now := time.Now()
+	bidder := uuid.New()
+	partner := uuid.New()
+	other := uuid.New()
+	rules := shillRules{
+		NewAccountAge:   10 * time.Minute,
+		AlternatingBids: 4,
+		LinkedAuctions:  3,
+	}
+	newBid := func(amount uint32) BidInfo {
+		return BidInfo{
+			ItemID:    uuid.New(),
+			User:      BidInfoUser{ID: bidder, Name: "bidder"},
+			Amount:    amount,
+			CreatedAt: now,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		rules    shillRules
+		evidence shillEvidence
+		want     []shillFinding
+	}{
+		{
+			name:  "正常的出價不應被標記",
+			rules: rules,
+			evidence: shillEvidence{
+				Bid:             newBid(400),
+				BidderCreatedAt: now.Add(-24 * time.Hour),
+				RecentBids: []shillBid{
+					{UserID: other, Amount: 100},
+					{UserID: partner, Amount: 200},
+					{UserID: other, Amount: 300},
+					{UserID: bidder, Amount: 400},
+				},
+				CoBidders: map[uuid.UUID]int{partner: 2},
+			},
+		},
+		{
+			name:  "剛建立的帳號出價應被標記",
+			rules: rules,
+			evidence: shillEvidence{
+				Bid:             newBid(100),
+				BidderCreatedAt: now.Add(-3 * time.Minute),
+				RecentBids:      []shillBid{{UserID: bidder, Amount: 100}},
+			},
+			want: []shillFinding{{
+				Rule:   openapi.NewAccount,
+				Detail: "Account placed a bid of 100 only 3m0s after creation",
+			}},
+		},
+		{
+			name:  "兩個帳號交替出價應被標記",
+			rules: rules,
+			evidence: shillEvidence{
+				Bid:             newBid(400),
+				BidderCreatedAt: now.Add(-24 * time.Hour),
+				RecentBids: []shillBid{
+					{UserID: other, Amount: 50},
+					{UserID: partner, Amount: 100},
+					{UserID: bidder, Amount: 200},
+					{UserID: partner, Amount: 300},
+					{UserID: bidder, Amount: 400},
+				},
+			},
+			want: []shillFinding{{
+				Rule:           openapi.AlternatingBidders,
+				RelatedUserIDs: []uuid.UUID{partner},
+				Detail:         "Last 4 bids alternated between two accounts, from 100 to 400",
+			}},
+		},
+		{
+			name:  "出價次數不足時不應判斷交替出價",
+			rules: rules,
+			evidence: shillEvidence{
+				Bid:             newBid(400),
+				BidderCreatedAt: now.Add(-24 * time.Hour),
+				RecentBids: []shillBid{
+					{UserID: partner, Amount: 300},
+					{UserID: bidder, Amount: 400},
+				},
+			},
+		},
+		{
+			name:  "同一帳號連續出價不應判斷為交替出價",
+			rules: rules,
+			evidence: shillEvidence{
+				Bid:             newBid(400),
+				BidderCreatedAt: now.Add(-24 * time.Hour),
+				RecentBids: []shillBid{
+					{UserID: partner, Amount: 100},
+					{UserID: bidder, Amount: 200},
+					{UserID: bidder, Amount: 300},
+					{UserID: bidder, Amount: 400},
+				},
+			},
+		},
+		{
+			name:  "經常共同出價同一賣家的帳號應被標記",
+			rules: rules,
+			evidence: shillEvidence{
+				Bid:             newBid(400),
+				BidderCreatedAt: now.Add(-24 * time.Hour),
+				CoBidders:       map[uuid.UUID]int{partner: 3, other: 1},
+			},
+			want: []shillFinding{{
+				Rule:           openapi.LinkedAccounts,
+				RelatedUserIDs: []uuid.UUID{partner},
+				Detail:         "Account repeatedly bid together with 1 account(s) on at least 3 auctions of the same seller",
+			}},
+		},
+		{
+			name:  "門檻為0時不啟用規則",
+			rules: shillRules{},
+			evidence: shillEvidence{
+				Bid:             newBid(400),
+				BidderCreatedAt: now,
+				RecentBids: []shillBid{
+					{UserID: partner, Amount: 100},
+					{UserID: bidder, Amount: 200},
+				},
+				CoBidders: map[uuid.UUID]int{partner: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rules.Evaluate(tt.evidence)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	memoryAdapter "q4/adapters/memory"
+	pgAdapter "q4/adapters/postgres"
+	redisAdapter "q4/adapters/redis"
+	"q4/adapters/sse"
+	"q4/api/openapi"
+)
+
+// SSE 推播出價事件的方式
+const (
+	// 每個實例讀取出價 stream
+	SSEBackendRedis = "redis"
+	// 出價成功後以 PostgreSQL 的 NOTIFY 發送，每個實例以 LISTEN 接收
+	SSEBackendPostgres = "postgres"
+	// 出價成功後在程序內發送，只適用於單一實例，單一程序模式下預設使用
+	SSEBackendMemory = "memory"
+)
+
+// SSE 連線的佇列已滿時的處理方式，參考 sse.OverflowPolicy
+const (
+	// 丟棄最舊的出價事件
+	SSEOverflowDropOldest = "drop-oldest"
+	// 只保留最新的出價事件，也就是最新的價格
+	SSEOverflowCoalesce = "coalesce"
+	// 中斷連線，瀏覽器重新連線時以 Last-Event-ID 補送遺漏的出價
+	SSEOverflowDisconnect = "disconnect"
+)
+
+// sseMetrics SSE 推播的指標，channels 為每個拍賣物品的 sse.ChannelStats
+var sseMetrics = newMetricsMap("sse")
+
+// sseOverflowPolicy 取得設定對應的 sse.OverflowPolicy
+func sseOverflowPolicy(name string) (sse.OverflowPolicy, error) {
+	switch name {
+	case SSEOverflowDropOldest:
+		return sse.OverflowDropOldest, nil
+	case SSEOverflowCoalesce:
+		return sse.OverflowCoalesce, nil
+	case SSEOverflowDisconnect:
+		return sse.OverflowDisconnect, nil
+	}
+	return 0, fmt.Errorf("unknown SSE overflow policy %q", name)
+}
+
+// bidEvent SSE 推播的出價事件
+type bidEvent struct {
+	// 出價在出價 stream 中的ID，作為 SSE 的事件ID，重新連線時以 Last-Event-ID 補送離線期間的出價
+	ID    string           `json:"id"`
+	Event openapi.BidEvent `json:"event"`
+}
+
+// newBidEvent 建立出價事件，id 為出價在出價 stream 中的ID
+func newBidEvent(id string, bid BidInfo) bidEvent {
+	return bidEvent{
+		ID:    id,
+		Event: openapi.BidEvent{Bid: bid.Amount, User: bid.User.Name, Time: bid.CreatedAt},
+	}
+}
+
+// bidEventSource SSE 推播的出價事件來源
+type bidEventSource interface {
+	sse.Subscriber[sse.PublishRequest[bidEvent]]
+	Start()
+	Close()
+}
+
+// newBidEventSource 依照設定建立 SSE 推播的出價事件來源，postgres 和 memory 模式下同時返回發送出價事件的 Publisher
+func newBidEventSource(config ServerConfig, redisClient redis.UniversalClient, db *gorm.DB, dsn string, bidStreams []string) (bidEventSource, sse.Publisher[sse.PublishRequest[bidEvent]], error) {
+	switch config.SSE.Backend {
+	case SSEBackendRedis:
+		consumer, err := newBidStreamConsumer(config, redisClient, bidStreams)
+		return consumer, nil, err
+	case SSEBackendPostgres:
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, nil, fmt.Errorf("fail to get database connection, err=%w", err)
+		}
+		notifier, err := pgAdapter.NewNotifier[sse.PublishRequest[bidEvent]](
+			sqlDB,
+			dsn,
+			config.SSE.PostgresChannel,
+			pgAdapter.WithNotifierLogger(slog.Default()),
+			pgAdapter.WithNotifierSpillTable(config.DB.Schema+".notification_payloads"),
+			pgAdapter.WithNotifierSpillTTL(config.SSE.PostgresSpillTTL),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fail to create notifier, err=%w", err)
+		}
+		return notifier, notifier, nil
+	case SSEBackendMemory:
+		pubSub := memoryAdapter.NewPubSub[sse.PublishRequest[bidEvent]]()
+		return pubSub, pubSub, nil
+	}
+	return nil, nil, fmt.Errorf("unknown SSE backend %q", config.SSE.Backend)
+}
+
+// newBidStreamConsumer 建立讀取出價 stream 的 consumer
+//   - 每個分區的stream各自使用一個consumer，再合併成一個
+//   - 每個實例各自保存讀取位置，重新啟動後不會遺漏停機期間的出價
+func newBidStreamConsumer(config ServerConfig, redisClient redis.UniversalClient, bidStreams []string) (redisAdapter.IConsumer[sse.PublishRequest[bidEvent]], error) {
+	var checkpointStore redisAdapter.CheckpointStore
+	if config.Redis.CheckpointInterval > 0 {
+		var err error
+		checkpointStore, err = redisAdapter.NewRedisCheckpointStore(redisClient, config.Redis.KeyPrefix+"checkpoint:"+config.ID)
+		if err != nil {
+			return nil, fmt.Errorf("fail to create checkpoint store, err=%w", err)
+		}
+	}
+	consumers := make([]redisAdapter.IConsumer[sse.PublishRequest[bidEvent]], len(bidStreams))
+	for i, stream := range bidStreams {
+		consumerOptions := []redisAdapter.ConsumerOption[sse.PublishRequest[bidEvent]]{
+			redisAdapter.WithConsumerRetryDelay[sse.PublishRequest[bidEvent]](config.Redis.RetryDelay),
+			redisAdapter.WithConsumerParseMessageFunc(func(id string, m map[string]any) (sse.PublishRequest[bidEvent], error) {
+				bidInfo, err := bidInfoSchema.Decode(m)
+				if err != nil {
+					return sse.PublishRequest[bidEvent]{}, fmt.Errorf("fail to parse message to sse.PublishRequest[bidEvent], err=%w", err)
+				}
+				return sse.PublishRequest[bidEvent]{
+					Channel: bidInfo.ItemID.String(),
+					Message: newBidEvent(id, bidInfo),
+				}, nil
+			}),
+		}
+		if checkpointStore != nil {
+			consumerOptions = append(consumerOptions, redisAdapter.WithConsumerCheckpoint[sse.PublishRequest[bidEvent]](checkpointStore, stream, config.Redis.CheckpointInterval))
+		}
+		var err error
+		consumers[i], err = redisAdapter.NewConsumer(redisClient, stream, consumerOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("fail to create consumer, err=%w", err)
+		}
+	}
+	consumer, err := redisAdapter.MergeConsumers(consumers...)
+	if err != nil {
+		return nil, fmt.Errorf("fail to merge consumers, err=%w", err)
+	}
+	return consumer, nil
+}
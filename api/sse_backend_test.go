@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"q4/adapters/sse"
+)
+
+func TestNewBidEventSource(t *testing.T) {
+	client := redis.NewClient(&redis.Options{})
+	defer client.Close()
+	config := ServerConfig{
+		Redis: RedisConfig{StreamKeys: RedisStreamKeys{BidStream: "bid-stream"}, StreamPartitions: 2},
+	}
+
+	// redis 模式下讀取出價 stream，不需要 Publisher
+	config.SSE.Backend = SSEBackendRedis
+	source, publisher, err := newBidEventSource(config, client, nil, "", bidStreamKeys(config.Redis))
+	require.NoError(t, err)
+	assert.NotNil(t, source)
+	assert.Nil(t, publisher)
+
+	config.SSE.Backend = "kafka"
+	_, _, err = newBidEventSource(config, client, nil, "", bidStreamKeys(config.Redis))
+	assert.Error(t, err)
+}
+
+func TestNewBidEventSource_EventID(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	config := ServerConfig{
+		Redis: RedisConfig{StreamKeys: RedisStreamKeys{BidStream: "bid-stream"}, StreamPartitions: 2, ExpireTime: time.Hour},
+	}
+	config.SSE.Backend = SSEBackendRedis
+	source, _, err := newBidEventSource(config, client, nil, "", bidStreamKeys(config.Redis))
+	require.NoError(t, err)
+	source.Start()
+	defer source.Close()
+
+	// 推播的出價事件以出價在 stream 中的ID作為事件ID
+	bid := BidInfo{ItemID: uuid.New(), User: BidInfoUser{ID: uuid.New(), Name: "TestUser"}, Amount: 100, CreatedAt: time.Now()}
+	store := &redisBidStore{client: client, config: config.Redis}
+	// 等待 consumer 開始讀取後再出價
+	time.Sleep(100 * time.Millisecond)
+	_, id, err := store.PlaceBid(context.Background(), bid, 0, time.Now().Add(time.Hour), nil)
+	require.NoError(t, err)
+
+	select {
+	case request := <-source.Subscribe():
+		assert.Equal(t, bid.ItemID.String(), request.Channel)
+		assert.Equal(t, id, request.Message.ID)
+		assert.Equal(t, uint32(100), request.Message.Event.Bid)
+		assert.Equal(t, "TestUser", request.Message.Event.User)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for bid event")
+	}
+}
+
+func TestSSEOverflowPolicy(t *testing.T) {
+	for name, want := range map[string]sse.OverflowPolicy{
+		SSEOverflowDropOldest: sse.OverflowDropOldest,
+		SSEOverflowCoalesce:   sse.OverflowCoalesce,
+		SSEOverflowDisconnect: sse.OverflowDisconnect,
+	} {
+		policy, err := sseOverflowPolicy(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, policy)
+	}
+	_, err := sseOverflowPolicy("block")
+	assert.Error(t, err)
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	redisAdapter "q4/adapters/redis"
+)
+
+// streamRetentionMetrics 修剪 stream 的指標
+//   - runs: 修剪的次數
+//   - lastRunAt: 最後一次修剪完成的時間(Unix秒)
+//   - trimmed: 每個 stream 累計被刪除的訊息數量
+var (
+	streamRetentionMetrics   = newMetricsMap("streamRetention")
+	streamRetentionRuns      = new(expvar.Int)
+	streamRetentionLastRunAt = new(expvar.Int)
+	streamRetentionTrimmed   = new(expvar.Map)
+)
+
+func init() {
+	streamRetentionMetrics.Set("runs", streamRetentionRuns)
+	streamRetentionMetrics.Set("lastRunAt", streamRetentionLastRunAt)
+	streamRetentionMetrics.Set("trimmed", streamRetentionTrimmed)
+}
+
+// parseStreamID 解析 stream 訊息的ID
+func parseStreamID(id string) (ms, seq uint64, err error) {
+	msPart, seqPart, ok := strings.Cut(id, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid stream id %q", id)
+	}
+	if ms, err = strconv.ParseUint(msPart, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid stream id %q", id)
+	}
+	if seq, err = strconv.ParseUint(seqPart, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid stream id %q", id)
+	}
+	return ms, seq, nil
+}
+
+// lessStreamID 比較兩個 stream 訊息的ID
+func lessStreamID(a, b string) (bool, error) {
+	aMs, aSeq, err := parseStreamID(a)
+	if err != nil {
+		return false, err
+	}
+	bMs, bSeq, err := parseStreamID(b)
+	if err != nil {
+		return false, err
+	}
+	return aMs < bMs || (aMs == bMs && aSeq < bSeq), nil
+}
+
+// streamAgeID 取得保留時間對應的 stream ID，maxAge 小於等於0時返回 "0-0" 表示不限制
+func streamAgeID(now time.Time, maxAge time.Duration) string {
+	if maxAge <= 0 {
+		return "0-0"
+	}
+	return fmt.Sprintf("%d-0", now.Add(-maxAge).UnixMilli())
+}
+
+// streamSafeTrimID 取得 stream 可以安全刪除的上限ID，小於這個ID的訊息都已經被所有 consumer group 讀取並確認
+//   - 有 pending 的紀錄時，上限是最早的 pending 紀錄
+//   - 沒有 pending 的紀錄時，上限是最後一筆被讀取的紀錄的下一個ID
+//   - 沒有任何 consumer group 時無法判斷訊息是否已經被同步，返回 "0-0" 表示不刪除任何訊息
+//
+// stream 不存在時返回空字串
+func streamSafeTrimID(ctx context.Context, client redis.Cmdable, stream string) (string, error) {
+	groups, err := client.XInfoGroups(ctx, stream).Result()
+	if err != nil {
+		if strings.Contains(err.Error(), "no such key") {
+			return "", nil
+		}
+		return "", fmt.Errorf("fail to get consumer groups, err=%w", err)
+	}
+	safeID := ""
+	for _, group := range groups {
+		groupID := group.LastDeliveredID
+		if group.Pending > 0 {
+			pending, err := client.XPending(ctx, stream, group.Name).Result()
+			if err != nil {
+				return "", fmt.Errorf("fail to get pending entries of %s, err=%w", group.Name, err)
+			}
+			groupID = pending.Lower
+		} else {
+			ms, seq, err := parseStreamID(groupID)
+			if err != nil {
+				return "", err
+			}
+			groupID = fmt.Sprintf("%d-%d", ms, seq+1)
+		}
+		if safeID == "" {
+			safeID = groupID
+		} else if less, err := lessStreamID(groupID, safeID); err != nil {
+			return "", err
+		} else if less {
+			safeID = groupID
+		}
+	}
+	if safeID == "" {
+		return "0-0", nil
+	}
+	return safeID, nil
+}
+
+// runStreamRetention 定期依照保留策略修剪出價 stream 和 dead-letter stream，同一時間只會有一個實例執行
+func (impl *ServerImpl) runStreamRetention(ctx context.Context) {
+	logger := slog.Default().With(slog.String("caller", "StreamRetention"))
+	defer logger.Info("Stream retention worker stopped")
+	mutex := impl.newMutex("stream-retention")
+	for {
+		lockCtx, err := mutex.Lock(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("Fail to acquire lock", slog.Any("error", err))
+			continue
+		}
+		logger.Info("Acquire lock, start trimming streams")
+		ticker := time.NewTicker(impl.config.StreamRetention.Interval)
+	LOOP:
+		for {
+			trimmed, err := impl.trimBidStreams(lockCtx, time.Now())
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error("Fail to trim streams", slog.Any("error", err))
+			} else if err == nil {
+				logger.Info("Streams trimmed", slog.Any("trimmed", trimmed))
+			}
+			select {
+			case <-lockCtx.Done():
+				break LOOP
+			case <-ticker.C:
+			}
+		}
+		ticker.Stop()
+		if _, err := mutex.Unlock(); err != nil {
+			logger.Warn("Fail to release lock", slog.Any("error", err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// trimBidStreams 修剪所有分區的出價 stream 和 dead-letter stream，返回每個 stream 被刪除的訊息數量
+//   - 出價 stream 只會刪除已經被所有 consumer group 確認的訊息，避免尚未同步的出價遺失
+//   - dead-letter stream 沒有 consumer group，只依照保留時間和最大筆數修剪
+func (impl *ServerImpl) trimBidStreams(ctx context.Context, now time.Time) (map[string]int64, error) {
+	config := impl.config.StreamRetention
+	result := map[string]int64{}
+	trim := func(stream, safeID string, maxAge time.Duration, maxLen int64) error {
+		if maxAge <= 0 && maxLen <= 0 {
+			return nil
+		}
+		count, err := TrimStreamScript.Run(ctx, impl.redisClient, []string{stream}, safeID, streamAgeID(now, maxAge), maxLen).Int64()
+		if err != nil {
+			return fmt.Errorf("fail to trim %s, err=%w", stream, err)
+		}
+		result[stream] = count
+		streamRetentionTrimmed.Add(stream, count)
+		return nil
+	}
+	for _, stream := range bidStreamKeys(impl.config.Redis) {
+		safeID, err := streamSafeTrimID(ctx, impl.redisClient, stream)
+		if err != nil {
+			return result, fmt.Errorf("fail to get safe trim id of %s, err=%w", stream, err)
+		}
+		if safeID != "" {
+			if err := trim(stream, safeID, config.MaxAge, config.MaxLen); err != nil {
+				return result, err
+			}
+		}
+		if err := trim(redisAdapter.DeadLetterStream(stream), "+", config.DeadLetterMaxAge, config.DeadLetterMaxLen); err != nil {
+			return result, err
+		}
+	}
+	streamRetentionRuns.Add(1)
+	streamRetentionLastRunAt.Set(now.Unix())
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamAgeID(t *testing.T) {
+	now := time.UnixMilli(10_000)
+	assert.Equal(t, "0-0", streamAgeID(now, 0))
+	assert.Equal(t, "9000-0", streamAgeID(now, time.Second))
+}
+
+func TestStreamSafeTrimID(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+	const stream = "bid-stream"
+	read := func(group string, count int64, ack bool) {
+		streams, err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: "consumer",
+			Streams:  []string{stream, ">"},
+			Count:    count,
+		}).Result()
+		require.NoError(t, err)
+		if ack {
+			for _, message := range streams[0].Messages {
+				require.NoError(t, client.XAck(ctx, stream, group, message.ID).Err())
+			}
+		}
+	}
+
+	// stream 不存在時不修剪
+	got, err := streamSafeTrimID(ctx, client, stream)
+	require.NoError(t, err)
+	assert.Equal(t, "", got)
+
+	for _, id := range []string{"1-0", "2-0", "3-0", "4-0", "5-0"} {
+		require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: stream, ID: id, Values: map[string]any{"data": id}}).Err())
+	}
+
+	// 沒有 consumer group 時無法判斷是否已經同步
+	got, err = streamSafeTrimID(ctx, client, stream)
+	require.NoError(t, err)
+	assert.Equal(t, "0-0", got)
+
+	// 全部確認時，上限是最後一筆被讀取的紀錄的下一個ID
+	require.NoError(t, client.XGroupCreate(ctx, stream, "sync", "0").Err())
+	read("sync", 3, true)
+	got, err = streamSafeTrimID(ctx, client, stream)
+	require.NoError(t, err)
+	assert.Equal(t, "3-1", got)
+
+	// 有 pending 時，上限是最早的 pending 紀錄
+	read("sync", 1, false)
+	got, err = streamSafeTrimID(ctx, client, stream)
+	require.NoError(t, err)
+	assert.Equal(t, "4-0", got)
+
+	// 多個 consumer group 時取最小者
+	require.NoError(t, client.XGroupCreate(ctx, stream, "detector", "0").Err())
+	read("detector", 1, true)
+	got, err = streamSafeTrimID(ctx, client, stream)
+	require.NoError(t, err)
+	assert.Equal(t, "1-1", got)
+}
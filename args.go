@@ -21,6 +21,7 @@ func ParseArgs() (*Args, error) {
 	// server config
 	pflag.String("server-url", "0.0.0.0:8080", "")
 	pflag.String("instance-id", "", "")
+	pflag.Bool("in-memory", false, "")
 
 	// auth config
 	_, defaultPrivateKey, err := ed25519.GenerateKey(rand.Reader)
@@ -68,12 +69,73 @@ func ParseArgs() (*Args, error) {
 	pflag.String("redis-addr", "", "")
 	pflag.String("redis-password", "", "")
 	pflag.Int("redis-db", 15, "")
+	pflag.Bool("redis-cluster", false, "")
+	pflag.String("redis-master-name", "", "")
+	pflag.String("redis-sentinel-password", "", "")
+	pflag.Int("redis-max-retries", 0, "")
+	pflag.Duration("redis-dial-timeout", 0, "")
+	pflag.Duration("redis-read-timeout", 0, "")
+	pflag.Duration("redis-write-timeout", 0, "")
+	pflag.Int("redis-pool-size", 0, "")
+	pflag.Duration("redis-retry-delay", time.Second, "")
+	pflag.Duration("redis-checkpoint-interval", 5*time.Second, "")
+	pflag.Duration("redis-claim-min-idle", time.Minute, "")
+	pflag.Int("redis-sync-batch-size", 100, "")
+	pflag.Duration("redis-partition-heartbeat-interval", 5*time.Second, "")
+	pflag.Duration("redis-partition-instance-ttl", 15*time.Second, "")
+	pflag.Duration("redis-claim-interval", 30*time.Second, "")
+	pflag.Int64("redis-max-deliveries", 5, "")
+	pflag.Int64("redis-max-attempts", 5, "")
+	pflag.Duration("redis-retry-backoff", time.Second, "")
+	pflag.Duration("redis-retry-max-backoff", time.Minute, "")
 	pflag.Duration("redis-expire-time", 3*24*time.Hour, "")
+	pflag.Duration("redis-idempotency-expire-time", 24*time.Hour, "")
+	pflag.Duration("redis-reconcile-interval", 10*time.Minute, "")
 	pflag.String("redis-key-prefix", "q4:", "")
 	pflag.String("redis-consumer-group", "q4-bid-group", "")
 
 	// redis stream keys
 	pflag.String("redis-stream-key-for-bid", "q4-shared-bid-stream", "")
+	pflag.String("redis-stream-key-for-auction-event", "q4-shared-auction-event-stream", "")
+	pflag.Int("redis-stream-partitions", 1, "")
+
+	// rate limit config
+	pflag.Int64("rate-limit-bid-per-user-limit", 20, "")
+	pflag.Duration("rate-limit-bid-per-user-window", 10*time.Second, "")
+	pflag.Int64("rate-limit-bid-per-user-auction-limit", 5, "")
+	pflag.Duration("rate-limit-bid-per-user-auction-window", 5*time.Second, "")
+
+	// sse config
+	pflag.String("sse-backend", "redis", "")
+	pflag.String("sse-postgres-channel", "q4_bid_events", "")
+	pflag.Duration("sse-postgres-spill-ttl", time.Hour, "")
+	pflag.Int("sse-queue-size", 64, "")
+	pflag.String("sse-overflow-policy", "disconnect", "")
+
+	// auction config
+	pflag.Duration("auction-lifecycle-interval", 30*time.Second, "")
+	pflag.Duration("auction-settle-delay", 5*time.Minute, "")
+	pflag.Uint32("auction-max-auto-relist-rounds", 3, "")
+	pflag.Int("auction-import-max-rows", 1000, "")
+	pflag.Int("auction-import-chunk-size", 100, "")
+
+	// shill detection config
+	pflag.Bool("shill-detection-enabled", true, "")
+	pflag.String("shill-detection-consumer-group", "q4-shill-detection-group", "")
+	pflag.Duration("shill-detection-new-account-age", 10*time.Minute, "")
+	pflag.Int("shill-detection-alternating-bids", 6, "")
+	pflag.Int("shill-detection-linked-auctions", 3, "")
+
+	// stream retention config
+	pflag.Duration("stream-retention-interval", 10*time.Minute, "")
+	pflag.Duration("stream-retention-max-age", 24*time.Hour, "")
+	pflag.Int64("stream-retention-max-len", 100000, "")
+	pflag.Duration("stream-retention-dead-letter-max-age", 30*24*time.Hour, "")
+	pflag.Int64("stream-retention-dead-letter-max-len", 10000, "")
+
+	// outbox config
+	pflag.Duration("outbox-interval", time.Second, "")
+	pflag.Int("outbox-batch-size", 100, "")
 
 	// bind pflag to viper
 	pflag.Parse()
@@ -109,7 +171,8 @@ func ParseArgs() (*Args, error) {
 	return &Args{
 		ServerURL: viper.GetString("server-url"),
 		ServerConfig: api.ServerConfig{
-			ID: viper.GetString("instance-id"),
+			ID:       viper.GetString("instance-id"),
+			InMemory: viper.GetBool("in-memory"),
 			Auth: api.AuthConfig{
 				Issuer:         viper.GetString("auth-issuer"),
 				PrivateKey:     authPrivateKey,
@@ -136,16 +199,81 @@ func ParseArgs() (*Args, error) {
 				Schema:   viper.GetString("db-schema"),
 			},
 			Redis: api.RedisConfig{
-				Addr:          viper.GetString("redis-addr"),
-				Password:      viper.GetString("redis-password"),
-				DB:            viper.GetInt("redis-db"),
-				ExpireTime:    viper.GetDuration("redis-expire-time"),
-				KeyPrefix:     viper.GetString("redis-key-prefix"),
-				ConsumerGroup: viper.GetString("redis-consumer-group"),
+				Addr:                       viper.GetString("redis-addr"),
+				Password:                   viper.GetString("redis-password"),
+				DB:                         viper.GetInt("redis-db"),
+				Cluster:                    viper.GetBool("redis-cluster"),
+				MasterName:                 viper.GetString("redis-master-name"),
+				SentinelPassword:           viper.GetString("redis-sentinel-password"),
+				MaxRetries:                 viper.GetInt("redis-max-retries"),
+				DialTimeout:                viper.GetDuration("redis-dial-timeout"),
+				ReadTimeout:                viper.GetDuration("redis-read-timeout"),
+				WriteTimeout:               viper.GetDuration("redis-write-timeout"),
+				PoolSize:                   viper.GetInt("redis-pool-size"),
+				RetryDelay:                 viper.GetDuration("redis-retry-delay"),
+				CheckpointInterval:         viper.GetDuration("redis-checkpoint-interval"),
+				ClaimMinIdle:               viper.GetDuration("redis-claim-min-idle"),
+				ClaimInterval:              viper.GetDuration("redis-claim-interval"),
+				MaxDeliveries:              viper.GetInt64("redis-max-deliveries"),
+				MaxAttempts:                viper.GetInt64("redis-max-attempts"),
+				RetryBackoff:               viper.GetDuration("redis-retry-backoff"),
+				RetryMaxBackoff:            viper.GetDuration("redis-retry-max-backoff"),
+				ExpireTime:                 viper.GetDuration("redis-expire-time"),
+				IdempotencyExpireTime:      viper.GetDuration("redis-idempotency-expire-time"),
+				ReconcileInterval:          viper.GetDuration("redis-reconcile-interval"),
+				KeyPrefix:                  viper.GetString("redis-key-prefix"),
+				ConsumerGroup:              viper.GetString("redis-consumer-group"),
+				SyncBatchSize:              viper.GetInt("redis-sync-batch-size"),
+				PartitionHeartbeatInterval: viper.GetDuration("redis-partition-heartbeat-interval"),
+				PartitionInstanceTTL:       viper.GetDuration("redis-partition-instance-ttl"),
 				StreamKeys: api.RedisStreamKeys{
-					BidStream: viper.GetString("redis-stream-key-for-bid"),
+					BidStream:          viper.GetString("redis-stream-key-for-bid"),
+					AuctionEventStream: viper.GetString("redis-stream-key-for-auction-event"),
+				},
+				StreamPartitions: viper.GetInt("redis-stream-partitions"),
+			},
+			RateLimit: api.RateLimitConfig{
+				BidPerUser: api.RateLimitRule{
+					Limit:  viper.GetInt64("rate-limit-bid-per-user-limit"),
+					Window: viper.GetDuration("rate-limit-bid-per-user-window"),
+				},
+				BidPerUserAuction: api.RateLimitRule{
+					Limit:  viper.GetInt64("rate-limit-bid-per-user-auction-limit"),
+					Window: viper.GetDuration("rate-limit-bid-per-user-auction-window"),
 				},
 			},
+			SSE: api.SSEConfig{
+				Backend:          viper.GetString("sse-backend"),
+				PostgresChannel:  viper.GetString("sse-postgres-channel"),
+				PostgresSpillTTL: viper.GetDuration("sse-postgres-spill-ttl"),
+				QueueSize:        viper.GetInt("sse-queue-size"),
+				OverflowPolicy:   viper.GetString("sse-overflow-policy"),
+			},
+			Auction: api.AuctionConfig{
+				LifecycleInterval:   viper.GetDuration("auction-lifecycle-interval"),
+				SettleDelay:         viper.GetDuration("auction-settle-delay"),
+				MaxAutoRelistRounds: viper.GetUint32("auction-max-auto-relist-rounds"),
+				ImportMaxRows:       viper.GetInt("auction-import-max-rows"),
+				ImportChunkSize:     viper.GetInt("auction-import-chunk-size"),
+			},
+			ShillDetection: api.ShillDetectionConfig{
+				Enabled:         viper.GetBool("shill-detection-enabled"),
+				ConsumerGroup:   viper.GetString("shill-detection-consumer-group"),
+				NewAccountAge:   viper.GetDuration("shill-detection-new-account-age"),
+				AlternatingBids: viper.GetInt("shill-detection-alternating-bids"),
+				LinkedAuctions:  viper.GetInt("shill-detection-linked-auctions"),
+			},
+			StreamRetention: api.StreamRetentionConfig{
+				Interval:         viper.GetDuration("stream-retention-interval"),
+				MaxAge:           viper.GetDuration("stream-retention-max-age"),
+				MaxLen:           viper.GetInt64("stream-retention-max-len"),
+				DeadLetterMaxAge: viper.GetDuration("stream-retention-dead-letter-max-age"),
+				DeadLetterMaxLen: viper.GetInt64("stream-retention-dead-letter-max-len"),
+			},
+			Outbox: api.OutboxConfig{
+				Interval:  viper.GetDuration("outbox-interval"),
+				BatchSize: viper.GetInt("outbox-batch-size"),
+			},
 		},
 	}, nil
 }
@@ -31,6 +31,7 @@ func main() {
 	router := gin.Default()
 	handler := openapi.NewStrictHandler(strictServer, nil)
 	openapi.RegisterHandlers(router, handler)
+	router.GET("/metrics", gin.WrapH(api.MetricsHandler()))
 	if err := router.Run(args.ServerURL); err != nil {
 		panic(err)
 	}
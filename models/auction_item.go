@@ -6,25 +6,82 @@ import (
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"gorm.io/gorm"
+
+	"q4/api/openapi"
 )
 
 // AuctionItem 代表拍賣系統中的商品
-// 包含商品資訊、起標價、目前最高出價、拍賣時間等資訊
+// 包含商品資訊、起標價、底價、目前最高出價、拍賣時間以及生命週期狀態等資訊
+// 流標的拍賣物品重新上架時，會記錄來源的拍賣物品
 type AuctionItem struct {
 	gorm.Model
 
-	ID            uuid.UUID      `gorm:"type:uuid;default:public.uuid_generate_v7();primaryKey;<-:false"`
-	UserID        uuid.UUID      `gorm:"type:uuid;<-:create"`
-	Title         string         `gorm:"type:varchar(255);not null"`
-	Description   string         `gorm:"type:text;not null"`
-	StartingPrice uint32         `gorm:"type:integer;not null"`
-	CurrentBidID  *uuid.UUID     `gorm:"type:uuid;"`
-	StartTime     time.Time      `gorm:"type:timestamp with time zone;not null"`
-	EndTime       time.Time      `gorm:"type:timestamp with time zone;not null"`
-	Carousels     pq.StringArray `gorm:"type:text[];default:'{}'"`
+	ID            uuid.UUID             `gorm:"type:uuid;default:public.uuid_generate_v7();primaryKey;<-:false"`
+	UserID        uuid.UUID             `gorm:"type:uuid;<-:create"`
+	Title         string                `gorm:"type:varchar(255);not null"`
+	Description   string                `gorm:"type:text;not null"`
+	StartingPrice uint32                `gorm:"type:integer;not null"`
+	CurrentBidID  *uuid.UUID            `gorm:"type:uuid;"`
+	StartTime     time.Time             `gorm:"type:timestamp with time zone;not null"`
+	EndTime       time.Time             `gorm:"type:timestamp with time zone;not null"`
+	Carousels     pq.StringArray        `gorm:"type:text[];default:'{}'"`
+	Status        openapi.AuctionStatus `gorm:"type:text;not null;default:'draft';index"`
+	// 底價，最高出價未達底價時視為流標，0表示沒有底價
+	ReservePrice uint32 `gorm:"type:integer;not null;default:0"`
+	// 流標時剩餘的自動重新上架次數
+	AutoRelistRounds uint32     `gorm:"type:integer;not null;default:0"`
+	RelistedFromID   *uuid.UUID `gorm:"type:uuid;uniqueIndex;<-:create"`
 
 	// 外鍵關聯
-	User       User
-	CurrentBid *Bid `gorm:"foreignKey:CurrentBidID"`
-	BidRecords []Bid
+	User         User
+	CurrentBid   *Bid         `gorm:"foreignKey:CurrentBidID"`
+	RelistedFrom *AuctionItem `gorm:"foreignKey:RelistedFromID"`
+	BidRecords   []Bid
+}
+
+// EffectiveStatus 回傳拍賣物品在指定時間的生命週期狀態
+// 排程中、進行中和已結束會隨著拍賣時間自動轉換，儲存的狀態只會由背景工作定期更新，所以需要以拍賣時間為準
+func (a *AuctionItem) EffectiveStatus(now time.Time) openapi.AuctionStatus {
+	switch a.Status {
+	case openapi.Draft, openapi.Cancelled, openapi.Settled:
+		return a.Status
+	}
+	if now.Before(a.StartTime) {
+		return openapi.Scheduled
+	}
+	if now.Before(a.EndTime) {
+		return openapi.Live
+	}
+	return openapi.Ended
+}
+
+// Publish 將拍賣物品發布，依照開始時間決定是排程中或進行中
+func (a *AuctionItem) Publish(now time.Time) {
+	if now.Before(a.StartTime) {
+		a.Status = openapi.Scheduled
+	} else {
+		a.Status = openapi.Live
+	}
+}
+
+// IsUnsold 判斷拍賣物品是否流標，沒有任何出價或最高出價未達底價，需要先載入 CurrentBid
+func (a *AuctionItem) IsUnsold() bool {
+	return a.CurrentBid == nil || a.CurrentBid.Amount < a.ReservePrice
+}
+
+// WhereEffectiveStatus 回傳篩選生命週期狀態的查詢條件，判斷方式和 EffectiveStatus 一致
+func WhereEffectiveStatus(status openapi.AuctionStatus, now time.Time) func(*gorm.DB) *gorm.DB {
+	timed := []openapi.AuctionStatus{openapi.Scheduled, openapi.Live, openapi.Ended}
+	return func(db *gorm.DB) *gorm.DB {
+		switch status {
+		case openapi.Scheduled:
+			return db.Where("status IN ? AND start_time > ?", timed, now)
+		case openapi.Live:
+			return db.Where("status IN ? AND start_time <= ? AND end_time > ?", timed, now, now)
+		case openapi.Ended:
+			return db.Where("status IN ? AND start_time <= ? AND end_time <= ?", timed, now, now)
+		default:
+			return db.Where("status = ?", status)
+		}
+	}
 }
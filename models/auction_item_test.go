@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"q4/api/openapi"
+)
+
+func TestAuctionItem_EffectiveStatus(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		status    openapi.AuctionStatus
+		startTime time.Time
+		endTime   time.Time
+		want      openapi.AuctionStatus
+	}{
+		{name: "草稿不受拍賣時間影響", status: openapi.Draft, startTime: before, endTime: after, want: openapi.Draft},
+		{name: "下架不受拍賣時間影響", status: openapi.Cancelled, startTime: before, endTime: after, want: openapi.Cancelled},
+		{name: "已結算不受拍賣時間影響", status: openapi.Settled, startTime: before, endTime: before, want: openapi.Settled},
+		{name: "開始前為排程中", status: openapi.Scheduled, startTime: after, endTime: after.Add(time.Hour), want: openapi.Scheduled},
+		{name: "排程中在開始後轉為進行中", status: openapi.Scheduled, startTime: before, endTime: after, want: openapi.Live},
+		{name: "開始時間當下為進行中", status: openapi.Scheduled, startTime: now, endTime: after, want: openapi.Live},
+		{name: "進行中在結束後轉為已結束", status: openapi.Live, startTime: before.Add(-time.Hour), endTime: before, want: openapi.Ended},
+		{name: "結束時間當下為已結束", status: openapi.Live, startTime: before, endTime: now, want: openapi.Ended},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := AuctionItem{Status: tt.status, StartTime: tt.startTime, EndTime: tt.endTime}
+			assert.Equal(t, tt.want, item.EffectiveStatus(now))
+		})
+	}
+}
+
+func TestAuctionItem_Publish(t *testing.T) {
+	now := time.Now()
+
+	item := AuctionItem{Status: openapi.Draft, StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour)}
+	item.Publish(now)
+	assert.Equal(t, openapi.Scheduled, item.Status)
+
+	item = AuctionItem{Status: openapi.Draft, StartTime: now, EndTime: now.Add(time.Hour)}
+	item.Publish(now)
+	assert.Equal(t, openapi.Live, item.Status)
+}
+
+func TestAuctionItem_IsUnsold(t *testing.T) {
+	tests := []struct {
+		name         string
+		reservePrice uint32
+		currentBid   *Bid
+		want         bool
+	}{
+		{name: "沒有出價為流標", reservePrice: 0, currentBid: nil, want: true},
+		{name: "沒有底價時有出價就成交", reservePrice: 0, currentBid: &Bid{Amount: 1}, want: false},
+		{name: "出價低於底價為流標", reservePrice: 100, currentBid: &Bid{Amount: 99}, want: true},
+		{name: "出價等於底價為成交", reservePrice: 100, currentBid: &Bid{Amount: 100}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := AuctionItem{ReservePrice: tt.reservePrice, CurrentBid: tt.currentBid}
+			assert.Equal(t, tt.want, item.IsUnsold())
+		})
+	}
+}
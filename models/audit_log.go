@@ -0,0 +1,37 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditAction 代表稽核紀錄的操作類型
+type AuditAction string
+
+const (
+	AuditActionSuspendUser   AuditAction = "suspend_user"
+	AuditActionUnsuspendUser AuditAction = "unsuspend_user"
+	AuditActionCancelAuction AuditAction = "cancel_auction"
+	AuditActionRemoveBid     AuditAction = "remove_bid"
+
+	AuditActionResolveShillFinding AuditAction = "resolve_shill_finding"
+
+	AuditActionReplayDeadLetter  AuditAction = "replay_dead_letter"
+	AuditActionDiscardDeadLetter AuditAction = "discard_dead_letter"
+)
+
+// AuditLog 代表管理員操作的稽核紀錄
+// 記錄操作者、操作類型、操作對象以及操作原因
+// 操作對象不是資料庫紀錄時(例如 dead-letter 訊息)，以 TargetRef 記錄對象的識別
+type AuditLog struct {
+	gorm.Model
+
+	ID        uuid.UUID   `gorm:"type:uuid;default:public.uuid_generate_v7();primaryKey;<-:false"`
+	ActorID   uuid.UUID   `gorm:"type:uuid;not null;<-:create"`
+	Action    AuditAction `gorm:"type:text;not null;<-:create"`
+	TargetID  uuid.UUID   `gorm:"type:uuid;not null;<-:create"`
+	TargetRef string      `gorm:"type:text;not null;default:'';<-:create"`
+	Reason    string      `gorm:"type:text;not null;<-:create"`
+
+	Actor *User `gorm:"foreignKey:ActorID"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// ListingTemplate 代表賣家儲存的拍賣物品範本
+// 包含建立拍賣物品需要的商品資訊和價格設定，同一個賣家的範本名稱不能重複
+type ListingTemplate struct {
+	gorm.Model
+
+	ID               uuid.UUID      `gorm:"type:uuid;default:public.uuid_generate_v7();primaryKey;<-:false"`
+	UserID           uuid.UUID      `gorm:"type:uuid;uniqueIndex:idx_listing_template_user_id_name,where:deleted_at IS NULL;not null;<-:create"`
+	Name             string         `gorm:"type:varchar(255);uniqueIndex:idx_listing_template_user_id_name,where:deleted_at IS NULL;not null"`
+	Title            string         `gorm:"type:varchar(255);not null"`
+	Description      string         `gorm:"type:text;not null"`
+	StartingPrice    uint32         `gorm:"type:integer;not null"`
+	ReservePrice     uint32         `gorm:"type:integer;not null;default:0"`
+	Carousels        pq.StringArray `gorm:"type:text[];default:'{}'"`
+	AutoRelistRounds uint32         `gorm:"type:integer;not null;default:0"`
+
+	User *User `gorm:"foreignKey:UserID"`
+}
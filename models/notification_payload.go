@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationPayload 代表超過 NOTIFY 長度限制的通知內容
+// 發送時先寫入這個資料表，再以 NOTIFY 傳送紀錄的ID，接收端依照ID讀取內容
+// 同一則通知可能被多個實例讀取，所以讀取後不刪除，由接收端定期刪除過期的紀錄
+type NotificationPayload struct {
+	gorm.Model
+
+	ID      uuid.UUID `gorm:"type:uuid;default:public.uuid_generate_v7();primaryKey;<-:false"`
+	Channel string    `gorm:"type:text;not null;<-:create"`
+	Payload string    `gorm:"type:text;not null;<-:create"`
+}
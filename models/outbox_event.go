@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutboxEventType 代表 outbox 事件的類型
+type OutboxEventType string
+
+const (
+	OutboxEventAuctionCreated   OutboxEventType = "auction_created"
+	OutboxEventAuctionEdited    OutboxEventType = "auction_edited"
+	OutboxEventAuctionPublished OutboxEventType = "auction_published"
+	OutboxEventAuctionCancelled OutboxEventType = "auction_cancelled"
+	OutboxEventAuctionSettled   OutboxEventType = "auction_settled"
+)
+
+// OutboxEvent 代表等待發送到 Redis stream 的資料庫事件
+// 和資料的修改寫在同一個交易中，由背景工作依照ID順序發送，發送成功後記錄 SentAt
+// 發送失敗時累計 Attempts 並記錄最後一次的錯誤，下一輪會重新發送
+type OutboxEvent struct {
+	gorm.Model
+
+	ID          uuid.UUID       `gorm:"type:uuid;default:public.uuid_generate_v7();primaryKey;<-:false"`
+	AggregateID uuid.UUID       `gorm:"type:uuid;not null;index;<-:create"`
+	Type        OutboxEventType `gorm:"type:text;not null;<-:create"`
+	Payload     []byte          `gorm:"type:jsonb;not null;<-:create"`
+	SentAt      *time.Time      `gorm:"type:timestamp with time zone;index"`
+	Attempts    int             `gorm:"type:integer;not null;default:0"`
+	LastError   string          `gorm:"type:text;not null;default:''"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"q4/api/openapi"
+)
+
+// ShillFinding 代表可疑出價偵測器發現的可疑出價模式
+// 記錄觸發的規則、出價的使用者和關聯帳號，以及管理員的審查結果
+// 同一個使用者在同一個拍賣物品上，每種規則只會有一筆紀錄
+type ShillFinding struct {
+	gorm.Model
+
+	ID             uuid.UUID                  `gorm:"type:uuid;default:public.uuid_generate_v7();primaryKey;<-:false"`
+	Rule           openapi.ShillRule          `gorm:"type:text;not null;uniqueIndex:idx_shill_findings_rule_item_user;<-:create"`
+	AuctionItemID  uuid.UUID                  `gorm:"type:uuid;not null;uniqueIndex:idx_shill_findings_rule_item_user;<-:create"`
+	UserID         uuid.UUID                  `gorm:"type:uuid;not null;uniqueIndex:idx_shill_findings_rule_item_user;<-:create"`
+	RelatedUserIDs pq.StringArray             `gorm:"type:text[];default:'{}';<-:create"`
+	Detail         string                     `gorm:"type:text;not null;<-:create"`
+	Status         openapi.ShillFindingStatus `gorm:"type:text;not null;default:'pending';index"`
+	ReviewerID     *uuid.UUID                 `gorm:"type:uuid"`
+	ReviewedAt     *time.Time                 `gorm:"type:timestamp with time zone"`
+	ReviewNote     string                     `gorm:"type:text;not null;default:''"`
+
+	// 外鍵關聯
+	AuctionItem AuctionItem
+	User        User
+	Reviewer    *User `gorm:"foreignKey:ReviewerID"`
+}
@@ -1,17 +1,23 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"q4/api/openapi"
 )
 
 // User 代表拍賣系統中的使用者
-// 包含基本的使用者資訊，如使用者名稱
+// 包含基本的使用者資訊，如使用者名稱、角色以及停權狀態
 type User struct {
 	gorm.Model
 
-	ID       uuid.UUID `gorm:"type:uuid;default:public.uuid_generate_v7();primaryKey;<-:false"`
-	Username string    `gorm:"type:varchar(255);not null"`
+	ID          uuid.UUID        `gorm:"type:uuid;default:public.uuid_generate_v7();primaryKey;<-:false"`
+	Username    string           `gorm:"type:varchar(255);not null"`
+	Role        openapi.UserRole `gorm:"type:text;not null;default:'user'"`
+	SuspendedAt *time.Time       `gorm:"type:timestamp with time zone"`
 
 	Identities []UserIdentity
 }